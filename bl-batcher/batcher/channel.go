@@ -47,10 +47,10 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rcfg *
 func (s *channel) TxFailed(id txID) {
 	if data, ok := s.pendingTransactions[id]; ok {
 		s.log.Trace("marked transaction as failed", "id", id)
-		// Note: when the batcher is changed to send multiple frames per tx,
-		// this needs to be changed to iterate over all frames of the tx data
-		// and re-queue them.
-		s.channelBuilder.PushFrame(data.Frame())
+		// Re-queue all frames of the failed transaction.
+		for _, f := range data.Frames() {
+			s.channelBuilder.PushFrame(f)
+		}
 		delete(s.pendingTransactions, id)
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
@@ -127,20 +127,32 @@ func (s *channel) ID() derive.ChannelID {
 	return s.channelBuilder.ID()
 }
 
+// NextTxData returns the next tx data packet.
+// If cfg.MaxFramesPerTx > 1, it pulls up to that many frames from the channel
+// builder, which are then sent as a multi-blob transaction.
+// HasTxData must be called prior to check if there's tx data available.
 func (s *channel) NextTxData() txData {
-	frame := s.channelBuilder.NextFrame()
+	nf := s.cfg.maxFramesPerTx()
+	txdata := txData{frames: make([]frameData, 0, nf), asBlob: s.cfg.UseBlobs}
+	for i := 0; i < nf && s.channelBuilder.HasFrame(); i++ {
+		txdata.frames = append(txdata.frames, s.channelBuilder.NextFrame())
+	}
 
-	txdata := txData{frame}
 	id := txdata.ID()
-
-	s.log.Trace("returning next tx data", "id", id)
+	s.log.Trace("returning next tx data", "id", id, "num_frames", len(txdata.frames), "as_blob", txdata.asBlob)
 	s.pendingTransactions[id] = txdata
 
 	return txdata
 }
 
-func (s *channel) HasFrame() bool {
-	return s.channelBuilder.HasFrame()
+// HasTxData returns whether the channel has tx data ready to be sent. If the
+// channel is sending multiple frames per tx, it only returns true once enough
+// frames are pending or the channel is full.
+func (s *channel) HasTxData() bool {
+	if s.IsFull() || s.cfg.maxFramesPerTx() == 1 {
+		return s.channelBuilder.HasFrame()
+	}
+	return s.channelBuilder.PendingFrames() >= s.cfg.maxFramesPerTx()
 }
 
 func (s *channel) IsFull() bool {
@@ -179,6 +191,10 @@ func (s *channel) PendingFrames() int {
 	return s.channelBuilder.PendingFrames()
 }
 
+func (s *channel) PendingFramesBytes() int {
+	return s.channelBuilder.PendingFramesBytes()
+}
+
 func (s *channel) OutputFrames() error {
 	return s.channelBuilder.OutputFrames()
 }
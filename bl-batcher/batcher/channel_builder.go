@@ -10,7 +10,9 @@ import (
 	"github.com/BLASTchain/blast/bl-batcher/compressor"
 	"github.com/BLASTchain/blast/bl-node/rollup"
 	"github.com/BLASTchain/blast/bl-node/rollup/derive"
+	"github.com/BLASTchain/blast/bl-service/eth"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var (
@@ -62,8 +64,20 @@ type ChannelConfig struct {
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// UseBlobs indicates that this channel should be sent as blob transactions,
+	// each frame being posted as a separate blob.
+	UseBlobs bool
+	// MaxFramesPerTx is the maximum number of frames that are sent in a single
+	// transaction. It must be 1 for calldata transactions. For blob transactions,
+	// it is bounded by the maximum number of blobs per L1 block.
+	// A value of 0 is treated as 1.
+	MaxFramesPerTx int
 }
 
+// maxBlobsPerBlock is the maximum number of blobs that fit into a single L1 block.
+const maxBlobsPerBlock = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
 // Check validates the [ChannelConfig] parameters.
 func (cc *ChannelConfig) Check() error {
 	// The [ChannelTimeout] must be larger than the [SubSafetyMargin].
@@ -91,9 +105,33 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
 	}
 
+	if cc.MaxFramesPerTx < 0 {
+		return fmt.Errorf("max frames per tx cannot be negative: %d", cc.MaxFramesPerTx)
+	}
+
+	if cc.UseBlobs {
+		// Each frame, prefixed by the version byte, must fit into a single blob.
+		if cc.MaxFrameSize > eth.MaxBlobDataSize-1 {
+			return fmt.Errorf("max frame size %d exceeds max blob data size %d", cc.MaxFrameSize, eth.MaxBlobDataSize-1)
+		}
+		if cc.MaxFramesPerTx > maxBlobsPerBlock {
+			return fmt.Errorf("max frames per tx %d exceeds max blobs per block %d", cc.MaxFramesPerTx, maxBlobsPerBlock)
+		}
+	} else if cc.MaxFramesPerTx > 1 {
+		return fmt.Errorf("calldata transactions can only hold a single frame, got max frames per tx %d", cc.MaxFramesPerTx)
+	}
+
 	return nil
 }
 
+// maxFramesPerTx returns the effective maximum number of frames per transaction.
+func (cc *ChannelConfig) maxFramesPerTx() int {
+	if cc.MaxFramesPerTx < 1 {
+		return 1
+	}
+	return cc.MaxFramesPerTx
+}
+
 type frameID struct {
 	chID        derive.ChannelID
 	frameNumber uint16
@@ -430,6 +468,15 @@ func (c *channelBuilder) PendingFrames() int {
 	return len(c.frames)
 }
 
+// PendingFramesBytes returns the total size of the frames in the frames queue.
+func (c *channelBuilder) PendingFramesBytes() int {
+	var size int
+	for _, f := range c.frames {
+		size += len(f.data)
+	}
+	return size
+}
+
 // NextFrame returns the next available frame.
 // HasFrame must be called prior to check if there's a next frame available.
 // Panics if called when there's no next frame.
@@ -53,6 +53,15 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	blobChannelConfig := defaultTestChannelConfig
+	blobChannelConfig.UseBlobs = true
+	blobChannelConfig.MaxFramesPerTx = 6
+	largeBlobChannelConfig := blobChannelConfig
+	largeBlobChannelConfig.MaxFrameSize = eth.MaxBlobDataSize
+	manyBlobsChannelConfig := blobChannelConfig
+	manyBlobsChannelConfig.MaxFramesPerTx = 7
+	multiFrameCalldataChannelConfig := defaultTestChannelConfig
+	multiFrameCalldataChannelConfig.MaxFramesPerTx = 2
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -72,6 +81,30 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: blobChannelConfig,
+			assertion: func(output error) {
+				require.NoError(t, output)
+			},
+		},
+		{
+			input: largeBlobChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "exceeds max blob data size")
+			},
+		},
+		{
+			input: manyBlobsChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "exceeds max blobs per block")
+			},
+		},
+		{
+			input: multiFrameCalldataChannelConfig,
+			assertion: func(output error) {
+				require.ErrorContains(t, output, "calldata transactions can only hold a single frame")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
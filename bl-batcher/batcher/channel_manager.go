@@ -130,7 +130,7 @@ func (s *channelManager) removePendingChannel(channel *channel) {
 
 // nextTxData pops off s.datas & handles updating the internal state
 func (s *channelManager) nextTxData(channel *channel) (txData, error) {
-	if channel == nil || !channel.HasFrame() {
+	if channel == nil || !channel.HasTxData() {
 		s.log.Trace("no next tx data")
 		return txData{}, io.EOF // TODO: not enough data error instead
 	}
@@ -141,21 +141,22 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 
 // TxData returns the next tx data that should be submitted to L1.
 //
-// It currently only uses one frame per transaction. If the pending channel is
+// It currently only uses one frame per calldata transaction and up to
+// MaxFramesPerTx frames per blob transaction. If the pending channel is
 // full, it only returns the remaining frames of this channel until it got
-// successfully fully sent to L1. It returns io.EOF if there's no pending frame.
+// successfully fully sent to L1. It returns io.EOF if there's no pending tx data.
 func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var firstWithFrame *channel
 	for _, ch := range s.channelQueue {
-		if ch.HasFrame() {
+		if ch.HasTxData() {
 			firstWithFrame = ch
 			break
 		}
 	}
 
-	dataPending := firstWithFrame != nil && firstWithFrame.HasFrame()
+	dataPending := firstWithFrame != nil && firstWithFrame.HasTxData()
 	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
 	// Short circuit if there is a pending frame or the channel manager is closed.
@@ -307,6 +308,66 @@ func (s *channelManager) outputFrames() error {
 	return nil
 }
 
+// channelManagerStatus describes the data held by the channel manager.
+type channelManagerStatus struct {
+	pendingBlocks       int
+	pendingBytes        uint64
+	openChannels        int
+	pendingFrames       int
+	pendingTransactions int
+}
+
+// Status returns the amount of data the channel manager has yet to submit.
+func (s *channelManager) Status() channelManagerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := channelManagerStatus{
+		pendingBlocks:       len(s.blocks),
+		openChannels:        len(s.channelQueue),
+		pendingTransactions: len(s.txChannels),
+	}
+	for _, block := range s.blocks {
+		status.pendingBytes += blockDataSize(block)
+	}
+	for _, ch := range s.channelQueue {
+		status.pendingFrames += ch.PendingFrames()
+		status.pendingBytes += uint64(ch.ReadyBytes() + ch.PendingFramesBytes())
+	}
+	return status
+}
+
+// blockDataSize estimates the amount of data a block adds to a channel before compression.
+func blockDataSize(block *types.Block) uint64 {
+	var size uint64
+	for _, tx := range block.Transactions() {
+		size += tx.Size()
+	}
+	return size
+}
+
+// ChannelConfig returns the configuration used for new channels.
+func (s *channelManager) ChannelConfig() ChannelConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// UpdateChannelConfig applies update to the configuration used for new channels.
+// The update is rejected if the resulting configuration is invalid. Existing channels are unaffected.
+func (s *channelManager) UpdateChannelConfig(update func(cfg *ChannelConfig)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg := s.cfg
+	update(&cfg)
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	s.cfg = cfg
+	s.log.Info("Updated channel config", "max_channel_duration", cfg.MaxChannelDuration,
+		"target_num_frames", cfg.CompressorConfig.TargetNumFrames, "compressor", cfg.CompressorConfig.Kind)
+	return nil
+}
+
 // AddL2Block adds an L2 block to the internal blocks queue. It returns ErrReorg
 // if the block does not extend the last block loaded into the state. If no
 // blocks were added yet, the parent hash check is skipped.
@@ -413,3 +413,69 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+func TestChannelManagerStatus(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout: 10,
+		MaxFrameSize:   100,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  100,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+		},
+	}, &defaultTestRollupConfig)
+	m.Clear()
+
+	require.Equal(channelManagerStatus{}, m.Status())
+
+	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	require.NoError(m.AddL2Block(a))
+	status := m.Status()
+	require.Equal(1, status.pendingBlocks)
+	require.Equal(blockDataSize(a), status.pendingBytes)
+	require.Zero(status.openChannels)
+
+	// Once added to a channel, the block only counts with its compressed size
+	_, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	status = m.Status()
+	require.Zero(status.pendingBlocks)
+	require.Equal(1, status.openChannels)
+	require.Equal(1, status.pendingTransactions)
+	require.Equal(m.currentChannel.TotalFrames()-1, status.pendingFrames)
+	require.EqualValues(m.currentChannel.ReadyBytes()+m.currentChannel.PendingFramesBytes(), status.pendingBytes)
+}
+
+func TestChannelManagerUpdateChannelConfig(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		ChannelTimeout: 10,
+		MaxFrameSize:   120_000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  120_000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+		},
+	}, &defaultTestRollupConfig)
+
+	require.NoError(m.UpdateChannelConfig(func(cfg *ChannelConfig) {
+		cfg.MaxChannelDuration = 5
+		cfg.CompressorConfig.Kind = compressor.ShadowKind
+	}))
+	require.EqualValues(5, m.ChannelConfig().MaxChannelDuration)
+	require.Equal(compressor.ShadowKind, m.ChannelConfig().CompressorConfig.Kind)
+
+	// Invalid configs are rejected
+	require.Error(m.UpdateChannelConfig(func(cfg *ChannelConfig) {
+		cfg.MaxFramesPerTx = 2
+	}))
+	require.Equal(0, m.ChannelConfig().MaxFramesPerTx)
+
+	// New channels use the updated config
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}))
+	require.EqualValues(5, m.currentChannel.cfg.MaxChannelDuration)
+}
@@ -95,7 +95,7 @@ func TestChannelNextTxData(t *testing.T) {
 
 	// Now the nextTxData function should return the frame
 	returnedTxData, err = m.nextTxData(channel)
-	expectedTxData := txData{frame}
+	expectedTxData := txData{frames: []frameData{frame}}
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
@@ -103,6 +103,63 @@ func TestChannelNextTxData(t *testing.T) {
 	require.Equal(t, expectedTxData, channel.pendingTransactions[expectedChannelID])
 }
 
+// TestChannelNextTxDataMultiFrame checks that a blob channel packs multiple
+// frames into a single tx data and re-queues all of them on failure.
+func TestChannelNextTxDataMultiFrame(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{
+		UseBlobs:       true,
+		MaxFramesPerTx: 3,
+	}, &rollup.Config{})
+	m.Clear()
+
+	require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+	channel := m.currentChannel
+	require.NotNil(t, channel)
+
+	pushFrame := func(n uint16) {
+		channel.channelBuilder.PushFrame(frameData{
+			data: []byte{byte(n)},
+			id:   frameID{chID: channel.ID(), frameNumber: n},
+		})
+	}
+
+	// Not enough frames pending for a full blob tx while the channel is open
+	pushFrame(0)
+	pushFrame(1)
+	require.False(t, channel.HasTxData())
+	_, err := m.nextTxData(channel)
+	require.ErrorIs(t, err, io.EOF)
+
+	pushFrame(2)
+	pushFrame(3)
+	require.True(t, channel.HasTxData())
+	txdata, err := m.nextTxData(channel)
+	require.NoError(t, err)
+	require.True(t, txdata.asBlob)
+	require.Len(t, txdata.Frames(), 3)
+	require.Equal(t, uint16(0), txdata.ID().frameNumber)
+	require.Equal(t, 1, channel.PendingFrames())
+	require.Equal(t, 6, txdata.Len())
+
+	blobs, err := txdata.Blobs()
+	require.NoError(t, err)
+	require.Len(t, blobs, 3)
+	for i, blob := range blobs {
+		data, err := blob.ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data{derive.DerivationVersion0, byte(i)}, data)
+	}
+
+	// Once the channel is full, the remaining frames are returned
+	channel.Close()
+	require.True(t, channel.HasTxData())
+
+	// All frames of a failed tx are re-queued
+	m.TxFailed(txdata.ID())
+	require.Equal(t, 4, channel.PendingFrames())
+}
+
 // TestChannelTxConfirmed checks the [ChannelManager.TxConfirmed] function.
 func TestChannelTxConfirmed(t *testing.T) {
 	// Create a channel manager
@@ -129,7 +186,7 @@ func TestChannelTxConfirmed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := txData{frames: []frameData{frame}}
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
@@ -178,7 +235,7 @@ func TestChannelTxFailed(t *testing.T) {
 	m.currentChannel.channelBuilder.PushFrame(frame)
 	require.Equal(t, 1, m.currentChannel.PendingFrames())
 	returnedTxData, err := m.nextTxData(m.currentChannel)
-	expectedTxData := txData{frame}
+	expectedTxData := txData{frames: []frameData{frame}}
 	expectedChannelID := expectedTxData.ID()
 	require.NoError(t, err)
 	require.Equal(t, expectedTxData, returnedTxData)
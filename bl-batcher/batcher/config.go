@@ -1,6 +1,8 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -52,8 +54,22 @@ type CLIConfig struct {
 
 	Stopped bool
 
+	// ThrottleThreshold is the number of pending bytes above which the sequencer is throttled.
+	// If 0, throttling is disabled.
+	ThrottleThreshold uint64
+
+	// ThrottleTxSize is the maximum data availability size of a transaction while throttling.
+	ThrottleTxSize uint64
+
+	// ThrottleBlockSize is the maximum data availability size of a block while throttling.
+	ThrottleBlockSize uint64
+
 	BatchType uint
 
+	// DataAvailabilityType is one of the values defined in bl-batcher/flags/types.go and dictates
+	// the data availability type to use for posting batches, e.g. blobs vs calldata.
+	DataAvailabilityType flags.DataAvailabilityType
+
 	TxMgrConfig      txmgr.CLIConfig
 	LogConfig        oplog.CLIConfig
 	MetricsConfig    opmetrics.CLIConfig
@@ -65,6 +81,12 @@ type CLIConfig struct {
 func (c *CLIConfig) Check() error {
 	// TODO(7512): check the sanity of flags loaded directly https://github.com/BLASTchain/blast/issues/7512
 
+	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
+		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
+	}
+	if c.ThrottleThreshold != 0 && (c.ThrottleTxSize == 0 || c.ThrottleBlockSize == 0) {
+		return errors.New("throttle tx size and block size must be set when throttling is enabled")
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -95,7 +117,11 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
 		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
+		ThrottleThreshold:      ctx.Uint64(flags.ThrottleThresholdFlag.Name),
+		ThrottleTxSize:         ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
+		ThrottleBlockSize:      ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
 		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:   flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
 		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
 		LogConfig:              oplog.ReadCLIConfig(ctx),
 		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
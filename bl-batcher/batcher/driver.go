@@ -8,13 +8,16 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/bl-batcher/compressor"
 	"github.com/BLASTchain/blast/bl-batcher/metrics"
+	"github.com/BLASTchain/blast/bl-batcher/rpc"
 	"github.com/BLASTchain/blast/bl-node/rollup"
 	"github.com/BLASTchain/blast/bl-node/rollup/derive"
 	"github.com/BLASTchain/blast/bl-service/eth"
@@ -46,6 +49,8 @@ type DriverSetup struct {
 	L2Client      L2Client
 	RollupClient  RollupClient
 	ChannelConfig ChannelConfig
+	// Throttler is used to throttle the sequencer when too much data is pending. Optional.
+	Throttler ThrottleClient
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -63,6 +68,9 @@ type BatchSubmitter struct {
 	mutex   sync.Mutex
 	running bool
 
+	// throttling is true while the sequencer is throttled
+	throttling atomic.Bool
+
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
@@ -138,6 +146,59 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// Status returns the amount of data pending submission and the current channel configuration.
+func (l *BatchSubmitter) Status() rpc.BatcherStatus {
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+
+	status := l.state.Status()
+	cfg := l.state.ChannelConfig()
+	return rpc.BatcherStatus{
+		Running:             running,
+		PendingBlocks:       status.pendingBlocks,
+		PendingBytes:        status.pendingBytes,
+		OpenChannels:        status.openChannels,
+		PendingFrames:       status.pendingFrames,
+		PendingTransactions: status.pendingTransactions,
+		Throttling:          l.throttling.Load(),
+		MaxChannelDuration:  cfg.MaxChannelDuration,
+		TargetNumFrames:     cfg.CompressorConfig.TargetNumFrames,
+		CompressorKind:      cfg.CompressorConfig.Kind,
+	}
+}
+
+// SetMaxChannelDuration sets the maximum duration, in L1 blocks, of new channels. 0 disables the limit.
+func (l *BatchSubmitter) SetMaxChannelDuration(duration uint64) error {
+	return l.state.UpdateChannelConfig(func(cfg *ChannelConfig) {
+		cfg.MaxChannelDuration = duration
+	})
+}
+
+// SetTargetNumFrames sets the number of frames new channels target.
+// When posting blobs, this is also the number of frames sent per transaction.
+func (l *BatchSubmitter) SetTargetNumFrames(numFrames int) error {
+	if numFrames < 1 {
+		return fmt.Errorf("target number of frames must be at least 1, got %d", numFrames)
+	}
+	return l.state.UpdateChannelConfig(func(cfg *ChannelConfig) {
+		cfg.CompressorConfig.TargetNumFrames = numFrames
+		if cfg.UseBlobs {
+			cfg.MaxFramesPerTx = numFrames
+		}
+	})
+}
+
+// SetCompressorKind sets the kind of compressor new channels use.
+func (l *BatchSubmitter) SetCompressorKind(kind string) error {
+	if _, ok := compressor.Kinds[kind]; !ok {
+		return fmt.Errorf("unknown compressor kind: %q", kind)
+	}
+	return l.state.UpdateChannelConfig(func(cfg *ChannelConfig) {
+		cfg.CompressorConfig.Kind = kind
+	})
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -262,6 +323,7 @@ func (l *BatchSubmitter) loop() {
 				l.state.Clear()
 				continue
 			}
+			l.updateThrottling(l.shutdownCtx)
 			l.publishStateToL1(queue, receiptsCh, false)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
@@ -271,6 +333,7 @@ func (l *BatchSubmitter) loop() {
 				l.Log.Error("error closing the channel manager", "err", err)
 			}
 			l.publishStateToL1(queue, receiptsCh, true)
+			l.resetThrottling(l.killCtx)
 			return
 		}
 	}
@@ -338,20 +401,54 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
 func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
+	var candidate *txmgr.TxCandidate
+	if txdata.asBlob {
+		var err error
+		if candidate, err = l.blobTxCandidate(txdata); err != nil {
+			// We could potentially fall through and try a calldata tx instead, but this would
+			// likely result in the chain spending more in gas fees than it is tuned for, so best
+			// to just fail. We do not expect this error to trigger unless there is a serious bug
+			// or configuration issue.
+			l.Log.Error("Failed to create blob transaction candidate", "id", txdata.ID(), "err", err)
+			l.recordFailedTx(txdata.ID(), err)
+			return
+		}
+	} else {
+		candidate = l.calldataTxCandidate(txdata.Bytes())
+	}
+
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
-	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
+	intrinsicGas, err := core.IntrinsicGas(candidate.TxData, nil, false, true, true, false)
 	if err != nil {
 		l.Log.Error("Failed to calculate intrinsic gas", "error", err)
+		l.recordFailedTx(txdata.ID(), err)
 		return
 	}
+	candidate.GasLimit = intrinsicGas
+
+	queue.Send(txdata, *candidate, receiptsCh)
+}
+
+// blobTxCandidate creates a blob transaction candidate holding one blob per frame of the tx data.
+func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error) {
+	blobs, err := data.Blobs()
+	if err != nil {
+		return nil, fmt.Errorf("generating blobs for tx data: %w", err)
+	}
+	l.Log.Info("building blob transaction candidate", "size", data.Len(), "num_blobs", len(blobs))
+	return &txmgr.TxCandidate{
+		To:    &l.RollupConfig.BatchInboxAddress,
+		Blobs: blobs,
+	}, nil
+}
 
-	candidate := txmgr.TxCandidate{
-		To:       &l.RollupConfig.BatchInboxAddress,
-		TxData:   data,
-		GasLimit: intrinsicGas,
+// calldataTxCandidate creates a calldata transaction candidate with the given data.
+func (l *BatchSubmitter) calldataTxCandidate(data []byte) *txmgr.TxCandidate {
+	l.Log.Info("building calldata transaction candidate", "size", len(data))
+	return &txmgr.TxCandidate{
+		To:     &l.RollupConfig.BatchInboxAddress,
+		TxData: data,
 	}
-	queue.Send(txdata, candidate, receiptsCh)
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
@@ -14,11 +14,13 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/bl-batcher/flags"
 	"github.com/BLASTchain/blast/bl-batcher/metrics"
 	"github.com/BLASTchain/blast/bl-batcher/rpc"
 	"github.com/BLASTchain/blast/bl-node/rollup"
 	"github.com/BLASTchain/blast/bl-service/cliapp"
 	"github.com/BLASTchain/blast/bl-service/dial"
+	"github.com/BLASTchain/blast/bl-service/eth"
 	"github.com/BLASTchain/blast/bl-service/httputil"
 	opmetrics "github.com/BLASTchain/blast/bl-service/metrics"
 	oppprof "github.com/BLASTchain/blast/bl-service/pprof"
@@ -31,6 +33,7 @@ type BatcherConfig struct {
 	NetworkTimeout         time.Duration
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
+	Throttle               ThrottleConfig
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -86,6 +89,11 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.PollInterval = cfg.PollInterval
 	bs.MaxPendingTransactions = cfg.MaxPendingTransactions
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
+	bs.Throttle = ThrottleConfig{
+		Threshold: cfg.ThrottleThreshold,
+		TxSize:    cfg.ThrottleTxSize,
+		BlockSize: cfg.ThrottleBlockSize,
+	}
 
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
@@ -175,6 +183,19 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		CompressorConfig:   cfg.CompressorConfig.Config(),
 		BatchType:          cfg.BatchType,
 	}
+
+	switch cfg.DataAvailabilityType {
+	case flags.BlobsType:
+		// each frame, prefixed by the version byte, is posted as a single blob
+		bs.ChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1
+		bs.ChannelConfig.CompressorConfig.TargetFrameSize = bs.ChannelConfig.MaxFrameSize
+		bs.ChannelConfig.MaxFramesPerTx = cfg.CompressorConfig.TargetNumFrames
+		bs.ChannelConfig.UseBlobs = true
+	case flags.CalldataType:
+	default:
+		return fmt.Errorf("unknown data availability type: %v", cfg.DataAvailabilityType)
+	}
+
 	if err := bs.ChannelConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
@@ -224,6 +245,10 @@ func (bs *BatcherService) initMetricsServer(cfg *CLIConfig) error {
 }
 
 func (bs *BatcherService) initDriver() {
+	var throttler ThrottleClient
+	if bs.Throttle.Threshold != 0 {
+		throttler = NewMinerThrottleClient(bs.L2Client.Client())
+	}
 	bs.driver = NewBatchSubmitter(DriverSetup{
 		Log:           bs.Log,
 		Metr:          bs.Metrics,
@@ -234,6 +259,7 @@ func (bs *BatcherService) initDriver() {
 		L2Client:      bs.L2Client,
 		RollupClient:  bs.RollupNode,
 		ChannelConfig: bs.ChannelConfig,
+		Throttler:     throttler,
 	})
 }
 
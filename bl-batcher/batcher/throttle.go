@@ -0,0 +1,92 @@
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ThrottleClient limits the data availability size of the transactions and blocks produced by the sequencer.
+type ThrottleClient interface {
+	// SetMaxDASize sets the maximum data availability size of a transaction and of a block.
+	// A value of 0 removes the limit.
+	SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error
+}
+
+// ThrottleConfig configures when and how the sequencer is throttled.
+type ThrottleConfig struct {
+	// Threshold is the number of pending bytes above which the sequencer is throttled.
+	// If 0, throttling is disabled.
+	Threshold uint64
+	// TxSize is the maximum data availability size of a transaction while throttling.
+	TxSize uint64
+	// BlockSize is the maximum data availability size of a block while throttling.
+	BlockSize uint64
+}
+
+// MinerThrottleClient throttles the sequencer via the miner_setMaxDASize RPC of its execution engine.
+type MinerThrottleClient struct {
+	rpc *rpc.Client
+}
+
+func NewMinerThrottleClient(client *rpc.Client) *MinerThrottleClient {
+	return &MinerThrottleClient{rpc: client}
+}
+
+func (c *MinerThrottleClient) SetMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) error {
+	var success bool
+	txSize := (*hexutil.Big)(new(big.Int).SetUint64(maxTxSize))
+	blockSize := (*hexutil.Big)(new(big.Int).SetUint64(maxBlockSize))
+	if err := c.rpc.CallContext(ctx, &success, "miner_setMaxDASize", txSize, blockSize); err != nil {
+		return fmt.Errorf("failed to set max DA size: %w", err)
+	}
+	if !success {
+		return fmt.Errorf("failed to set max DA size: rejected by execution engine")
+	}
+	return nil
+}
+
+// updateThrottling throttles the sequencer when the pending data exceeds the throttle threshold,
+// and removes the limit once the backlog dropped below it again.
+func (l *BatchSubmitter) updateThrottling(ctx context.Context) {
+	if l.Throttler == nil || l.Config.Throttle.Threshold == 0 {
+		return
+	}
+	pending := l.state.Status().pendingBytes
+	throttle := pending > l.Config.Throttle.Threshold
+	if throttle == l.throttling.Load() {
+		return
+	}
+	if throttle {
+		l.Log.Warn("Pending data exceeds throttle threshold, throttling sequencer",
+			"pending_bytes", pending, "threshold", l.Config.Throttle.Threshold)
+		l.setMaxDASize(ctx, l.Config.Throttle.TxSize, l.Config.Throttle.BlockSize)
+	} else {
+		l.Log.Info("Pending data below throttle threshold, removing sequencer throttle",
+			"pending_bytes", pending, "threshold", l.Config.Throttle.Threshold)
+		l.setMaxDASize(ctx, 0, 0)
+	}
+}
+
+// resetThrottling removes the sequencer throttle if it is set.
+func (l *BatchSubmitter) resetThrottling(ctx context.Context) {
+	if l.Throttler == nil || !l.throttling.Load() {
+		return
+	}
+	l.Log.Info("Removing sequencer throttle")
+	l.setMaxDASize(ctx, 0, 0)
+}
+
+func (l *BatchSubmitter) setMaxDASize(ctx context.Context, maxTxSize uint64, maxBlockSize uint64) {
+	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	if err := l.Throttler.SetMaxDASize(ctx, maxTxSize, maxBlockSize); err != nil {
+		// The throttle state is left unchanged, so the update is retried on the next poll.
+		l.Log.Error("Failed to update sequencer throttle", "err", err)
+		return
+	}
+	l.throttling.Store(maxTxSize != 0 || maxBlockSize != 0)
+}
@@ -0,0 +1,73 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-batcher/metrics"
+	derivetest "github.com/BLASTchain/blast/bl-node/rollup/derive/test"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+type mockThrottleClient struct {
+	err   error
+	calls [][2]uint64
+}
+
+func (m *mockThrottleClient) SetMaxDASize(_ context.Context, maxTxSize uint64, maxBlockSize uint64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.calls = append(m.calls, [2]uint64{maxTxSize, maxBlockSize})
+	return nil
+}
+
+func TestUpdateThrottling(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	lgr := testlog.Logger(t, log.LvlCrit)
+	block := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	throttler := &mockThrottleClient{}
+	l := NewBatchSubmitter(DriverSetup{
+		Log:  lgr,
+		Metr: metrics.NoopMetrics,
+		Config: BatcherConfig{
+			NetworkTimeout: time.Second,
+			Throttle: ThrottleConfig{
+				Threshold: blockDataSize(block) - 1,
+				TxSize:    300,
+				BlockSize: 21_000,
+			},
+		},
+		RollupConfig: &defaultTestRollupConfig,
+		Throttler:    throttler,
+	})
+	ctx := context.Background()
+
+	l.updateThrottling(ctx)
+	require.Empty(t, throttler.calls, "should not throttle without pending data")
+
+	require.NoError(t, l.state.AddL2Block(block))
+	throttler.err = errors.New("boom")
+	l.updateThrottling(ctx)
+	require.False(t, l.throttling.Load(), "should not be throttling if the update failed")
+
+	throttler.err = nil
+	l.updateThrottling(ctx)
+	require.True(t, l.throttling.Load())
+	require.Equal(t, [][2]uint64{{300, 21_000}}, throttler.calls)
+
+	// No repeated calls while the state is unchanged
+	l.updateThrottling(ctx)
+	require.Len(t, throttler.calls, 1)
+
+	l.state.Clear()
+	l.updateThrottling(ctx)
+	require.False(t, l.throttling.Load())
+	require.Equal(t, [][2]uint64{{300, 21_000}, {0, 0}}, throttler.calls)
+}
@@ -4,47 +4,75 @@ import (
 	"fmt"
 
 	"github.com/BLASTchain/blast/bl-node/rollup/derive"
+	"github.com/BLASTchain/blast/bl-service/eth"
 )
 
 // txData represents the data for a single transaction.
 //
-// Note: The batcher currently sends exactly one frame per transaction. This
-// might change in the future to allow for multiple frames from possibly
-// different channels.
+// Note: The batcher sends one frame per calldata transaction. Blob transactions
+// may hold multiple frames of the same channel, one frame per blob.
 type txData struct {
-	frame frameData
+	frames []frameData
+	asBlob bool // indicates whether this should be sent as blob
 }
 
 // ID returns the id for this transaction data. It can be used as a map key.
 func (td *txData) ID() txID {
-	return td.frame.id
+	return td.frames[0].id
 }
 
 // Bytes returns the transaction data. It's a version byte (0) followed by the
 // concatenated frames for this transaction.
 func (td *txData) Bytes() []byte {
-	return append([]byte{derive.DerivationVersion0}, td.frame.data...)
+	size := 1
+	for _, f := range td.frames {
+		size += len(f.data)
+	}
+	out := make([]byte, 0, size)
+	out = append(out, derive.DerivationVersion0)
+	for _, f := range td.frames {
+		out = append(out, f.data...)
+	}
+	return out
 }
 
-func (td *txData) Len() int {
-	return 1 + len(td.frame.data)
+// Len returns the total number of bytes of the transaction data, including
+// one version byte per frame when sent as blobs.
+func (td *txData) Len() (l int) {
+	for _, f := range td.frames {
+		l += len(f.data)
+	}
+	if td.asBlob {
+		return l + len(td.frames)
+	}
+	return l + 1
 }
 
-// Frame returns the single frame of this tx data.
-//
-// Note: when the batcher is changed to possibly send multiple frames per tx,
-// this should be changed to a func Frames() []frameData.
-func (td *txData) Frame() frameData {
-	return td.frame
+// Blobs returns the blobs of this tx data, one per frame. Each blob holds a
+// version byte (0) followed by the frame's data.
+func (td *txData) Blobs() ([]*eth.Blob, error) {
+	blobs := make([]*eth.Blob, 0, len(td.frames))
+	for _, f := range td.frames {
+		var blob eth.Blob
+		if err := blob.FromData(append([]byte{derive.DerivationVersion0}, f.data...)); err != nil {
+			return nil, fmt.Errorf("encoding frame %v as blob: %w", f.id, err)
+		}
+		blobs = append(blobs, &blob)
+	}
+	return blobs, nil
+}
+
+// Frames returns the frames of this tx data.
+func (td *txData) Frames() []frameData {
+	return td.frames
 }
 
 // txID is an opaque identifier for a transaction.
 // It's internal fields should not be inspected after creation & are subject to change.
 // This ID must be trivially comparable & work as a map key.
 //
-// Note: transactions currently only hold a single frame, so it can be
-// identified by the frame. This needs to be changed once the batcher is changed
-// to send multiple frames per tx.
+// Note: a transaction's frames always belong to the same channel and are
+// consecutive, so it can be identified by its first frame.
 type txID = frameID
 
 func (id txID) String() string {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/BLASTchain/blast/bl-batcher/compressor"
 	opservice "github.com/BLASTchain/blast/bl-service"
+	openum "github.com/BLASTchain/blast/bl-service/enum"
 	oplog "github.com/BLASTchain/blast/bl-service/log"
 	opmetrics "github.com/BLASTchain/blast/bl-service/metrics"
 	oppprof "github.com/BLASTchain/blast/bl-service/pprof"
@@ -76,12 +77,40 @@ var (
 		Usage:   "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
 		EnvVars: prefixEnvVars("STOPPED"),
 	}
+	ThrottleThresholdFlag = &cli.Uint64Flag{
+		Name:    "throttle-threshold",
+		Usage:   "The number of pending bytes above which the sequencer is throttled via miner_setMaxDASize. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_THRESHOLD"),
+	}
+	ThrottleTxSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-tx-size",
+		Usage:   "The maximum data availability size of a transaction while the sequencer is throttled.",
+		Value:   300,
+		EnvVars: prefixEnvVars("THROTTLE_TX_SIZE"),
+	}
+	ThrottleBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-block-size",
+		Usage:   "The maximum data availability size of a block while the sequencer is throttled.",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("THROTTLE_BLOCK_SIZE"),
+	}
 	BatchTypeFlag = &cli.UintFlag{
 		Name:    "batch-type",
 		Usage:   "The batch type. 0 for SingularBatch and 1 for SpanBatch.",
 		Value:   0,
 		EnvVars: prefixEnvVars("BATCH_TYPE"),
 	}
+	DataAvailabilityTypeFlag = &cli.GenericFlag{
+		Name: "data-availability-type",
+		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
+			openum.EnumString(DataAvailabilityTypes),
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -99,8 +128,12 @@ var optionalFlags = []cli.Flag{
 	MaxChannelDurationFlag,
 	MaxL1TxSizeBytesFlag,
 	StoppedFlag,
+	ThrottleThresholdFlag,
+	ThrottleTxSizeFlag,
+	ThrottleBlockSizeFlag,
 	SequencerHDPathFlag,
 	BatchTypeFlag,
+	DataAvailabilityTypeFlag,
 }
 
 func init() {
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
@@ -10,9 +11,35 @@ import (
 	"github.com/BLASTchain/blast/bl-service/rpc"
 )
 
+// BatcherStatus describes the data the batcher has yet to submit and its current channel configuration.
+type BatcherStatus struct {
+	Running bool `json:"running"`
+	// PendingBlocks is the number of L2 blocks queued that have not been added to a channel yet.
+	PendingBlocks int `json:"pendingBlocks"`
+	// PendingBytes estimates the amount of data yet to be submitted: the transaction data of the queued
+	// L2 blocks plus the compressed channel data that hasn't been sent yet.
+	PendingBytes uint64 `json:"pendingBytes"`
+	// OpenChannels is the number of channels that haven't been fully submitted and confirmed.
+	OpenChannels int `json:"openChannels"`
+	// PendingFrames is the number of frames ready to be sent.
+	PendingFrames int `json:"pendingFrames"`
+	// PendingTransactions is the number of batcher transactions sent but not yet confirmed.
+	PendingTransactions int `json:"pendingTransactions"`
+	// Throttling is true if the batcher currently limits the data availability size of the sequencer.
+	Throttling bool `json:"throttling"`
+
+	MaxChannelDuration uint64 `json:"maxChannelDuration"`
+	TargetNumFrames    int    `json:"targetNumFrames"`
+	CompressorKind     string `json:"compressorKind"`
+}
+
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	Status() BatcherStatus
+	SetMaxChannelDuration(duration uint64) error
+	SetTargetNumFrames(numFrames int) error
+	SetCompressorKind(kind string) error
 }
 
 type adminAPI struct {
@@ -41,3 +68,22 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+func (a *adminAPI) BatcherStatus(_ context.Context) (BatcherStatus, error) {
+	return a.b.Status(), nil
+}
+
+// SetMaxChannelDuration sets the maximum duration, in L1 blocks, to keep new channels open. 0 disables the limit.
+func (a *adminAPI) SetMaxChannelDuration(_ context.Context, duration hexutil.Uint64) error {
+	return a.b.SetMaxChannelDuration(uint64(duration))
+}
+
+// SetTargetNumFrames sets the number of frames new channels target.
+func (a *adminAPI) SetTargetNumFrames(_ context.Context, numFrames int) error {
+	return a.b.SetTargetNumFrames(numFrames)
+}
+
+// SetCompressorKind sets the kind of compressor used by new channels.
+func (a *adminAPI) SetCompressorKind(_ context.Context, kind string) error {
+	return a.b.SetCompressorKind(kind)
+}
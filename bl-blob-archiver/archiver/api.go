@@ -0,0 +1,79 @@
+package archiver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+const sidecarsPathPrefix = "/eth/v1/beacon/blob_sidecars/"
+
+// apiError mirrors the error format of the Beacon API.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewAPIHandler returns a HTTP handler serving archived blob sidecars through the
+// Beacon API's /eth/v1/beacon/blob_sidecars/{block_id} endpoint, with an optional
+// indices query filter. Only numeric slots are supported as block id.
+func NewAPIHandler(l log.Logger, storage Storage) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc(sidecarsPathPrefix, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, sidecarsPathPrefix), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid block id, only slot numbers are supported")
+			return
+		}
+		indices := make(map[uint64]struct{})
+		for _, v := range r.URL.Query()["indices"] {
+			idx, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid index: "+v)
+				return
+			}
+			indices[idx] = struct{}{}
+		}
+
+		sidecars, err := storage.ReadSidecars(slot)
+		if errors.Is(err, ErrNotFound) {
+			writeError(w, http.StatusNotFound, "block not found")
+			return
+		} else if err != nil {
+			l.Error("Failed to read blob sidecars", "slot", slot, "err", err)
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		resp := eth.APIGetBlobSidecarsResponse{Data: make([]*eth.BlobSidecar, 0, len(sidecars))}
+		for _, sidecar := range sidecars {
+			if _, ok := indices[uint64(sidecar.Index)]; len(indices) == 0 || ok {
+				resp.Data = append(resp.Data, sidecar)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			l.Warn("Failed to write blob sidecars response", "slot", slot, "err", err)
+		}
+	})
+	return mux
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(apiError{Code: code, Message: msg})
+}
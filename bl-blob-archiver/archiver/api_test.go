@@ -0,0 +1,51 @@
+package archiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/client"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/sources"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+func TestAPIHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	storage, err := NewDiskStorage(t.TempDir())
+	require.NoError(t, err)
+	sidecars := []*eth.BlobSidecar{
+		{Slot: 5, Index: 0, KZGCommitment: eth.Bytes48{1}},
+		{Slot: 5, Index: 1, KZGCommitment: eth.Bytes48{2}},
+		{Slot: 5, Index: 2, KZGCommitment: eth.Bytes48{3}},
+	}
+	require.NoError(t, storage.WriteSidecars(5, sidecars))
+
+	srv := httptest.NewServer(NewAPIHandler(logger, storage))
+	defer srv.Close()
+
+	// the archiver serves the same API as a beacon node, so the beacon client can be used
+	cl := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(srv.URL, logger))
+
+	resp, err := cl.BeaconBlobSideCars(context.Background(), 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, resp.Data)
+
+	resp, err = cl.BeaconBlobSideCars(context.Background(), 5, []eth.IndexedBlobHash{{Index: 2}, {Index: 0}})
+	require.NoError(t, err)
+	require.Equal(t, []*eth.BlobSidecar{sidecars[0], sidecars[2]}, resp.Data)
+
+	_, err = cl.BeaconBlobSideCars(context.Background(), 6, nil)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	res, err := http.Get(srv.URL + "/eth/v1/beacon/blob_sidecars/head")
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
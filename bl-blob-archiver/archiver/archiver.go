@@ -0,0 +1,170 @@
+package archiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/sources"
+)
+
+// BeaconFetcher is the subset of the Beacon API used to archive blob sidecars.
+type BeaconFetcher interface {
+	sources.BlobSideCarsFetcher
+	BeaconBlockHeader(ctx context.Context, blockID string) (eth.APIBeaconBlockHeaderResponse, error)
+}
+
+// Archiver continuously fetches the blob sidecars of newly finalized slots from a beacon node
+// and persists them to a Storage.
+type Archiver struct {
+	log     log.Logger
+	fetcher BeaconFetcher
+	storage Storage
+
+	pollInterval time.Duration
+	startSlot    uint64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewArchiver creates a new Archiver. Only finalized slots are archived, so that archived sidecars are never reorged out.
+// If nothing has been archived yet, archiving starts at startSlot, or the current finalized slot if 0.
+func NewArchiver(log log.Logger, fetcher BeaconFetcher, storage Storage, pollInterval time.Duration, startSlot uint64) *Archiver {
+	return &Archiver{
+		log:          log,
+		fetcher:      fetcher,
+		storage:      storage,
+		pollInterval: pollInterval,
+		startSlot:    startSlot,
+	}
+}
+
+// Start starts archiving in the background, until Stop is called.
+func (a *Archiver) Start(ctx context.Context) {
+	ctx, a.cancel = context.WithCancel(ctx)
+	a.wg.Add(1)
+	go a.loop(ctx)
+}
+
+// Stop stops archiving and waits for the background routine to exit.
+func (a *Archiver) Stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.wg.Wait()
+}
+
+func (a *Archiver) loop(ctx context.Context) {
+	defer a.wg.Done()
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.archiveNewSlots(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			a.log.Warn("Failed to archive blob sidecars", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// archiveNewSlots archives all slots from the latest archived slot up to the slot of the finalized block.
+// Slots are never re-checked once archived, so non-finalized slots, of which the sidecars may still be
+// reorged out, are not archived.
+func (a *Archiver) archiveNewSlots(ctx context.Context) error {
+	finalized, err := a.fetcher.BeaconBlockHeader(ctx, "finalized")
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized header: %w", err)
+	}
+	target := uint64(finalized.Data.Header.Message.Slot)
+	if target == 0 {
+		return nil
+	}
+
+	next, err := a.nextSlot(target)
+	if err != nil {
+		return err
+	}
+	for slot := next; slot <= target; slot++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := a.archiveSlot(ctx, slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextSlot returns the next slot to archive.
+func (a *Archiver) nextSlot(target uint64) (uint64, error) {
+	latest, err := a.storage.LatestSlot()
+	if errors.Is(err, ErrNotFound) {
+		if a.startSlot != 0 {
+			return a.startSlot, nil
+		}
+		return target, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return latest + 1, nil
+}
+
+// archiveSlot fetches, verifies and persists all blob sidecars of the given slot.
+// Slots are only archived as empty once they are known to be missed. Sidecars that are not
+// available yet, or fail verification, are retried on the next poll.
+func (a *Archiver) archiveSlot(ctx context.Context, slot uint64) error {
+	resp, err := a.fetcher.BeaconBlobSideCars(ctx, slot, nil)
+	if errors.Is(err, ethereum.NotFound) {
+		missed, missedErr := a.slotMissed(ctx, slot)
+		if missedErr != nil {
+			return fmt.Errorf("failed to check if slot %d was missed: %w", slot, missedErr)
+		} else if !missed {
+			return fmt.Errorf("blob sidecars of slot %d are not available: %w", slot, err)
+		}
+		a.log.Debug("No block at slot", "slot", slot)
+	} else if err != nil {
+		return fmt.Errorf("failed to fetch blob sidecars of slot %d: %w", slot, err)
+	}
+	for _, sidecar := range resp.Data {
+		if err := eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return fmt.Errorf("blob sidecar %d of slot %d failed verification: %w", sidecar.Index, slot, err)
+		}
+	}
+	if err := a.storage.WriteSidecars(slot, resp.Data); err != nil {
+		return err
+	}
+	if err := a.storage.SetLatestSlot(slot); err != nil {
+		return err
+	}
+	a.log.Debug("Archived blob sidecars", "slot", slot, "count", len(resp.Data))
+	return nil
+}
+
+// slotMissed returns whether no block was proposed at the given slot: the beacon node has no block
+// at the slot, while its head is already past it. A beacon node that is still syncing the slot
+// does not have its block yet either. Slots up to the finalized slot have no block in any fork.
+func (a *Archiver) slotMissed(ctx context.Context, slot uint64) (bool, error) {
+	_, err := a.fetcher.BeaconBlockHeader(ctx, strconv.FormatUint(slot, 10))
+	if err == nil {
+		return false, nil
+	} else if !errors.Is(err, ethereum.NotFound) {
+		return false, err
+	}
+	head, err := a.fetcher.BeaconBlockHeader(ctx, "head")
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch head header: %w", err)
+	}
+	return uint64(head.Data.Header.Message.Slot) > slot, nil
+}
@@ -0,0 +1,154 @@
+package archiver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+type mockBeaconFetcher struct {
+	sidecars map[uint64][]*eth.BlobSidecar
+	blocks   map[uint64]bool
+	headSlot      uint64
+	finalizedSlot uint64
+	err           error
+	requests []uint64
+}
+
+func (m *mockBeaconFetcher) BeaconBlobSideCars(ctx context.Context, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	m.requests = append(m.requests, slot)
+	if m.err != nil {
+		return eth.APIGetBlobSidecarsResponse{}, m.err
+	}
+	sidecars, ok := m.sidecars[slot]
+	if !ok {
+		return eth.APIGetBlobSidecarsResponse{}, ethereum.NotFound
+	}
+	return eth.APIGetBlobSidecarsResponse{Data: sidecars}, nil
+}
+
+func (m *mockBeaconFetcher) BeaconBlockHeader(ctx context.Context, blockID string) (eth.APIBeaconBlockHeaderResponse, error) {
+	slot := m.headSlot
+	if blockID == "finalized" {
+		slot = m.finalizedSlot
+	} else if blockID != "head" {
+		var err error
+		slot, err = strconv.ParseUint(blockID, 10, 64)
+		if err != nil {
+			return eth.APIBeaconBlockHeaderResponse{}, err
+		}
+		if !m.blocks[slot] {
+			return eth.APIBeaconBlockHeaderResponse{}, ethereum.NotFound
+		}
+	}
+	var resp eth.APIBeaconBlockHeaderResponse
+	resp.Data.Header.Message.Slot = eth.Uint64String(slot)
+	return resp, nil
+}
+
+func makeTestSidecar(t *testing.T, slot, index uint64) *eth.BlobSidecar {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data(strconv.FormatUint(slot, 10))))
+	commit, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commit)
+	require.NoError(t, err)
+	return &eth.BlobSidecar{
+		Slot:          eth.Uint64String(slot),
+		Index:         eth.Uint64String(index),
+		Blob:          blob,
+		KZGCommitment: eth.Bytes48(commit),
+		KZGProof:      eth.Bytes48(proof),
+	}
+}
+
+func TestArchiveNewSlots(t *testing.T) {
+	storage, err := NewDiskStorage(t.TempDir())
+	require.NoError(t, err)
+	fetcher := &mockBeaconFetcher{
+		sidecars: map[uint64][]*eth.BlobSidecar{
+			3: {makeTestSidecar(t, 3, 0)},
+			4: {},
+			// slot 5 was missed
+			6: {makeTestSidecar(t, 6, 0), makeTestSidecar(t, 6, 1)},
+		},
+		blocks:        map[uint64]bool{3: true, 4: true, 6: true, 7: true, 8: true},
+		headSlot:      8,
+		finalizedSlot: 6,
+	}
+	a := NewArchiver(testlog.Logger(t, log.LvlInfo), fetcher, storage, time.Second, 3)
+
+	// archives from the start slot up to the finalized slot, the sidecars of later slots may still be reorged out
+	require.NoError(t, a.archiveNewSlots(context.Background()))
+	require.Equal(t, []uint64{3, 4, 5, 6}, fetcher.requests)
+	latest, err := storage.LatestSlot()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), latest)
+	sidecars, err := storage.ReadSidecars(6)
+	require.NoError(t, err)
+	require.Len(t, sidecars, 2)
+	sidecars, err = storage.ReadSidecars(5)
+	require.NoError(t, err)
+	require.Empty(t, sidecars)
+
+	// nothing new to archive
+	fetcher.requests = nil
+	require.NoError(t, a.archiveNewSlots(context.Background()))
+	require.Empty(t, fetcher.requests)
+
+	// failures are retried on the next call
+	fetcher.finalizedSlot = 8
+	fetcher.err = errors.New("boom")
+	require.ErrorIs(t, a.archiveNewSlots(context.Background()), fetcher.err)
+	latest, err = storage.LatestSlot()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), latest)
+
+	fetcher.err = nil
+	fetcher.requests = nil
+	fetcher.sidecars[7] = []*eth.BlobSidecar{}
+	fetcher.sidecars[8] = []*eth.BlobSidecar{}
+	require.NoError(t, a.archiveNewSlots(context.Background()))
+	require.Equal(t, []uint64{7, 8}, fetcher.requests)
+}
+
+func TestArchiveSlotNotAvailable(t *testing.T) {
+	storage, err := NewDiskStorage(t.TempDir())
+	require.NoError(t, err)
+	fetcher := &mockBeaconFetcher{
+		sidecars: map[uint64][]*eth.BlobSidecar{},
+		blocks:        map[uint64]bool{10: true},
+		headSlot:      12,
+		finalizedSlot: 10,
+	}
+	a := NewArchiver(testlog.Logger(t, log.LvlInfo), fetcher, storage, time.Second, 10)
+
+	// the block of slot 10 is known, but its sidecars are not served (yet)
+	require.ErrorIs(t, a.archiveNewSlots(context.Background()), ethereum.NotFound)
+	_, err = storage.LatestSlot()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// sidecars failing verification are not archived
+	corrupt := *makeTestSidecar(t, 10, 0)
+	corrupt.Blob[40] ^= 1
+	fetcher.sidecars[10] = []*eth.BlobSidecar{&corrupt}
+	require.ErrorContains(t, a.archiveNewSlots(context.Background()), "failed verification")
+	_, err = storage.LatestSlot()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	fetcher.sidecars[10] = []*eth.BlobSidecar{makeTestSidecar(t, 10, 0)}
+	require.NoError(t, a.archiveNewSlots(context.Background()))
+	sidecars, err := storage.ReadSidecars(10)
+	require.NoError(t, err)
+	require.Len(t, sidecars, 1)
+}
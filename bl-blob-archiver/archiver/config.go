@@ -0,0 +1,66 @@
+package archiver
+
+import (
+	"errors"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/BLASTchain/blast/bl-blob-archiver/flags"
+	oplog "github.com/BLASTchain/blast/bl-service/log"
+)
+
+type CLIConfig struct {
+	// BeaconAddr is the HTTP endpoint of the L1 beacon node to archive blob sidecars from.
+	BeaconAddr string
+
+	// DataDir is the directory the archived blob sidecars are persisted in.
+	DataDir string
+
+	HTTPAddr string
+	HTTPPort int
+
+	// PollInterval is the delay between polling the beacon node for newly finalized slots.
+	PollInterval time.Duration
+
+	// StartSlot is the slot to start archiving from if nothing has been archived yet.
+	// If 0, archiving starts at the current finalized slot.
+	StartSlot uint64
+
+	// ServeOnly disables archiving, only previously archived sidecars are served.
+	ServeOnly bool
+
+	LogConfig oplog.CLIConfig
+}
+
+func (c *CLIConfig) Check() error {
+	if c.DataDir == "" {
+		return errors.New("must specify a data directory")
+	}
+	if c.HTTPPort < 0 {
+		return errors.New("must specify a valid HTTP port")
+	}
+	if !c.ServeOnly {
+		if c.BeaconAddr == "" {
+			return errors.New("must specify an L1 beacon endpoint to archive from")
+		}
+		if c.PollInterval <= 0 {
+			return errors.New("poll interval must be positive")
+		}
+	}
+	return nil
+}
+
+// NewConfig parses the Config from the provided flags or environment variables.
+func NewConfig(ctx *cli.Context) *CLIConfig {
+	return &CLIConfig{
+		BeaconAddr:   ctx.String(flags.BeaconAddrFlag.Name),
+		DataDir:      ctx.String(flags.DataDirFlag.Name),
+		HTTPAddr:     ctx.String(flags.HTTPAddrFlag.Name),
+		HTTPPort:     ctx.Int(flags.HTTPPortFlag.Name),
+		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
+		StartSlot:    ctx.Uint64(flags.StartSlotFlag.Name),
+		ServeOnly:    ctx.Bool(flags.ServeOnlyFlag.Name),
+		LogConfig:    oplog.ReadCLIConfig(ctx),
+	}
+}
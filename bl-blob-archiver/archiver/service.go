@@ -0,0 +1,143 @@
+package archiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/BLASTchain/blast/bl-blob-archiver/flags"
+	opservice "github.com/BLASTchain/blast/bl-service"
+	"github.com/BLASTchain/blast/bl-service/cliapp"
+	"github.com/BLASTchain/blast/bl-service/client"
+	"github.com/BLASTchain/blast/bl-service/httputil"
+	oplog "github.com/BLASTchain/blast/bl-service/log"
+	"github.com/BLASTchain/blast/bl-service/sources"
+)
+
+// Main is the entrypoint into the blob archiver service.
+func Main(version string) cliapp.LifecycleAction {
+	return func(cliCtx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		if err := flags.CheckRequired(cliCtx); err != nil {
+			return nil, err
+		}
+		cfg := NewConfig(cliCtx)
+		if err := cfg.Check(); err != nil {
+			return nil, fmt.Errorf("invalid CLI flags: %w", err)
+		}
+
+		l := oplog.NewLogger(oplog.AppOut(cliCtx), cfg.LogConfig)
+		oplog.SetGlobalLogHandler(l.GetHandler())
+		opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, l)
+
+		l.Info("Initializing Blob Archiver", "version", version)
+		return ArchiverServiceFromCLIConfig(cliCtx.Context, cfg, l)
+	}
+}
+
+// ArchiverService archives blob sidecars from a beacon node and serves them back
+// through the Beacon API, so they remain available after the beacon node pruned them.
+// It conforms to the bl-service CLI Lifecycle interface.
+type ArchiverService struct {
+	Log      log.Logger
+	Storage  Storage
+	Archiver *Archiver
+
+	httpServer *httputil.HTTPServer
+
+	stopped atomic.Bool
+}
+
+// ArchiverServiceFromCLIConfig creates a new ArchiverService from a CLIConfig.
+func ArchiverServiceFromCLIConfig(ctx context.Context, cfg *CLIConfig, log log.Logger) (*ArchiverService, error) {
+	var as ArchiverService
+	if err := as.initFromCLIConfig(ctx, cfg, log); err != nil {
+		return nil, errors.Join(err, as.Stop(ctx)) // try to clean up our failed initialization attempt
+	}
+	return &as, nil
+}
+
+func (as *ArchiverService) initFromCLIConfig(ctx context.Context, cfg *CLIConfig, log log.Logger) error {
+	as.Log = log
+
+	storage, err := NewDiskStorage(cfg.DataDir)
+	if err != nil {
+		return err
+	}
+	as.Storage = storage
+
+	if !cfg.ServeOnly {
+		if err := as.initArchiver(ctx, cfg); err != nil {
+			return fmt.Errorf("failed to init archiver: %w", err)
+		}
+	}
+
+	if err := as.initHTTPServer(cfg); err != nil {
+		return fmt.Errorf("failed to start HTTP server: %w", err)
+	}
+	return nil
+}
+
+func (as *ArchiverService) initArchiver(ctx context.Context, cfg *CLIConfig) error {
+	beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.BeaconAddr, as.Log))
+	as.Archiver = NewArchiver(as.Log, beacon, as.Storage, cfg.PollInterval, cfg.StartSlot)
+	return nil
+}
+
+func (as *ArchiverService) initHTTPServer(cfg *CLIConfig) error {
+	endpoint := net.JoinHostPort(cfg.HTTPAddr, strconv.Itoa(cfg.HTTPPort))
+	srv, err := httputil.StartHTTPServer(endpoint, NewAPIHandler(as.Log, as.Storage))
+	if err != nil {
+		return err
+	}
+	as.Log.Info("Started blob sidecars API server", "addr", srv.Addr())
+	as.httpServer = srv
+	return nil
+}
+
+// HTTPEndpoint returns the address of the blob sidecars API server.
+func (as *ArchiverService) HTTPEndpoint() string {
+	return "http://" + as.httpServer.Addr().String()
+}
+
+// Start runs once upon start of the archiver lifecycle, and starts archiving.
+func (as *ArchiverService) Start(ctx context.Context) error {
+	if as.Archiver != nil {
+		as.Archiver.Start(context.Background())
+	}
+	as.Log.Info("Blob archiver started")
+	return nil
+}
+
+// Stopped returns if the service as a whole is stopped.
+func (as *ArchiverService) Stopped() bool {
+	return as.stopped.Load()
+}
+
+// Stop fully stops the archiver and all its resources gracefully.
+func (as *ArchiverService) Stop(ctx context.Context) error {
+	if as.stopped.Load() {
+		return errors.New("already stopped")
+	}
+	as.Log.Info("Stopping blob archiver")
+
+	if as.Archiver != nil {
+		as.Archiver.Stop()
+	}
+	var result error
+	if as.httpServer != nil {
+		if err := as.httpServer.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop HTTP server: %w", err))
+		}
+	}
+	as.stopped.Store(true)
+	as.Log.Info("Blob archiver stopped")
+	return result
+}
+
+var _ cliapp.Lifecycle = (*ArchiverService)(nil)
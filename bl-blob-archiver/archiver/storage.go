@@ -0,0 +1,153 @@
+package archiver
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/ioutil"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Storage persists the blob sidecars of archived slots.
+type Storage interface {
+	// ReadSidecars returns the archived sidecars of the given slot.
+	// It returns ErrNotFound if the slot has not been archived.
+	ReadSidecars(slot uint64) ([]*eth.BlobSidecar, error)
+	// WriteSidecars archives the sidecars of the given slot. A slot without sidecars is
+	// archived as well, so it can be served as empty rather than unknown.
+	WriteSidecars(slot uint64, sidecars []*eth.BlobSidecar) error
+	// LatestSlot returns the latest slot that was recorded as archived with SetLatestSlot.
+	// It returns ErrNotFound if no slot has been recorded yet.
+	LatestSlot() (uint64, error)
+	// SetLatestSlot records the latest slot that was archived.
+	SetLatestSlot(slot uint64) error
+}
+
+// DiskStorage is a Storage that keeps one gzipped JSON file per slot in a directory.
+type DiskStorage struct {
+	dir  string
+	lock sync.RWMutex
+}
+
+var _ Storage = (*DiskStorage)(nil)
+
+type latestSlot struct {
+	Slot uint64 `json:"slot"`
+}
+
+// NewDiskStorage creates a DiskStorage in the given directory, creating it if necessary.
+func NewDiskStorage(dir string) (*DiskStorage, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "sidecars"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory %v: %w", dir, err)
+	}
+	return &DiskStorage{dir: dir}, nil
+}
+
+func (d *DiskStorage) sidecarsPath(slot uint64) string {
+	return filepath.Join(d.dir, "sidecars", strconv.FormatUint(slot, 10)+".json.gz")
+}
+
+func (d *DiskStorage) latestPath() string {
+	return filepath.Join(d.dir, "latest.json")
+}
+
+func (d *DiskStorage) ReadSidecars(slot uint64) ([]*eth.BlobSidecar, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var sidecars []*eth.BlobSidecar
+	if err := readJSON(d.sidecarsPath(slot), &sidecars); err != nil {
+		return nil, fmt.Errorf("failed to read sidecars of slot %d: %w", slot, err)
+	}
+	return sidecars, nil
+}
+
+func (d *DiskStorage) WriteSidecars(slot uint64, sidecars []*eth.BlobSidecar) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if sidecars == nil {
+		sidecars = []*eth.BlobSidecar{}
+	}
+	if err := writeJSON(d.sidecarsPath(slot), sidecars); err != nil {
+		return fmt.Errorf("failed to write sidecars of slot %d: %w", slot, err)
+	}
+	return nil
+}
+
+func (d *DiskStorage) LatestSlot() (uint64, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var latest latestSlot
+	if err := readJSON(d.latestPath(), &latest); err != nil {
+		return 0, fmt.Errorf("failed to read latest slot: %w", err)
+	}
+	return latest.Slot, nil
+}
+
+func (d *DiskStorage) SetLatestSlot(slot uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err := writeJSON(d.latestPath(), latestSlot{Slot: slot}); err != nil {
+		return fmt.Errorf("failed to write latest slot: %w", err)
+	}
+	return nil
+}
+
+// readJSON decodes the JSON file at path into dest, gunzipping it if the path ends with .gz.
+// It returns ErrNotFound if the file does not exist.
+func readJSON(path string, dest any) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	var in io.Reader = f
+	if ioutil.IsGzip(path) {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		defer gz.Close()
+		in = gz
+	}
+	return json.NewDecoder(in).Decode(dest)
+}
+
+// writeJSON atomically writes obj as JSON to path, gzipped if the path ends with .gz,
+// by writing to a temporary file first and renaming it.
+func writeJSON(path string, obj any) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var out io.Writer = f
+	var gz *gzip.Writer
+	if ioutil.IsGzip(path) {
+		gz = gzip.NewWriter(f)
+		out = gz
+	}
+	if err := json.NewEncoder(out).Encode(obj); err != nil {
+		return err
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
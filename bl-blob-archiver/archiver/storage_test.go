@@ -0,0 +1,45 @@
+package archiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+func TestDiskStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewDiskStorage(dir)
+	require.NoError(t, err)
+
+	_, err = storage.LatestSlot()
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = storage.ReadSidecars(10)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	sidecars := []*eth.BlobSidecar{
+		{Slot: 10, Index: 0, KZGCommitment: eth.Bytes48{1}},
+		{Slot: 10, Index: 1, KZGCommitment: eth.Bytes48{2}},
+	}
+	sidecars[1].Blob[100] = 0x42
+	require.NoError(t, storage.WriteSidecars(10, sidecars))
+	require.NoError(t, storage.WriteSidecars(11, nil))
+	require.NoError(t, storage.SetLatestSlot(11))
+
+	// reopen the storage to make sure everything was persisted
+	storage, err = NewDiskStorage(dir)
+	require.NoError(t, err)
+
+	latest, err := storage.LatestSlot()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), latest)
+
+	got, err := storage.ReadSidecars(10)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, got)
+
+	got, err = storage.ReadSidecars(11)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/BLASTchain/blast/bl-blob-archiver/archiver"
+	"github.com/BLASTchain/blast/bl-blob-archiver/flags"
+	opservice "github.com/BLASTchain/blast/bl-service"
+	"github.com/BLASTchain/blast/bl-service/cliapp"
+	oplog "github.com/BLASTchain/blast/bl-service/log"
+	"github.com/BLASTchain/blast/bl-service/opio"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	Version   = "v0.0.1"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
+	app.Name = "bl-blob-archiver"
+	app.Usage = "Blob Archiver Service"
+	app.Description = "Service for archiving L1 blob sidecars and serving them through the Beacon API after they are pruned"
+	app.Action = cliapp.LifecycleCmd(archiver.Main(Version))
+
+	ctx := opio.WithInterruptBlocker(context.Background())
+	err := app.RunContext(ctx, os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
@@ -0,0 +1,93 @@
+package flags
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/BLASTchain/blast/bl-service"
+	oplog "github.com/BLASTchain/blast/bl-service/log"
+)
+
+const EnvVarPrefix = "OP_BLOB_ARCHIVER"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
+}
+
+var (
+	// Required flags
+	BeaconAddrFlag = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to archive blob sidecars from",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
+	DataDirFlag = &cli.StringFlag{
+		Name:    "data-dir",
+		Usage:   "Directory to persist the archived blob sidecars in",
+		EnvVars: prefixEnvVars("DATA_DIR"),
+	}
+	// Optional flags
+	HTTPAddrFlag = &cli.StringFlag{
+		Name:    "http.addr",
+		Usage:   "Address the blob sidecars API server should listen on",
+		Value:   "0.0.0.0",
+		EnvVars: prefixEnvVars("HTTP_ADDR"),
+	}
+	HTTPPortFlag = &cli.IntFlag{
+		Name:    "http.port",
+		Usage:   "Port the blob sidecars API server should listen on",
+		Value:   8080,
+		EnvVars: prefixEnvVars("HTTP_PORT"),
+	}
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "How frequently to poll the L1 beacon node for new slots",
+		Value:   6 * time.Second,
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+	}
+	StartSlotFlag = &cli.Uint64Flag{
+		Name: "start-slot",
+		Usage: "The slot to start archiving from if nothing has been archived yet. " +
+			"0 to start from the current finalized slot.",
+		Value:   0,
+		EnvVars: prefixEnvVars("START_SLOT"),
+	}
+	ServeOnlyFlag = &cli.BoolFlag{
+		Name:    "serve-only",
+		Usage:   "Only serve previously archived blob sidecars, without archiving new ones",
+		EnvVars: prefixEnvVars("SERVE_ONLY"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	DataDirFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	BeaconAddrFlag,
+	HTTPAddrFlag,
+	HTTPPortFlag,
+	PollIntervalFlag,
+	StartSlotFlag,
+	ServeOnlyFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/BLASTchain/blast/bl-challenger/config"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/solver"
 	"github.com/BLASTchain/blast/bl-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -224,6 +226,45 @@ func TestPollInterval(t *testing.T) {
 	})
 }
 
+func TestSolverStrategy(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, solver.StrategyHonest, cfg.SolverStrategy)
+	})
+
+	for _, strategy := range solver.Strategies {
+		strategy := strategy
+		t.Run("Valid_"+strategy.String(), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--solver-strategy", strategy.String()))
+			require.Equal(t, strategy, cfg.SolverStrategy)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"unknown solver strategy: \"foo\"",
+			addRequiredArgs(config.TraceTypeAlphabet, "--solver-strategy", "foo"))
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.DryRun)
+		require.Equal(t, filepath.Join(datadir, config.DefaultDryRunJournal), cfg.DryRunJournalPath())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--dry-run", "--dry-run-journal", "/tmp/journal.jsonl", "--dry-run-http-addr", "127.0.0.1", "--dry-run-http-port", "1234"))
+		require.True(t, cfg.DryRun)
+		require.Equal(t, "/tmp/journal.jsonl", cfg.DryRunJournalPath())
+		require.Equal(t, "127.0.0.1", cfg.DryRunHTTPAddr)
+		require.Equal(t, 1234, cfg.DryRunHTTPPort)
+	})
+}
+
 func TestCannonBin(t *testing.T) {
 	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
 		configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--cannon-bin"))
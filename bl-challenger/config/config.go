@@ -3,12 +3,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/solver"
 	"github.com/BLASTchain/blast/bl-node/chaincfg"
 	opmetrics "github.com/BLASTchain/blast/bl-service/metrics"
 	oppprof "github.com/BLASTchain/blast/bl-service/pprof"
@@ -17,6 +19,7 @@ import (
 
 var (
 	ErrMissingTraceType              = errors.New("no supported trace types specified")
+	ErrInvalidSolverStrategy         = errors.New("invalid solver strategy")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
 	ErrMissingCannonL2               = errors.New("missing cannon L2")
@@ -95,6 +98,10 @@ const (
 	// The default value is 11 days, which is a 4 day resolution buffer
 	// plus the 7 day game finalization window.
 	DefaultGameWindow = time.Duration(11 * 24 * time.Hour)
+	// DefaultDryRunJournal is the file name of the dry-run journal in the datadir.
+	DefaultDryRunJournal  = "dry-run-journal.jsonl"
+	DefaultDryRunHTTPAddr = "127.0.0.1"
+	DefaultDryRunHTTPPort = 7310
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -110,7 +117,14 @@ type Config struct {
 	MaxConcurrency          uint             // Maximum number of threads to use when progressing games
 	PollInterval            time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 
-	TraceTypes []TraceType // Type of traces supported
+	TraceTypes     []TraceType     // Type of traces supported
+	SolverStrategy solver.Strategy // Strategy used to select the claims to respond to
+
+	// Dry-run mode records the actions to a journal instead of sending transactions
+	DryRun         bool
+	DryRunJournal  string // Path of the journal, defaults to a file in the datadir
+	DryRunHTTPAddr string // Address to serve the journal on
+	DryRunHTTPPort int    // Port to serve the journal on
 
 	// Specific to the alphabet trace provider
 	AlphabetTrace string // String for the AlphabetTraceProvider
@@ -149,7 +163,11 @@ func NewConfig(
 
 		AgreeWithProposedOutput: agreeWithProposedOutput,
 
-		TraceTypes: supportedTraceTypes,
+		TraceTypes:     supportedTraceTypes,
+		SolverStrategy: solver.StrategyHonest,
+
+		DryRunHTTPAddr: DefaultDryRunHTTPAddr,
+		DryRunHTTPPort: DefaultDryRunHTTPPort,
 
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
@@ -163,6 +181,14 @@ func NewConfig(
 	}
 }
 
+// DryRunJournalPath returns the path of the dry-run journal.
+func (c Config) DryRunJournalPath() string {
+	if c.DryRunJournal != "" {
+		return c.DryRunJournal
+	}
+	return filepath.Join(c.Datadir, DefaultDryRunJournal)
+}
+
 func (c Config) TraceTypeEnabled(t TraceType) bool {
 	return slices.Contains(c.TraceTypes, t)
 }
@@ -177,6 +203,9 @@ func (c Config) Check() error {
 	if len(c.TraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
+	if !solver.ValidStrategy(c.SolverStrategy) {
+		return fmt.Errorf("%w: %q", ErrInvalidSolverStrategy, c.SolverStrategy)
+	}
 	if c.Datadir == "" {
 		return ErrMissingDatadir
 	}
@@ -232,8 +261,11 @@ func (c Config) Check() error {
 	if c.TraceTypeEnabled(TraceTypeAlphabet) && c.AlphabetTrace == "" {
 		return ErrMissingAlphabetTrace
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	// Dry-run mode never sends transactions, so it doesn't need a signer.
+	if !c.DryRun {
+		if err := c.TxMgrConfig.Check(); err != nil {
+			return err
+		}
 	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
@@ -59,6 +59,13 @@ func TestTxMgrConfig(t *testing.T) {
 		config.TxMgrConfig = txmgr.CLIConfig{}
 		require.Equal(t, config.Check().Error(), "must provide a L1 RPC url")
 	})
+
+	t.Run("NotRequiredForDryRun", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.DryRun = true
+		config.TxMgrConfig = txmgr.CLIConfig{}
+		require.NoError(t, config.Check())
+	})
 }
 
 func TestL1EthRpcRequired(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/BLASTchain/blast/bl-challenger/config"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/solver"
 	"github.com/BLASTchain/blast/bl-node/chaincfg"
 	opservice "github.com/BLASTchain/blast/bl-service"
 	openum "github.com/BLASTchain/blast/bl-service/enum"
@@ -73,6 +74,34 @@ var (
 		EnvVars: prefixEnvVars("HTTP_POLL_INTERVAL"),
 		Value:   config.DefaultPollInterval,
 	}
+	SolverStrategyFlag = &cli.StringFlag{
+		Name:    "solver-strategy",
+		Usage:   "The strategy used to select the claims to respond to. Valid options: " + openum.EnumString(solver.Strategies),
+		EnvVars: prefixEnvVars("SOLVER_STRATEGY"),
+		Value:   solver.StrategyHonest.String(),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name:    "dry-run",
+		Usage:   "Record the actions to a journal instead of sending transactions.",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
+	DryRunJournalFlag = &cli.StringFlag{
+		Name:    "dry-run-journal",
+		Usage:   "Path of the dry-run journal. Defaults to " + config.DefaultDryRunJournal + " in the datadir.",
+		EnvVars: prefixEnvVars("DRY_RUN_JOURNAL"),
+	}
+	DryRunHTTPAddrFlag = &cli.StringFlag{
+		Name:    "dry-run-http-addr",
+		Usage:   "Address to serve the dry-run journal on.",
+		EnvVars: prefixEnvVars("DRY_RUN_HTTP_ADDR"),
+		Value:   config.DefaultDryRunHTTPAddr,
+	}
+	DryRunHTTPPortFlag = &cli.IntFlag{
+		Name:    "dry-run-http-port",
+		Usage:   "Port to serve the dry-run journal on.",
+		EnvVars: prefixEnvVars("DRY_RUN_HTTP_PORT"),
+		Value:   config.DefaultDryRunHTTPPort,
+	}
 	RollupRpcFlag = &cli.StringFlag{
 		Name:    "rollup-rpc",
 		Usage:   "HTTP provider URL for the rollup node",
@@ -154,6 +183,11 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	MaxConcurrencyFlag,
 	HTTPPollInterval,
+	SolverStrategyFlag,
+	DryRunFlag,
+	DryRunJournalFlag,
+	DryRunHTTPAddrFlag,
+	DryRunHTTPPortFlag,
 	RollupRpcFlag,
 	AlphabetFlag,
 	GameAllowlistFlag,
@@ -279,6 +313,11 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
 
+	var solverStrategy solver.Strategy
+	if err := solverStrategy.Set(ctx.String(SolverStrategyFlag.Name)); err != nil {
+		return nil, err
+	}
+
 	maxConcurrency := ctx.Uint(MaxConcurrencyFlag.Name)
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
@@ -287,6 +326,11 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		// Required Flags
 		L1EthRpc:                ctx.String(L1EthRpcFlag.Name),
 		TraceTypes:              traceTypes,
+		SolverStrategy:          solverStrategy,
+		DryRun:                  ctx.Bool(DryRunFlag.Name),
+		DryRunJournal:           ctx.String(DryRunJournalFlag.Name),
+		DryRunHTTPAddr:          ctx.String(DryRunHTTPAddrFlag.Name),
+		DryRunHTTPPort:          ctx.Int(DryRunHTTPPortFlag.Name),
 		GameFactoryAddress:      gameFactoryAddress,
 		GameAllowlist:           allowedGames,
 		GameWindow:              ctx.Duration(GameWindowFlag.Name),
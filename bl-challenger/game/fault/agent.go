@@ -36,17 +36,29 @@ type Agent struct {
 	maxDepth                int
 	agreeWithProposedOutput bool
 	log                     log.Logger
+
+	// reportedSkips are the skipped actions already reported, as the same action is skipped on every Act
+	reportedSkips map[skippedActionKey]struct{}
+}
+
+// skippedActionKey identifies a skipped action, across polls of the game
+type skippedActionKey struct {
+	actionType types.ActionType
+	parentIdx  int
+	isAttack   bool
+	reason     solver.SkipReason
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, agreeWithProposedOutput bool, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, agreeWithProposedOutput bool, strategy solver.Strategy, log log.Logger) *Agent {
 	return &Agent{
 		metrics:                 m,
-		solver:                  solver.NewGameSolver(maxDepth, trace),
+		solver:                  solver.NewGameSolver(maxDepth, trace, strategy),
 		loader:                  loader,
 		responder:               responder,
 		maxDepth:                maxDepth,
 		agreeWithProposedOutput: agreeWithProposedOutput,
 		log:                     log,
+		reportedSkips:           make(map[skippedActionKey]struct{}),
 	}
 }
 
@@ -61,11 +73,13 @@ func (a *Agent) Act(ctx context.Context) error {
 	}
 
 	// Calculate the actions to take
-	actions, err := a.solver.CalculateNextActions(ctx, game)
+	actions, skipped, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
 		log.Error("Failed to calculate all required moves", "err", err)
 	}
 
+	a.reportSkipped(skipped)
+
 	// Perform the actions
 	for _, action := range actions {
 		log := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
@@ -90,6 +104,22 @@ func (a *Agent) Act(ctx context.Context) error {
 	return nil
 }
 
+// reportSkipped reports the actions skipped by the solver strategy. Each skipped action is only
+// recorded once, later polls skipping it again only log at debug level.
+func (a *Agent) reportSkipped(skipped []solver.SkippedAction) {
+	for _, skip := range skipped {
+		log := a.log.New("action", skip.Action.Type, "is_attack", skip.Action.IsAttack, "parent", skip.Action.ParentIdx, "reason", skip.Reason)
+		key := skippedActionKey{skip.Action.Type, skip.Action.ParentIdx, skip.Action.IsAttack, skip.Reason}
+		if _, ok := a.reportedSkips[key]; ok {
+			log.Debug("Skipping action")
+			continue
+		}
+		a.reportedSkips[key] = struct{}{}
+		a.metrics.RecordActionSkipped(string(skip.Reason))
+		log.Info("Skipping action")
+	}
+}
+
 // shouldResolve returns true if the agent should resolve the game.
 // This method will return false if the game is still in progress.
 func (a *Agent) shouldResolve(status gameTypes.GameStatus) bool {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/solver"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/test"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/trace/alphabet"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
@@ -115,7 +116,7 @@ func setupTestAgent(t *testing.T, agreeWithProposedOutput bool) (*Agent, *stubCl
 	depth := 4
 	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
 	responder := &stubResponder{}
-	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, agreeWithProposedOutput, logger)
+	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, agreeWithProposedOutput, solver.StrategyHonest, logger)
 	return agent, claimLoader, responder
 }
 
@@ -165,3 +166,29 @@ func (s *stubResponder) ResolveClaim(ctx context.Context, clainIdx uint64) error
 func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) error {
 	return nil
 }
+
+func TestReportSkippedOnce(t *testing.T) {
+	agent, _, _ := setupTestAgent(t, false)
+	m := &stubSkipMetrics{}
+	agent.metrics = m
+
+	skip := solver.SkippedAction{Action: types.Action{Type: types.ActionTypeMove, ParentIdx: 1, IsAttack: true}, Reason: solver.SkipReasonNotRequired}
+	agent.reportSkipped([]solver.SkippedAction{skip})
+	agent.reportSkipped([]solver.SkippedAction{skip})
+	require.Equal(t, 1, m.skipped, "should record the same skipped action once")
+
+	// a different action against the same claim is reported
+	other := skip
+	other.Action.IsAttack = false
+	agent.reportSkipped([]solver.SkippedAction{skip, other})
+	require.Equal(t, 2, m.skipped)
+}
+
+type stubSkipMetrics struct {
+	metrics.NoopMetricsImpl
+	skipped int
+}
+
+func (m *stubSkipMetrics) RecordActionSkipped(reason string) {
+	m.skipped++
+}
@@ -0,0 +1,240 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
+	"github.com/BLASTchain/blast/bl-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const claimCacheFile = "claims.json"
+
+type ClaimSource interface {
+	GetClaimCountAtBlock(ctx context.Context, block batching.Block) (uint64, error)
+	GetClaimsAtBlock(ctx context.Context, block batching.Block, indices ...uint64) ([]types.Claim, error)
+}
+
+type L1HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+type cachedClaim struct {
+	ParentIndex int          `json:"parentIndex"`
+	Countered   bool         `json:"countered"`
+	Value       common.Hash  `json:"value"`
+	Position    *hexutil.Big `json:"position"`
+	Clock       uint64       `json:"clock"`
+}
+
+// cachedGame is the state of a game's claims as of an L1 block.
+type cachedGame struct {
+	BlockHash   common.Hash   `json:"blockHash"`
+	BlockNumber uint64        `json:"blockNumber"`
+	Claims      []cachedClaim `json:"claims"`
+}
+
+// claimCache is a [ClaimLoader] that persists the claims of a game in the game's data directory,
+// so that only new claims have to be loaded from the contract.
+//
+// Claim data is immutable once posted, except for the countered flag. Moves counter their parent claim,
+// and steps counter claims at the max game depth, so those are reloaded when they may have changed.
+// Once the game may be in resolution, resolveClaim sets the countered flag of any claim, so all claims
+// are reloaded. The cache is discarded if the L1 block it was loaded at is reorged out.
+type claimCache struct {
+	logger   log.Logger
+	source   ClaimSource
+	l1       L1HeaderSource
+	path     string
+	maxDepth int
+	// gameDuration is the GAME_DURATION of the game, in seconds
+	gameDuration uint64
+
+	game *cachedGame
+}
+
+func newClaimCache(logger log.Logger, source ClaimSource, l1 L1HeaderSource, dir string, maxDepth int, gameDuration uint64) *claimCache {
+	return &claimCache{
+		logger:       logger,
+		source:       source,
+		l1:           l1,
+		path:         filepath.Join(dir, claimCacheFile),
+		maxDepth:     maxDepth,
+		gameDuration: gameDuration,
+	}
+}
+
+func (c *claimCache) GetAllClaims(ctx context.Context) ([]types.Claim, error) {
+	if c.game == nil {
+		game, err := c.read()
+		if err != nil {
+			c.logger.Warn("Failed to read claim cache, reloading all claims", "err", err)
+		}
+		c.game = game
+	}
+	head, err := c.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if c.game != nil {
+		reorged, err := c.isReorged(ctx, head)
+		if err != nil {
+			return nil, err
+		}
+		if reorged {
+			c.logger.Warn("Discarding claim cache after L1 reorg", "block", c.game.BlockNumber, "hash", c.game.BlockHash)
+			c.game = nil
+		}
+	}
+
+	var claims []types.Claim
+	if c.game != nil {
+		claims = c.game.claims()
+	}
+	block := batching.BlockByHash(head.Hash())
+	count, err := c.source.GetClaimCountAtBlock(ctx, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claim count: %w", err)
+	}
+	if count < uint64(len(claims)) {
+		c.logger.Warn("Discarding claim cache with more claims than the game", "cached", len(claims), "count", count)
+		claims = nil
+	}
+
+	resolving := c.mayBeResolving(head, claims)
+	indices := make([]uint64, 0, count-uint64(len(claims)))
+	for _, claim := range claims {
+		// Claims at the max depth may have been countered by a step, and any claim by its resolution
+		if resolving || (claim.Depth() == c.maxDepth && !claim.Countered) {
+			indices = append(indices, uint64(claim.ContractIndex))
+		}
+	}
+	cachedCount := len(claims)
+	for i := uint64(cachedCount); i < count; i++ {
+		indices = append(indices, i)
+	}
+	if len(indices) > 0 {
+		loaded, err := c.source.GetClaimsAtBlock(ctx, block, indices...)
+		if err != nil {
+			return nil, err
+		}
+		for _, claim := range loaded {
+			if claim.ContractIndex < cachedCount {
+				claims[claim.ContractIndex] = claim
+			} else {
+				claims = append(claims, claim)
+			}
+		}
+		// Moves counter their parent claim
+		for _, claim := range claims[cachedCount:] {
+			if !claim.IsRoot() {
+				claims[claim.ParentContractIndex].Countered = true
+			}
+		}
+	}
+
+	c.game = newCachedGame(head, claims)
+	if err := c.write(); err != nil {
+		c.logger.Warn("Failed to write claim cache", "err", err)
+	}
+	return claims, nil
+}
+
+// mayBeResolving returns true if claims may have been resolved as of the given head. A claim can only be
+// resolved once its clock expired, which takes at least half the game duration since the root claim was posted.
+func (c *claimCache) mayBeResolving(head *ethtypes.Header, claims []types.Claim) bool {
+	if len(claims) == 0 {
+		return false
+	}
+	// The clock of a claim is truncated to its timestamp
+	return head.Time > claims[0].Clock+c.gameDuration/2
+}
+
+// isReorged returns true if the block the cache was loaded at is no longer canonical.
+func (c *claimCache) isReorged(ctx context.Context, head *ethtypes.Header) (bool, error) {
+	if c.game.BlockHash == head.Hash() {
+		return false, nil
+	}
+	if c.game.BlockNumber > head.Number.Uint64() {
+		return true, nil
+	}
+	header, err := c.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(c.game.BlockNumber))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L1 block %v: %w", c.game.BlockNumber, err)
+	}
+	return header.Hash() != c.game.BlockHash, nil
+}
+
+func (c *claimCache) read() (*cachedGame, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var game cachedGame
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+func (c *claimCache) write() error {
+	data, err := json.Marshal(c.game)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+func newCachedGame(head *ethtypes.Header, claims []types.Claim) *cachedGame {
+	game := &cachedGame{
+		BlockHash:   head.Hash(),
+		BlockNumber: head.Number.Uint64(),
+		Claims:      make([]cachedClaim, len(claims)),
+	}
+	for i, claim := range claims {
+		game.Claims[i] = cachedClaim{
+			ParentIndex: claim.ParentContractIndex,
+			Countered:   claim.Countered,
+			Value:       claim.Value,
+			Position:    (*hexutil.Big)(claim.Position.ToGIndex()),
+			Clock:       claim.Clock,
+		}
+	}
+	return game
+}
+
+func (g *cachedGame) claims() []types.Claim {
+	claims := make([]types.Claim, len(g.Claims))
+	for i, claim := range g.Claims {
+		claims[i] = types.Claim{
+			ClaimData: types.ClaimData{
+				Value:    claim.Value,
+				Position: types.NewPositionFromGIndex(claim.Position.ToInt()),
+			},
+			Countered:           claim.Countered,
+			Clock:               claim.Clock,
+			ContractIndex:       i,
+			ParentContractIndex: claim.ParentIndex,
+		}
+	}
+	return claims
+}
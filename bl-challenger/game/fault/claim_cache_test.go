@@ -0,0 +1,175 @@
+package fault
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
+	"github.com/BLASTchain/blast/bl-service/sources/batching"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimCache(t *testing.T) {
+	maxDepth := 2
+	gameDuration := uint64(100)
+	root := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(big.NewInt(1))},
+		ContractIndex:       0,
+		ParentContractIndex: math.MaxUint32,
+	}
+	child := func(parent types.Claim, idx int) types.Claim {
+		return types.Claim{
+			ClaimData:           types.ClaimData{Value: common.Hash{byte(idx + 1)}, Position: parent.Position.Attack()},
+			Clock:               uint64(idx),
+			ContractIndex:       idx,
+			ParentContractIndex: parent.ContractIndex,
+		}
+	}
+	claim1 := child(root, 1)
+	claim2 := child(claim1, 2)
+
+	setup := func(t *testing.T, dir string) (*claimCache, *stubClaimSource, *stubL1Headers) {
+		source := &stubClaimSource{}
+		l1 := &stubL1Headers{headers: make(map[uint64]*ethtypes.Header)}
+		return newClaimCache(testlog.Logger(t, log.LvlInfo), source, l1, dir, maxDepth, gameDuration), source, l1
+	}
+
+	t.Run("LoadIncrementally", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, source, l1 := setup(t, dir)
+		l1.setHead(1, 0xaa)
+		source.claims = []types.Claim{root}
+		claims, err := cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, []types.Claim{root}, claims)
+		require.Equal(t, []uint64{0}, source.requested)
+
+		l1.setHead(2, 0xbb)
+		counteredRoot := root
+		counteredRoot.Countered = true
+		counteredClaim1 := claim1
+		counteredClaim1.Countered = true
+		source.claims = []types.Claim{counteredRoot, counteredClaim1, claim2}
+		claims, err = cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, []types.Claim{counteredRoot, counteredClaim1, claim2}, claims)
+		require.Equal(t, []uint64{1, 2}, source.requested)
+
+		// Uncountered claims at the max depth are reloaded in case they were stepped on
+		l1.setHead(3, 0xcc)
+		counteredClaim2 := claim2
+		counteredClaim2.Countered = true
+		source.claims = []types.Claim{counteredRoot, counteredClaim1, counteredClaim2}
+		claims, err = cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, source.claims, claims)
+		require.Equal(t, []uint64{2}, source.requested)
+
+		// The claims are persisted in the game directory
+		cache, source, l1 = setup(t, dir)
+		l1.setHead(1, 0xaa)
+		l1.setHead(2, 0xbb)
+		l1.setHead(3, 0xcc)
+		source.claims = []types.Claim{counteredRoot, counteredClaim1, counteredClaim2}
+		claims, err = cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, source.claims, claims)
+		require.Empty(t, source.requested)
+	})
+
+	t.Run("ReloadAllWhenResolving", func(t *testing.T) {
+		cache, source, l1 := setup(t, t.TempDir())
+		l1.setHead(1, 0xaa)
+		counteredRoot := root
+		counteredRoot.Countered = true
+		source.claims = []types.Claim{counteredRoot, claim1}
+		_, err := cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+
+		// Before half the game duration elapsed, no claim can be resolved
+		l1.setHead(2, 0xbb)
+		l1.headers[2].Time = gameDuration / 2
+		_, err = cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, source.requested)
+
+		// Resolving claim1 un-counters the root
+		l1.setHead(3, 0xcc)
+		l1.headers[3].Time = gameDuration/2 + 1
+		source.claims = []types.Claim{root, claim1}
+		claims, err := cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, source.claims, claims)
+		require.Equal(t, []uint64{0, 1}, source.requested)
+	})
+
+	t.Run("DiscardOnReorg", func(t *testing.T) {
+		cache, source, l1 := setup(t, t.TempDir())
+		l1.setHead(1, 0xaa)
+		source.claims = []types.Claim{root, claim1}
+		_, err := cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+
+		// Block 1 is replaced, which removed claim1
+		l1.setHead(1, 0xa1)
+		l1.setHead(2, 0xbb)
+		source.claims = []types.Claim{root}
+		claims, err := cache.GetAllClaims(context.Background())
+		require.NoError(t, err)
+		requireClaims(t, []types.Claim{root}, claims)
+		require.Equal(t, []uint64{0}, source.requested)
+	})
+}
+
+type stubClaimSource struct {
+	claims    []types.Claim
+	requested []uint64
+}
+
+func (s *stubClaimSource) GetClaimCountAtBlock(_ context.Context, _ batching.Block) (uint64, error) {
+	s.requested = nil
+	return uint64(len(s.claims)), nil
+}
+
+func (s *stubClaimSource) GetClaimsAtBlock(_ context.Context, _ batching.Block, indices ...uint64) ([]types.Claim, error) {
+	s.requested = indices
+	claims := make([]types.Claim, len(indices))
+	for i, idx := range indices {
+		claims[i] = s.claims[idx]
+	}
+	return claims, nil
+}
+
+type stubL1Headers struct {
+	headers map[uint64]*ethtypes.Header
+	head    uint64
+}
+
+func (s *stubL1Headers) setHead(num uint64, extra byte) {
+	s.headers[num] = &ethtypes.Header{Number: new(big.Int).SetUint64(num), Extra: []byte{extra}}
+	s.head = num
+}
+
+func (s *stubL1Headers) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	if number == nil {
+		return s.headers[s.head], nil
+	}
+	return s.headers[number.Uint64()], nil
+}
+
+// requireClaims compares the claims by generalized index, as positions may be encoded differently.
+func requireClaims(t *testing.T, expected []types.Claim, actual []types.Claim) {
+	require.Len(t, actual, len(expected))
+	for i, claim := range actual {
+		want := expected[i]
+		require.Zero(t, want.ToGIndex().Cmp(claim.ToGIndex()), "claim %v position", i)
+		want.Position = claim.Position
+		require.Equal(t, want, claim)
+	}
+}
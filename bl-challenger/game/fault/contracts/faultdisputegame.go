@@ -108,7 +108,12 @@ func (f *FaultDisputeGameContract) GetStatus(ctx context.Context) (gameTypes.Gam
 }
 
 func (f *FaultDisputeGameContract) GetClaimCount(ctx context.Context) (uint64, error) {
-	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodClaimCount))
+	return f.GetClaimCountAtBlock(ctx, batching.BlockLatest)
+}
+
+// GetClaimCountAtBlock returns the number of claims in the game at the given block.
+func (f *FaultDisputeGameContract) GetClaimCountAtBlock(ctx context.Context, block batching.Block) (uint64, error) {
+	result, err := f.multiCaller.SingleCall(ctx, block, f.contract.Call(methodClaimCount))
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch claim count: %w", err)
 	}
@@ -146,6 +151,25 @@ func (f *FaultDisputeGameContract) GetAllClaims(ctx context.Context) ([]types.Cl
 	return claims, nil
 }
 
+// GetClaimsAtBlock returns the claims with the given indices at the given block.
+func (f *FaultDisputeGameContract) GetClaimsAtBlock(ctx context.Context, block batching.Block, indices ...uint64) ([]types.Claim, error) {
+	calls := make([]*batching.ContractCall, len(indices))
+	for i, idx := range indices {
+		calls[i] = f.contract.Call(methodClaim, new(big.Int).SetUint64(idx))
+	}
+
+	results, err := f.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim data: %w", err)
+	}
+
+	claims := make([]types.Claim, len(results))
+	for i, result := range results {
+		claims[i] = f.decodeClaim(result, int(indices[i]))
+	}
+	return claims, nil
+}
+
 func (f *FaultDisputeGameContract) vm(ctx context.Context) (*VMContract, error) {
 	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodVM))
 	if err != nil {
@@ -189,6 +189,40 @@ func TestGetAllClaims(t *testing.T) {
 	require.Equal(t, expectedClaims, claims)
 }
 
+func TestGetClaimsAtBlock(t *testing.T) {
+	stubRpc, game := setup(t)
+	block := batching.BlockByHash(common.Hash{0xcc})
+	claim2 := faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value:    common.Hash{0xbb},
+			Position: faultTypes.NewPositionFromGIndex(big.NewInt(6)),
+		},
+		Clock:               7777,
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	claim4 := faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value:    common.Hash{0xdd},
+			Position: faultTypes.NewPositionFromGIndex(big.NewInt(12)),
+		},
+		Countered:           true,
+		Clock:               8888,
+		ContractIndex:       4,
+		ParentContractIndex: 2,
+	}
+	stubRpc.SetResponse(fdgAddr, methodClaimCount, block, nil, []interface{}{big.NewInt(5)})
+	expectGetClaimAtBlock(stubRpc, block, claim2)
+	expectGetClaimAtBlock(stubRpc, block, claim4)
+
+	count, err := game.GetClaimCountAtBlock(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), count)
+	claims, err := game.GetClaimsAtBlock(context.Background(), block, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, []faultTypes.Claim{claim2, claim4}, claims)
+}
+
 func TestCallResolveClaim(t *testing.T) {
 	stubRpc, game := setup(t)
 	stubRpc.SetResponse(fdgAddr, methodResolveClaim, batching.BlockLatest, []interface{}{big.NewInt(123)}, nil)
@@ -281,10 +315,14 @@ func TestUpdateOracleTx(t *testing.T) {
 }
 
 func expectGetClaim(stubRpc *batchingTest.AbiBasedRpc, claim faultTypes.Claim) {
+	expectGetClaimAtBlock(stubRpc, batching.BlockLatest, claim)
+}
+
+func expectGetClaimAtBlock(stubRpc *batchingTest.AbiBasedRpc, block batching.Block, claim faultTypes.Claim) {
 	stubRpc.SetResponse(
 		fdgAddr,
 		methodClaim,
-		batching.BlockLatest,
+		block,
 		[]interface{}{big.NewInt(int64(claim.ContractIndex))},
 		[]interface{}{
 			uint32(claim.ParentContractIndex),
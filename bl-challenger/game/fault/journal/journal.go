@@ -0,0 +1,150 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type ActionType string
+
+const (
+	ActionAttack       ActionType = "attack"
+	ActionDefend       ActionType = "defend"
+	ActionStep         ActionType = "step"
+	ActionUpdateOracle ActionType = "update-oracle"
+	ActionResolveClaim ActionType = "resolve-claim"
+	ActionResolve      ActionType = "resolve"
+)
+
+// Entry is an action the challenger would have performed, including the transaction it would have sent.
+type Entry struct {
+	Time   time.Time      `json:"time"`
+	Game   common.Address `json:"game"`
+	Action ActionType     `json:"action"`
+	// ClaimIndex is the index of the claim the action applies to. Unset for game resolution.
+	ClaimIndex *uint64 `json:"claimIndex,omitempty"`
+	// IsAttack is set for steps.
+	IsAttack *bool `json:"isAttack,omitempty"`
+	// Value is the trace value of the claim posted by moves.
+	Value    *common.Hash    `json:"value,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	Bond     *hexutil.Big    `json:"bond,omitempty"`
+	Calldata hexutil.Bytes   `json:"calldata"`
+}
+
+// id identifies the entry regardless of when it was recorded.
+// The agent recalculates the same actions every time a game is progressed.
+func (e Entry) id() string {
+	return fmt.Sprintf("%v:%v:%x", e.Game, e.To, []byte(e.Calldata))
+}
+
+// Journal records the actions of a challenger running in dry-run mode to a file of JSON lines.
+// Each action is only recorded once.
+type Journal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries []Entry
+	seen    map[string]bool
+}
+
+// NewJournal opens the journal at path, loading the entries already recorded in it.
+func NewJournal(path string) (*Journal, error) {
+	j := &Journal{seen: make(map[string]bool)}
+	if err := j.load(path); err != nil {
+		return nil, fmt.Errorf("failed to load journal %v: %w", path, err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %v: %w", path, err)
+	}
+	j.file = file
+	return j, nil
+}
+
+func (j *Journal) load(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return err
+		}
+		j.entries = append(j.entries, entry)
+		j.seen[entry.id()] = true
+	}
+	return scanner.Err()
+}
+
+// Record appends the entry to the journal, unless the same action was already recorded.
+func (j *Journal) Record(entry Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.seen[entry.id()] {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	j.entries = append(j.entries, entry)
+	j.seen[entry.id()] = true
+	return nil
+}
+
+// Entries returns the recorded entries for the game, or all entries if game is the zero address.
+func (j *Journal) Entries(game common.Address) []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]Entry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		if game == (common.Address{}) || entry.Game == game {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// ServeHTTP serves the recorded entries as JSON. The entries can be filtered by the game query parameter.
+func (j *Journal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var game common.Address
+	if param := r.URL.Query().Get("game"); param != "" {
+		if !common.IsHexAddress(param) {
+			http.Error(w, "invalid game address", http.StatusBadRequest)
+			return
+		}
+		game = common.HexToAddress(param)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(j.Entries(game))
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
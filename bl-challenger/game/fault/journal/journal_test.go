@@ -0,0 +1,71 @@
+package journal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	claimIdx := uint64(1)
+	move := Entry{Game: game1, Action: ActionAttack, ClaimIndex: &claimIdx, Value: &common.Hash{0xcc}, Calldata: []byte{1}}
+	resolve := Entry{Game: game2, Action: ActionResolve, Calldata: []byte{2}}
+
+	j, err := NewJournal(path)
+	require.NoError(t, err)
+	require.NoError(t, j.Record(move))
+	require.NoError(t, j.Record(resolve))
+	// Recalculated actions are only recorded once
+	require.NoError(t, j.Record(move))
+	require.Len(t, j.Entries(common.Address{}), 2)
+	require.NoError(t, j.Close())
+
+	t.Run("Reload", func(t *testing.T) {
+		j, err := NewJournal(path)
+		require.NoError(t, err)
+		defer j.Close()
+		require.NoError(t, j.Record(move))
+		entries := j.Entries(common.Address{})
+		require.Len(t, entries, 2)
+		require.Equal(t, game1, entries[0].Game)
+		require.Equal(t, claimIdx, *entries[0].ClaimIndex)
+		require.False(t, entries[0].Time.IsZero())
+		require.Equal(t, game2, entries[1].Game)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		j, err := NewJournal(path)
+		require.NoError(t, err)
+		defer j.Close()
+		srv := httptest.NewServer(j)
+		defer srv.Close()
+
+		get := func(query string) (int, []Entry) {
+			res, err := http.Get(srv.URL + query)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			var entries []Entry
+			if res.StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&entries))
+			}
+			return res.StatusCode, entries
+		}
+		status, entries := get("")
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, entries, 2)
+		status, entries = get("?game=" + game2.Hex())
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, entries, 1)
+		require.Equal(t, ActionResolve, entries[0].Action)
+		status, _ = get("?game=foo")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
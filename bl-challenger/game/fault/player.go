@@ -7,6 +7,7 @@ import (
 
 	"github.com/BLASTchain/blast/bl-challenger/config"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/contracts"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/journal"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/responder"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
 	gameTypes "github.com/BLASTchain/blast/bl-challenger/game/types"
@@ -48,6 +49,7 @@ func NewGamePlayer(
 	addr common.Address,
 	txMgr txmgr.TxManager,
 	client *ethclient.Client,
+	dryRunJournal *journal.Journal,
 	creator resourceCreator,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
@@ -90,12 +92,23 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to validate absolute prestate: %w", err)
 	}
 
-	responder, err := responder.NewFaultResponder(logger, txMgr, loader)
+	var gameResponder Responder
+	if cfg.DryRun {
+		gameResponder = responder.NewDryRunResponder(logger, addr, loader, dryRunJournal)
+	} else {
+		gameResponder, err = responder.NewFaultResponder(logger, txMgr, loader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the responder: %w", err)
+		}
+	}
+
+	gameDuration, err := loader.GetGameDuration(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create the responder: %w", err)
+		return nil, fmt.Errorf("failed to fetch the game duration: %w", err)
 	}
 
-	agent := NewAgent(m, loader, int(gameDepth), accessor, responder, cfg.AgreeWithProposedOutput, logger)
+	claimLoader := newClaimCache(logger, loader, client, dir, int(gameDepth), gameDuration)
+	agent := NewAgent(m, claimLoader, int(gameDepth), accessor, gameResponder, cfg.AgreeWithProposedOutput, cfg.SolverStrategy, logger)
 	return &GamePlayer{
 		act:                     agent.Act,
 		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/BLASTchain/blast/bl-challenger/config"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/contracts"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/journal"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/trace"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/trace/alphabet"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/trace/cannon"
@@ -38,15 +39,16 @@ func RegisterGameTypes(
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
 	client *ethclient.Client,
+	dryRunJournal *journal.Journal,
 ) {
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
-		registerOutputCannon(registry, ctx, logger, m, cfg, txMgr, client)
+		registerOutputCannon(registry, ctx, logger, m, cfg, txMgr, client, dryRunJournal)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		registerCannon(registry, ctx, logger, m, cfg, txMgr, client)
+		registerCannon(registry, ctx, logger, m, cfg, txMgr, client, dryRunJournal)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		registerAlphabet(registry, ctx, logger, m, cfg, txMgr, client)
+		registerAlphabet(registry, ctx, logger, m, cfg, txMgr, client, dryRunJournal)
 	}
 }
 
@@ -57,7 +59,8 @@ func registerOutputCannon(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	dryRunJournal *journal.Journal) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		logger := logger.New("game", addr)
 		// TODO(client-pod#43): Updated contracts should expose this as the pre and post state blocks
@@ -76,7 +79,7 @@ func registerOutputCannon(
 		return accessor, noopValidator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, dryRunJournal, resourceCreator)
 	}
 	registry.RegisterGameType(outputCannonGameType, playerCreator)
 }
@@ -88,7 +91,8 @@ func registerCannon(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	dryRunJournal *journal.Journal) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		logger := logger.New("game", addr)
 		provider, err := cannon.NewTraceProvider(ctx, logger, m, cfg, contract, cannon.NoLocalContext, dir, gameDepth)
@@ -101,7 +105,7 @@ func registerCannon(
 		return trace.NewSimpleTraceAccessor(provider), validator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, dryRunJournal, resourceCreator)
 	}
 	registry.RegisterGameType(cannonGameType, playerCreator)
 }
@@ -113,7 +117,8 @@ func registerAlphabet(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
-	client *ethclient.Client) {
+	client *ethclient.Client,
+	dryRunJournal *journal.Journal) {
 	resourceCreator := func(addr common.Address, contract *contracts.FaultDisputeGameContract, gameDepth uint64, dir string) (faultTypes.TraceAccessor, gameValidator, error) {
 		provider := alphabet.NewTraceProvider(cfg.AlphabetTrace, gameDepth)
 		validator := func(ctx context.Context, contract *contracts.FaultDisputeGameContract) error {
@@ -122,7 +127,7 @@ func registerAlphabet(
 		return trace.NewSimpleTraceAccessor(provider), validator, nil
 	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, resourceCreator)
+		return NewGamePlayer(ctx, logger, m, cfg, dir, game.Proxy, txMgr, client, dryRunJournal, resourceCreator)
 	}
 	registry.RegisterGameType(alphabetGameType, playerCreator)
 }
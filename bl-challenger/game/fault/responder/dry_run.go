@@ -0,0 +1,101 @@
+package responder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/journal"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
+	gameTypes "github.com/BLASTchain/blast/bl-challenger/game/types"
+	"github.com/BLASTchain/blast/bl-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Journal interface {
+	Record(entry journal.Entry) error
+}
+
+// DryRunResponder implements the [Responder] interface by recording the transactions
+// that would have been sent to a [Journal] instead of sending them.
+type DryRunResponder struct {
+	log log.Logger
+
+	game     common.Address
+	contract GameContract
+	journal  Journal
+}
+
+// NewDryRunResponder returns a new [DryRunResponder] for the game at the given address.
+func NewDryRunResponder(logger log.Logger, game common.Address, contract GameContract, journal Journal) *DryRunResponder {
+	return &DryRunResponder{
+		log:      logger,
+		game:     game,
+		contract: contract,
+		journal:  journal,
+	}
+}
+
+func (r *DryRunResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
+	return r.contract.CallResolve(ctx)
+}
+
+func (r *DryRunResponder) Resolve(ctx context.Context) error {
+	candidate, err := r.contract.ResolveTx()
+	if err != nil {
+		return err
+	}
+	return r.record(journal.Entry{Action: journal.ActionResolve}, candidate)
+}
+
+func (r *DryRunResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
+	return r.contract.CallResolveClaim(ctx, claimIdx)
+}
+
+func (r *DryRunResponder) ResolveClaim(ctx context.Context, claimIdx uint64) error {
+	candidate, err := r.contract.ResolveClaimTx(claimIdx)
+	if err != nil {
+		return err
+	}
+	return r.record(journal.Entry{Action: journal.ActionResolveClaim, ClaimIndex: &claimIdx}, candidate)
+}
+
+func (r *DryRunResponder) PerformAction(ctx context.Context, action types.Action) error {
+	claimIdx := uint64(action.ParentIdx)
+	if action.OracleData != nil {
+		candidate, err := r.contract.UpdateOracleTx(ctx, action.OracleData)
+		if err != nil {
+			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+		}
+		if err := r.record(journal.Entry{Action: journal.ActionUpdateOracle, ClaimIndex: &claimIdx}, candidate); err != nil {
+			return err
+		}
+	}
+	candidate, err := actionTx(r.contract, action)
+	if err != nil {
+		return err
+	}
+	entry := journal.Entry{ClaimIndex: &claimIdx}
+	switch action.Type {
+	case types.ActionTypeMove:
+		entry.Action = journal.ActionDefend
+		if action.IsAttack {
+			entry.Action = journal.ActionAttack
+		}
+		entry.Value = &action.Value
+	case types.ActionTypeStep:
+		entry.Action = journal.ActionStep
+		entry.IsAttack = &action.IsAttack
+	}
+	return r.record(entry, candidate)
+}
+
+func (r *DryRunResponder) record(entry journal.Entry, candidate txmgr.TxCandidate) error {
+	entry.Game = r.game
+	entry.To = candidate.To
+	entry.Bond = (*hexutil.Big)(candidate.Value)
+	entry.Calldata = candidate.TxData
+	r.log.Info("Dry run, recording action instead of sending transaction", "action", entry.Action, "to", entry.To)
+	return r.journal.Record(entry)
+}
@@ -0,0 +1,81 @@
+package responder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/journal"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunResponder(t *testing.T) {
+	game := common.Address{0xaa}
+
+	t.Run("Move", func(t *testing.T) {
+		responder, contract, entries := newTestDryRunResponder(t, game)
+		action := types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 3,
+			IsAttack:  true,
+			Value:     common.Hash{0xbb},
+		}
+		require.NoError(t, responder.PerformAction(context.Background(), action))
+		require.EqualValues(t, []interface{}{uint64(3), common.Hash{0xbb}}, contract.attackArgs)
+		require.Len(t, *entries, 1)
+		entry := (*entries)[0]
+		require.Equal(t, game, entry.Game)
+		require.Equal(t, journal.ActionAttack, entry.Action)
+		require.Equal(t, uint64(3), *entry.ClaimIndex)
+		require.Equal(t, common.Hash{0xbb}, *entry.Value)
+		require.Equal(t, []byte("attack"), []byte(entry.Calldata))
+	})
+
+	t.Run("StepWithOracleData", func(t *testing.T) {
+		responder, _, entries := newTestDryRunResponder(t, game)
+		action := types.Action{
+			Type:       types.ActionTypeStep,
+			ParentIdx:  5,
+			IsAttack:   false,
+			PreState:   []byte{1, 2, 3},
+			ProofData:  []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{IsLocal: true},
+		}
+		require.NoError(t, responder.PerformAction(context.Background(), action))
+		require.Len(t, *entries, 2)
+		require.Equal(t, journal.ActionUpdateOracle, (*entries)[0].Action)
+		require.Equal(t, []byte("updateOracle"), []byte((*entries)[0].Calldata))
+		require.Equal(t, journal.ActionStep, (*entries)[1].Action)
+		require.False(t, *(*entries)[1].IsAttack)
+		require.Equal(t, []byte("step"), []byte((*entries)[1].Calldata))
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		responder, _, entries := newTestDryRunResponder(t, game)
+		require.NoError(t, responder.ResolveClaim(context.Background(), 2))
+		require.NoError(t, responder.Resolve(context.Background()))
+		require.Len(t, *entries, 2)
+		require.Equal(t, journal.ActionResolveClaim, (*entries)[0].Action)
+		require.Equal(t, uint64(2), *(*entries)[0].ClaimIndex)
+		require.Equal(t, journal.ActionResolve, (*entries)[1].Action)
+		require.Nil(t, (*entries)[1].ClaimIndex)
+	})
+}
+
+func newTestDryRunResponder(t *testing.T, game common.Address) (*DryRunResponder, *mockContract, *[]journal.Entry) {
+	contract := &mockContract{}
+	j := &mockJournal{}
+	return NewDryRunResponder(testlog.Logger(t, log.LvlError), game, contract, j), contract, &j.entries
+}
+
+type mockJournal struct {
+	entries []journal.Entry
+}
+
+func (m *mockJournal) Record(entry journal.Entry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
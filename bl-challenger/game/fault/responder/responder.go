@@ -83,22 +83,26 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 			return fmt.Errorf("failed to populate pre-image oracle: %w", err)
 		}
 	}
-	var candidate txmgr.TxCandidate
-	var err error
+	candidate, err := actionTx(r.contract, action)
+	if err != nil {
+		return err
+	}
+	return r.sendTxAndWait(ctx, candidate)
+}
+
+// actionTx creates the transaction that performs the move or step action.
+func actionTx(contract GameContract, action types.Action) (txmgr.TxCandidate, error) {
 	switch action.Type {
 	case types.ActionTypeMove:
 		if action.IsAttack {
-			candidate, err = r.contract.AttackTx(uint64(action.ParentIdx), action.Value)
-		} else {
-			candidate, err = r.contract.DefendTx(uint64(action.ParentIdx), action.Value)
+			return contract.AttackTx(uint64(action.ParentIdx), action.Value)
 		}
+		return contract.DefendTx(uint64(action.ParentIdx), action.Value)
 	case types.ActionTypeStep:
-		candidate, err = r.contract.StepTx(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
+		return contract.StepTx(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
+	default:
+		return txmgr.TxCandidate{}, fmt.Errorf("unknown action type: %v", action.Type)
 	}
-	if err != nil {
-		return err
-	}
-	return r.sendTxAndWait(ctx, candidate)
 }
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
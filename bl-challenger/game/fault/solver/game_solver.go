@@ -10,15 +10,19 @@ import (
 
 type GameSolver struct {
 	claimSolver *claimSolver
+	strategy    Strategy
 }
 
-func NewGameSolver(gameDepth int, trace types.TraceAccessor) *GameSolver {
+func NewGameSolver(gameDepth int, trace types.TraceAccessor, strategy Strategy) *GameSolver {
 	return &GameSolver{
-		claimSolver: newClaimSolver(gameDepth, trace),
+		claimSolver: newClaimSolver(gameDepth, trace, strategy == StrategyDefendAll),
+		strategy:    strategy,
 	}
 }
 
-func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game) ([]types.Action, error) {
+// CalculateNextActions returns the actions to perform in the game according to the solver strategy,
+// and the actions that were deliberately skipped by the strategy.
+func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game) ([]types.Action, []SkippedAction, error) {
 	var errs []error
 	var actions []types.Action
 	for _, claim := range game.Claims() {
@@ -38,7 +42,11 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 		}
 		actions = append(actions, *action)
 	}
-	return actions, errors.Join(errs...)
+	var skipped []SkippedAction
+	if s.strategy == StrategyConservative {
+		actions, skipped = filterForRootOutcome(game, actions)
+	}
+	return actions, skipped, errors.Join(errs...)
 }
 
 func (s *GameSolver) calculateStep(ctx context.Context, game types.Game, claim types.Claim) (*types.Action, error) {
@@ -114,9 +114,10 @@ func TestCalculateNextActions(t *testing.T) {
 					i, claim.Position.ToGIndex(), claim.Position.TraceIndex(maxDepth), claim.ParentContractIndex, claim.Countered, claim.Value)
 			}
 
-			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()))
-			actions, err := solver.CalculateNextActions(context.Background(), game)
+			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), StrategyHonest)
+			actions, skipped, err := solver.CalculateNextActions(context.Background(), game)
 			require.NoError(t, err)
+			require.Empty(t, skipped)
 			for i, action := range actions {
 				t.Logf("Move %v: Type: %v, ParentIdx: %v, Attack: %v, Value: %v, PreState: %v, ProofData: %v",
 					i, action.Type, action.ParentIdx, action.IsAttack, action.Value, hex.EncodeToString(action.PreState), hex.EncodeToString(action.ProofData))
@@ -132,3 +133,97 @@ func TestCalculateNextActions(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateNextActionsStrategies(t *testing.T) {
+	maxDepth := 6
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, maxDepth)
+
+	// invalidBranch builds a branch that disputes the root claim with an invalid claim.
+	// The last claim is correct, but its path to the root is not.
+	invalidBranch := func(builder *faulttest.GameBuilder) *faulttest.GameBuilderSeq {
+		return builder.Seq().
+			Attack(common.Hash{0xaa}).
+			AttackCorrect().
+			AttackCorrect()
+	}
+
+	// countersBothBranches builds a game where the root claim can be countered by either of two steps.
+	countersBothBranches := func(builder *faulttest.GameBuilder) (*faulttest.GameBuilderSeq, *faulttest.GameBuilderSeq) {
+		claim := builder.Seq().
+			AttackCorrect().
+			Attack(common.Hash{0xaa}).
+			AttackCorrect().
+			Attack(common.Hash{0xbb})
+		first := claim.AttackCorrect().Attack(common.Hash{0xcc})
+		second := claim.DefendCorrect().Attack(common.Hash{0xdd})
+		return first, second
+	}
+
+	tests := []struct {
+		name          string
+		strategy      Strategy
+		setupGame     func(builder *faulttest.GameBuilder)
+		expectSkipped []SkipReason
+	}{
+		{
+			name:     "HonestIgnoresInvalidPaths",
+			strategy: StrategyHonest,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().ExpectAttack()
+				invalidBranch(builder).Attack(common.Hash{0xbb})
+			},
+		},
+		{
+			name:     "DefendAllCountersClaimsAgainstCorrectClaims",
+			strategy: StrategyDefendAll,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().ExpectAttack()
+				invalidBranch(builder).Attack(common.Hash{0xbb}).ExpectAttack()
+			},
+		},
+		{
+			name:     "HonestStepsOnAllBranches",
+			strategy: StrategyHonest,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				first, second := countersBothBranches(builder)
+				first.ExpectStepAttack()
+				second.ExpectStepAttack()
+			},
+		},
+		{
+			name:     "ConservativeOnlyPerformsRequiredActions",
+			strategy: StrategyConservative,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				first, _ := countersBothBranches(builder)
+				first.ExpectStepAttack()
+			},
+			expectSkipped: []SkipReason{SkipReasonNotRequired},
+		},
+		{
+			name:     "ConservativeSkipsWhenRootOutcomeSecured",
+			strategy: StrategyConservative,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				// The invalid claim is already countered by another invalid claim, so the root claim is countered.
+				invalid := builder.Seq().AttackCorrect().Attack(common.Hash{0xaa})
+				invalid.Attack(common.Hash{0xbb})
+			},
+			expectSkipped: []SkipReason{SkipReasonRootOutcomeSecured},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			builder := claimBuilder.GameBuilder(true, false)
+			test.setupGame(builder)
+			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), test.strategy)
+			actions, skipped, err := solver.CalculateNextActions(context.Background(), builder.Game)
+			require.NoError(t, err)
+			require.ElementsMatch(t, builder.ExpectedActions, actions)
+			require.Len(t, skipped, len(test.expectSkipped))
+			for i, reason := range test.expectSkipped {
+				require.Equal(t, reason, skipped[i].Reason)
+			}
+		})
+	}
+}
@@ -19,13 +19,16 @@ var (
 type claimSolver struct {
 	trace     types.TraceAccessor
 	gameDepth int
+	// defendAll enables moves against claims whose parent is correct, even if the path to the root is not.
+	defendAll bool
 }
 
 // newClaimSolver creates a new [claimSolver] using the provided [TraceProvider].
-func newClaimSolver(gameDepth int, trace types.TraceAccessor) *claimSolver {
+func newClaimSolver(gameDepth int, trace types.TraceAccessor, defendAll bool) *claimSolver {
 	return &claimSolver{
 		trace,
 		gameDepth,
+		defendAll,
 	}
 }
 
@@ -38,12 +41,13 @@ func (s *claimSolver) NextMove(ctx context.Context, claim types.Claim, game type
 	// Before challenging this claim, first check that the move wasn't warranted.
 	// If the parent claim is on a dishonest path, then we would have moved against it anyways. So we don't move.
 	// Avoiding dishonest paths ensures that there's always a valid claim available to support ours during step.
+	// When defending all honest claims, only the parent itself has to be correct.
 	if !claim.IsRoot() {
 		parent, err := game.GetParent(claim)
 		if err != nil {
 			return nil, err
 		}
-		agreeWithParent, err := s.agreeWithClaimPath(ctx, game, parent)
+		agreeWithParent, err := s.agreeWithParent(ctx, game, parent)
 		if err != nil {
 			return nil, err
 		}
@@ -156,6 +160,13 @@ func (s *claimSolver) agreeWithClaim(ctx context.Context, game types.Game, claim
 	return bytes.Equal(ourValue[:], claim.Value[:]), err
 }
 
+func (s *claimSolver) agreeWithParent(ctx context.Context, game types.Game, parent types.Claim) (bool, error) {
+	if s.defendAll {
+		return s.agreeWithClaim(ctx, game, parent)
+	}
+	return s.agreeWithClaimPath(ctx, game, parent)
+}
+
 // agreeWithClaimPath returns true if the every other claim in the path to root is correct according to the internal [TraceProvider].
 func (s *claimSolver) agreeWithClaimPath(ctx context.Context, game types.Game, claim types.Claim) (bool, error) {
 	agree, err := s.agreeWithClaim(ctx, game, claim)
@@ -0,0 +1,182 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/types"
+)
+
+// Strategy selects which of the claims in a game the solver responds to.
+type Strategy string
+
+const (
+	// StrategyHonest counters every claim that disputes the honest path from the root claim.
+	StrategyHonest Strategy = "honest"
+	// StrategyDefendAll counters every claim that disputes a claim we agree with,
+	// including claims in sub-trees that were started from an invalid claim.
+	// Steps are still only performed against claims on the honest path.
+	StrategyDefendAll Strategy = "defend-all"
+	// StrategyConservative only performs the actions that the honest strategy would perform
+	// when they are required to change the outcome of the root claim, minimising the bonds posted.
+	StrategyConservative Strategy = "conservative"
+)
+
+var Strategies = []Strategy{StrategyHonest, StrategyDefendAll, StrategyConservative}
+
+func (s Strategy) String() string {
+	return string(s)
+}
+
+// Set implements the Set method required by the [cli.Generic] interface.
+func (s *Strategy) Set(value string) error {
+	if !ValidStrategy(Strategy(value)) {
+		return fmt.Errorf("unknown solver strategy: %q", value)
+	}
+	*s = Strategy(value)
+	return nil
+}
+
+func (s *Strategy) Clone() any {
+	cpy := *s
+	return &cpy
+}
+
+func ValidStrategy(value Strategy) bool {
+	for _, s := range Strategies {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipReason describes why an action was deliberately not performed.
+type SkipReason string
+
+const (
+	// SkipReasonRootOutcomeSecured is used when the root claim already resolves the way we want.
+	SkipReasonRootOutcomeSecured SkipReason = "root-outcome-secured"
+	// SkipReasonNotRequired is used when other actions are sufficient to change the outcome of the root claim.
+	SkipReasonNotRequired SkipReason = "not-required"
+)
+
+// SkippedAction is an action the solver calculated but deliberately chose not to perform.
+type SkippedAction struct {
+	Action types.Action
+	Reason SkipReason
+}
+
+// outcomeFilter selects the minimal set of actions required to bring the root claim into the desired state.
+// A claim is countered if it was stepped against or has at least one uncountered child.
+// Any action against a claim counters it, as the new claim has no children yet.
+type outcomeFilter struct {
+	claims    map[int]types.Claim
+	children  map[int][]int
+	targets   map[int][]int
+	countered map[int]bool
+}
+
+func newOutcomeFilter(claims []types.Claim, actions []types.Action) *outcomeFilter {
+	f := &outcomeFilter{
+		claims:    make(map[int]types.Claim, len(claims)),
+		children:  make(map[int][]int),
+		targets:   make(map[int][]int),
+		countered: make(map[int]bool),
+	}
+	for _, claim := range claims {
+		f.claims[claim.ContractIndex] = claim
+		if !claim.IsRoot() {
+			f.children[claim.ParentContractIndex] = append(f.children[claim.ParentContractIndex], claim.ContractIndex)
+		}
+	}
+	for i, action := range actions {
+		f.targets[action.ParentIdx] = append(f.targets[action.ParentIdx], i)
+	}
+	return f
+}
+
+// isCountered returns true if the claim at idx currently resolves as countered.
+func (f *outcomeFilter) isCountered(idx int) bool {
+	if countered, ok := f.countered[idx]; ok {
+		return countered
+	}
+	countered := f.claims[idx].Countered
+	for _, child := range f.children[idx] {
+		if countered {
+			break
+		}
+		countered = !f.isCountered(child)
+	}
+	f.countered[idx] = countered
+	return countered
+}
+
+// require returns the indices of the actions needed to bring the claim at idx into the wanted state.
+// Returns false if the available actions can't achieve it.
+func (f *outcomeFilter) require(idx int, countered bool) ([]int, bool) {
+	if f.isCountered(idx) == countered {
+		return nil, true
+	}
+	if countered {
+		// A single action against the claim is enough, otherwise one of its children must become uncountered.
+		if actions := f.targets[idx]; len(actions) > 0 {
+			return actions[:1], true
+		}
+		for _, child := range f.children[idx] {
+			if selected, ok := f.require(child, false); ok {
+				return selected, true
+			}
+		}
+		return nil, false
+	}
+	if f.claims[idx].Countered {
+		// Claims that were stepped against can't be uncountered.
+		return nil, false
+	}
+	var selected []int
+	for _, child := range f.children[idx] {
+		if f.isCountered(child) {
+			continue
+		}
+		childActions, ok := f.require(child, true)
+		if !ok {
+			return nil, false
+		}
+		selected = append(selected, childActions...)
+	}
+	return selected, true
+}
+
+// filterForRootOutcome splits the actions into the ones required to change the outcome of the root claim
+// and the ones that can be skipped. If the required actions can't be determined, all actions are performed.
+func filterForRootOutcome(game types.Game, actions []types.Action) ([]types.Action, []SkippedAction) {
+	claims := game.Claims()
+	if len(actions) == 0 || len(claims) == 0 {
+		return actions, nil
+	}
+	root := claims[0]
+	f := newOutcomeFilter(claims, actions)
+	wantCountered := !game.AgreeWithClaimLevel(root)
+	reason := SkipReasonNotRequired
+	if f.isCountered(root.ContractIndex) == wantCountered {
+		reason = SkipReasonRootOutcomeSecured
+	}
+	selected, ok := f.require(root.ContractIndex, wantCountered)
+	if !ok {
+		return actions, nil
+	}
+	keep := make(map[int]bool, len(selected))
+	for _, i := range selected {
+		keep[i] = true
+	}
+	var required []types.Action
+	var skipped []SkippedAction
+	for i, action := range actions {
+		if keep[i] {
+			required = append(required, action)
+		} else {
+			skipped = append(skipped, SkippedAction{Action: action, Reason: reason})
+		}
+	}
+	return required, skipped
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
 
 	"github.com/BLASTchain/blast/bl-challenger/config"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault"
 	"github.com/BLASTchain/blast/bl-challenger/game/fault/contracts"
+	"github.com/BLASTchain/blast/bl-challenger/game/fault/journal"
 	"github.com/BLASTchain/blast/bl-challenger/game/loader"
 	"github.com/BLASTchain/blast/bl-challenger/game/registry"
 	"github.com/BLASTchain/blast/bl-challenger/game/scheduler"
@@ -29,8 +33,11 @@ type Service struct {
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
 
+	dryRunJournal *journal.Journal
+
 	pprofSrv   *httputil.HTTPServer
 	metricsSrv *httputil.HTTPServer
+	journalSrv *httputil.HTTPServer
 }
 
 func (s *Service) Stop(ctx context.Context) error {
@@ -44,6 +51,12 @@ func (s *Service) Stop(ctx context.Context) error {
 	if s.metricsSrv != nil {
 		result = errors.Join(result, s.metricsSrv.Stop(ctx))
 	}
+	if s.journalSrv != nil {
+		result = errors.Join(result, s.journalSrv.Stop(ctx))
+	}
+	if s.dryRunJournal != nil {
+		result = errors.Join(result, s.dryRunJournal.Close())
+	}
 	return result
 }
 
@@ -51,9 +64,14 @@ func (s *Service) Stop(ctx context.Context) error {
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
 	cl := clock.SystemClock
 	m := metrics.NewMetrics()
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &m.TxMetrics, cfg.TxMgrConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+	// Dry-run mode records transactions instead of sending them, so it runs without a transaction manager.
+	var txMgr txmgr.TxManager
+	if !cfg.DryRun {
+		simpleTxMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &m.TxMetrics, cfg.TxMgrConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+		}
+		txMgr = simpleTxMgr
 	}
 
 	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, cfg.L1EthRpc)
@@ -86,7 +104,28 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 		}
 		logger.Info("started metrics server", "addr", metricsSrv.Addr())
 		s.metricsSrv = metricsSrv
-		m.StartBalanceMetrics(ctx, logger, l1Client, txMgr.From())
+		if txMgr != nil {
+			m.StartBalanceMetrics(ctx, logger, l1Client, txMgr.From())
+		}
+	}
+
+	if cfg.DryRun {
+		journalPath := cfg.DryRunJournalPath()
+		dryRunJournal, err := journal.NewJournal(journalPath)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to open dry-run journal: %w", err), s.Stop(ctx))
+		}
+		s.dryRunJournal = dryRunJournal
+		logger.Warn("running in dry-run mode, transactions will not be sent", "journal", journalPath)
+
+		mux := http.NewServeMux()
+		mux.Handle("/journal", dryRunJournal)
+		journalSrv, err := httputil.StartHTTPServer(net.JoinHostPort(cfg.DryRunHTTPAddr, strconv.Itoa(cfg.DryRunHTTPPort)), mux)
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to start dry-run journal server: %w", err), s.Stop(ctx))
+		}
+		s.journalSrv = journalSrv
+		logger.Info("started dry-run journal server", "addr", journalSrv.Addr())
 	}
 
 	factoryContract, err := contracts.NewDisputeGameFactoryContract(cfg.GameFactoryAddress, batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize))
@@ -96,7 +135,7 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 	loader := loader.NewGameLoader(factoryContract)
 
 	gameTypeRegistry := registry.NewGameTypeRegistry()
-	fault.RegisterGameTypes(gameTypeRegistry, ctx, logger, m, cfg, txMgr, l1Client)
+	fault.RegisterGameTypes(gameTypeRegistry, ctx, logger, m, cfg, txMgr, l1Client, s.dryRunJournal)
 
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(
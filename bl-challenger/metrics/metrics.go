@@ -24,6 +24,7 @@ type Metricer interface {
 
 	RecordGameStep()
 	RecordGameMove()
+	RecordActionSkipped(reason string)
 	RecordCannonExecutionTime(t float64)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
@@ -49,8 +50,9 @@ type Metrics struct {
 
 	executors prometheus.GaugeVec
 
-	moves prometheus.Counter
-	steps prometheus.Counter
+	moves          prometheus.Counter
+	steps          prometheus.Counter
+	skippedActions prometheus.CounterVec
 
 	cannonExecutionTime prometheus.Histogram
 
@@ -100,6 +102,13 @@ func NewMetrics() *Metrics {
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
 		}),
+		skippedActions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "skipped_actions",
+			Help:      "Number of game actions deliberately skipped by the solver strategy",
+		}, []string{
+			"reason",
+		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "cannon_execution_time",
@@ -165,6 +174,10 @@ func (m *Metrics) RecordGameStep() {
 	m.steps.Add(1)
 }
 
+func (m *Metrics) RecordActionSkipped(reason string) {
+	m.skippedActions.WithLabelValues(reason).Add(1)
+}
+
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
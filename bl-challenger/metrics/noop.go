@@ -13,8 +13,9 @@ var NoopMetrics Metricer = new(NoopMetricsImpl)
 func (*NoopMetricsImpl) RecordInfo(version string) {}
 func (*NoopMetricsImpl) RecordUp()                 {}
 
-func (*NoopMetricsImpl) RecordGameMove() {}
-func (*NoopMetricsImpl) RecordGameStep() {}
+func (*NoopMetricsImpl) RecordGameMove()                   {}
+func (*NoopMetricsImpl) RecordGameStep()                   {}
+func (*NoopMetricsImpl) RecordActionSkipped(reason string) {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
 
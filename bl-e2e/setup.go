@@ -38,6 +38,7 @@ import (
 
 	bss "github.com/BLASTchain/blast/bl-batcher/batcher"
 	"github.com/BLASTchain/blast/bl-batcher/compressor"
+	batcherFlags "github.com/BLASTchain/blast/bl-batcher/flags"
 	"github.com/BLASTchain/blast/bl-bindings/predeploys"
 	"github.com/BLASTchain/blast/bl-chain-ops/genesis"
 	"github.com/BLASTchain/blast/bl-e2e/config"
@@ -726,8 +727,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			Level:  log.LvlInfo,
 			Format: oplog.FormatText,
 		},
-		Stopped:   sys.cfg.DisableBatcher, // Batch submitter may be enabled later
-		BatchType: batchType,
+		Stopped:              sys.cfg.DisableBatcher, // Batch submitter may be enabled later
+		BatchType:            batchType,
+		DataAvailabilityType: batcherFlags.CalldataType,
 	}
 	// Batch Submitter
 	batcher, err := bss.BatcherServiceFromCLIConfig(context.Background(), "0.0.1", batcherCLIConfig, sys.cfg.Loggers["batcher"])
@@ -0,0 +1,258 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+var (
+	ErrNotLeader = errors.New("not the sequencer leader")
+	// ErrAheadOfCommitted is returned if the unsafe head is ahead of the latest committed payload.
+	// The blocks after the committed payload were never published, and must not be built upon.
+	ErrAheadOfCommitted = errors.New("unsafe head is ahead of the latest committed payload")
+)
+
+// Consensus is the leader election backend shared by the sequencers of a cluster.
+// At most one sequencer holds leadership at a time, and only the leader may commit unsafe payloads.
+type Consensus interface {
+	// Campaign attempts to acquire leadership without blocking, and returns true if this node is the leader.
+	Campaign(ctx context.Context) (bool, error)
+	// Leader returns true if this node currently holds leadership.
+	Leader() bool
+	// Resign gives up leadership, if held.
+	Resign() error
+	// CommitUnsafePayload durably commits the payload to the cluster.
+	// It returns ErrNotLeader if this node does not hold leadership.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+	// LatestUnsafePayload returns the latest payload committed to the cluster, or nil if none was committed yet.
+	LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error)
+	Close() error
+}
+
+// SequencerControl is the part of the rollup driver that is controlled by the conductor.
+type SequencerControl interface {
+	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error
+	StartSequencer(ctx context.Context, blockHash common.Hash) error
+	StopSequencer(ctx context.Context) (common.Hash, error)
+	SequencerActive(ctx context.Context) (bool, error)
+}
+
+type Config struct {
+	Enabled bool
+
+	// LockDir is the directory shared by the sequencers of the cluster,
+	// holding the leader lock and the latest committed unsafe payload.
+	LockDir string
+
+	// Interval is the interval at which leadership is checked and campaigned for.
+	Interval time.Duration
+
+	// Consensus optionally overrides the file-lock backend, e.g. for testing. LockDir is ignored if set.
+	Consensus Consensus
+}
+
+func (c *Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Consensus == nil && c.LockDir == "" {
+		return errors.New("conductor lock dir is required")
+	}
+	if c.Interval <= 0 {
+		return errors.New("conductor interval must be positive")
+	}
+	return nil
+}
+
+// Setup returns the consensus backend of the config.
+func (c *Config) Setup() (Consensus, error) {
+	if c.Consensus != nil {
+		return c.Consensus, nil
+	}
+	return NewFileConsensus(c.LockDir)
+}
+
+// Conductor runs the sequencer of a node in active/standby mode.
+// The sequencer is started on the leader of the cluster, once the node has synced the latest committed unsafe payload,
+// and stopped when leadership is lost. Unsafe payloads must be committed to the cluster before they are published,
+// so that a new leader never sequences on top of a chain that differs from the one seen by the network.
+type Conductor struct {
+	log      log.Logger
+	cons     Consensus
+	seq      SequencerControl
+	interval time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewConductor(log log.Logger, cons Consensus, seq SequencerControl, interval time.Duration) *Conductor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Conductor{
+		log:      log,
+		cons:     cons,
+		seq:      seq,
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (c *Conductor) Start() {
+	c.wg.Add(1)
+	go c.loop()
+}
+
+// Stop stops the sequencer and gives up leadership.
+func (c *Conductor) Stop(ctx context.Context) error {
+	c.cancel()
+	c.wg.Wait()
+	if active, err := c.seq.SequencerActive(ctx); err == nil && active {
+		if _, err := c.seq.StopSequencer(ctx); err != nil {
+			c.log.Warn("Failed to stop sequencer", "err", err)
+		}
+	}
+	return c.cons.Close()
+}
+
+// CommitUnsafePayload commits the payload to the cluster.
+// If the commit fails, leadership is given up so that another sequencer can take over.
+func (c *Conductor) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if !c.cons.Leader() {
+		return ErrNotLeader
+	}
+	if err := c.cons.CommitUnsafePayload(ctx, payload); err != nil {
+		c.log.Error("Failed to commit unsafe payload, resigning leadership", "id", payload.ID(), "err", err)
+		if err := c.cons.Resign(); err != nil {
+			c.log.Error("Failed to resign leadership", "err", err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Conductor) loop() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(c.ctx, c.interval*10)
+		if err := c.step(ctx); err != nil {
+			c.log.Warn("Sequencer conductor step failed", "err", err)
+		}
+		cancel()
+		select {
+		case <-ticker.C:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// step stops the sequencer if leadership was lost, and otherwise campaigns for leadership
+// and starts the sequencer once this node is the leader.
+func (c *Conductor) step(ctx context.Context) error {
+	active, err := c.seq.SequencerActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if sequencer is active: %w", err)
+	}
+	if !c.cons.Leader() {
+		if active {
+			c.log.Warn("Stopping sequencer, not the leader")
+			if _, err := c.seq.StopSequencer(ctx); err != nil {
+				return fmt.Errorf("failed to stop sequencer: %w", err)
+			}
+		}
+		// Leave leadership to a sequencer that is not ahead of the cluster,
+		// until the uncommitted blocks are reorged out by the unsafe or safe chain.
+		if err := c.checkNotAhead(ctx); err != nil {
+			return err
+		}
+		leader, err := c.cons.Campaign(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to campaign for leadership: %w", err)
+		}
+		if !leader {
+			return nil
+		}
+		c.log.Info("Acquired sequencer leadership")
+	}
+	if active {
+		return nil
+	}
+	return c.startSequencer(ctx)
+}
+
+// checkNotAhead returns ErrAheadOfCommitted if the unsafe head is ahead of the latest committed payload.
+func (c *Conductor) checkNotAhead(ctx context.Context) error {
+	committed, err := c.cons.LatestUnsafePayload(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load latest committed payload: %w", err)
+	}
+	if committed == nil {
+		return nil
+	}
+	status, err := c.seq.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	if status.UnsafeL2.Number > uint64(committed.BlockNumber) {
+		return fmt.Errorf("%w: unsafe head %s, committed %s", ErrAheadOfCommitted, status.UnsafeL2, committed.ID())
+	}
+	return nil
+}
+
+// startSequencer starts the sequencer at the unsafe head, once it includes the latest committed payload.
+func (c *Conductor) startSequencer(ctx context.Context) error {
+	committed, err := c.cons.LatestUnsafePayload(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load latest committed payload: %w", err)
+	}
+	var status *eth.SyncStatus
+	if committed != nil {
+		ref, s, err := c.seq.BlockRefWithStatus(ctx, uint64(committed.BlockNumber))
+		if s == nil {
+			return fmt.Errorf("failed to get sync status: %w", err)
+		}
+		status = s
+		if status.UnsafeL2.Number < uint64(committed.BlockNumber) || (err == nil && ref.Hash != committed.BlockHash) {
+			c.log.Info("Waiting for unsafe head to sync committed payload", "unsafe", status.UnsafeL2, "committed", committed.ID())
+			if err := c.seq.OnUnsafeL2Payload(ctx, committed); err != nil {
+				return fmt.Errorf("failed to insert committed payload %s: %w", committed.ID(), err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get L2 block %d: %w", committed.BlockNumber, err)
+		}
+		if status.UnsafeL2.Number > uint64(committed.BlockNumber) {
+			// Only the previous leader can be ahead, with blocks that failed to commit and were never published.
+			c.log.Warn("Resigning leadership, unsafe head is ahead of committed payload", "unsafe", status.UnsafeL2, "committed", committed.ID())
+			if err := c.cons.Resign(); err != nil {
+				return fmt.Errorf("failed to resign leadership: %w", err)
+			}
+			return fmt.Errorf("%w: unsafe head %s, committed %s", ErrAheadOfCommitted, status.UnsafeL2, committed.ID())
+		}
+	} else {
+		status, err = c.seq.SyncStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get sync status: %w", err)
+		}
+	}
+	c.log.Info("Starting sequencer as leader", "unsafe", status.UnsafeL2)
+	if err := c.seq.StartSequencer(ctx, status.UnsafeL2.Hash); err != nil {
+		return fmt.Errorf("failed to start sequencer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+type mockConsensus struct {
+	leader    bool
+	canLead   bool
+	committed *eth.ExecutionPayload
+	commitErr error
+}
+
+func (m *mockConsensus) Campaign(ctx context.Context) (bool, error) {
+	m.leader = m.leader || m.canLead
+	return m.leader, nil
+}
+
+func (m *mockConsensus) Leader() bool {
+	return m.leader
+}
+
+func (m *mockConsensus) Resign() error {
+	m.leader = false
+	return nil
+}
+
+func (m *mockConsensus) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if m.commitErr != nil {
+		return m.commitErr
+	}
+	m.committed = payload
+	return nil
+}
+
+func (m *mockConsensus) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return m.committed, nil
+}
+
+func (m *mockConsensus) Close() error {
+	return m.Resign()
+}
+
+type mockSequencer struct {
+	active   bool
+	unsafe   eth.L2BlockRef
+	blocks   map[uint64]eth.L2BlockRef
+	inserted []*eth.ExecutionPayload
+}
+
+func (m *mockSequencer) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error) {
+	status := &eth.SyncStatus{UnsafeL2: m.unsafe}
+	ref, ok := m.blocks[num]
+	if !ok {
+		return eth.L2BlockRef{}, status, ethereum.NotFound
+	}
+	return ref, status, nil
+}
+
+func (m *mockSequencer) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{UnsafeL2: m.unsafe}, nil
+}
+
+func (m *mockSequencer) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	m.inserted = append(m.inserted, payload)
+	return nil
+}
+
+func (m *mockSequencer) StartSequencer(ctx context.Context, blockHash common.Hash) error {
+	if m.active {
+		return errors.New("already active")
+	}
+	if blockHash != m.unsafe.Hash {
+		return errors.New("block hash does not match unsafe head")
+	}
+	m.active = true
+	return nil
+}
+
+func (m *mockSequencer) StopSequencer(ctx context.Context) (common.Hash, error) {
+	if !m.active {
+		return common.Hash{}, errors.New("already stopped")
+	}
+	m.active = false
+	return m.unsafe.Hash, nil
+}
+
+func (m *mockSequencer) SequencerActive(ctx context.Context) (bool, error) {
+	return m.active, nil
+}
+
+func setupConductor(t *testing.T) (*Conductor, *mockConsensus, *mockSequencer) {
+	cons := &mockConsensus{}
+	seq := &mockSequencer{
+		unsafe: eth.L2BlockRef{Hash: common.Hash{0x05}, Number: 5},
+		blocks: map[uint64]eth.L2BlockRef{5: {Hash: common.Hash{0x05}, Number: 5}},
+	}
+	c := NewConductor(testlog.Logger(t, log.LvlInfo), cons, seq, time.Second)
+	return c, cons, seq
+}
+
+func TestConductorStandby(t *testing.T) {
+	c, _, seq := setupConductor(t)
+	require.NoError(t, c.step(context.Background()))
+	require.False(t, seq.active, "sequencer must not start without leadership")
+}
+
+func TestConductorStartsLeader(t *testing.T) {
+	t.Run("NoCommittedPayload", func(t *testing.T) {
+		c, cons, seq := setupConductor(t)
+		cons.canLead = true
+		require.NoError(t, c.step(context.Background()))
+		require.True(t, seq.active)
+	})
+
+	t.Run("SyncedCommittedPayload", func(t *testing.T) {
+		c, cons, seq := setupConductor(t)
+		cons.canLead = true
+		cons.committed = &eth.ExecutionPayload{BlockNumber: 5, BlockHash: common.Hash{0x05}}
+		require.NoError(t, c.step(context.Background()))
+		require.True(t, seq.active)
+		require.Empty(t, seq.inserted)
+	})
+
+	t.Run("BehindCommittedPayload", func(t *testing.T) {
+		c, cons, seq := setupConductor(t)
+		cons.canLead = true
+		cons.committed = &eth.ExecutionPayload{BlockNumber: 7, BlockHash: common.Hash{0x07}}
+		require.NoError(t, c.step(context.Background()))
+		require.False(t, seq.active, "must wait for the committed payload")
+		require.Equal(t, []*eth.ExecutionPayload{cons.committed}, seq.inserted)
+
+		// Once the unsafe head includes the committed payload the sequencer is started
+		seq.unsafe = eth.L2BlockRef{Hash: common.Hash{0x07}, Number: 7}
+		seq.blocks[7] = seq.unsafe
+		require.NoError(t, c.step(context.Background()))
+		require.True(t, seq.active)
+	})
+
+	t.Run("ConflictingCommittedPayload", func(t *testing.T) {
+		c, cons, seq := setupConductor(t)
+		cons.canLead = true
+		cons.committed = &eth.ExecutionPayload{BlockNumber: 5, BlockHash: common.Hash{0xbb}}
+		require.NoError(t, c.step(context.Background()))
+		require.False(t, seq.active, "must reorg to the committed payload")
+		require.Equal(t, []*eth.ExecutionPayload{cons.committed}, seq.inserted)
+	})
+
+	t.Run("AheadOfCommittedPayload", func(t *testing.T) {
+		c, cons, seq := setupConductor(t)
+		cons.leader = true
+		cons.committed = &eth.ExecutionPayload{BlockNumber: 4, BlockHash: common.Hash{0x04}}
+		seq.blocks[4] = eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 4}
+		require.ErrorIs(t, c.step(context.Background()), ErrAheadOfCommitted)
+		require.False(t, seq.active, "must not build on top of uncommitted blocks")
+		require.False(t, cons.Leader(), "must leave leadership to another sequencer")
+	})
+}
+
+func TestConductorNoCampaignAheadOfCommitted(t *testing.T) {
+	c, cons, seq := setupConductor(t)
+	cons.canLead = true
+	cons.committed = &eth.ExecutionPayload{BlockNumber: 4, BlockHash: common.Hash{0x04}}
+	require.ErrorIs(t, c.step(context.Background()), ErrAheadOfCommitted)
+	require.False(t, cons.Leader())
+	require.False(t, seq.active)
+
+	// Once the uncommitted blocks are reorged out, the node campaigns again
+	seq.unsafe = eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 4}
+	seq.blocks[4] = seq.unsafe
+	require.NoError(t, c.step(context.Background()))
+	require.True(t, cons.Leader())
+	require.True(t, seq.active)
+}
+
+func TestConductorStopsWithoutLeadership(t *testing.T) {
+	c, cons, seq := setupConductor(t)
+	cons.canLead = true
+	require.NoError(t, c.step(context.Background()))
+	require.True(t, seq.active)
+
+	require.NoError(t, cons.Resign())
+	cons.canLead = false
+	require.NoError(t, c.step(context.Background()))
+	require.False(t, seq.active)
+}
+
+func TestConductorCommitUnsafePayload(t *testing.T) {
+	ctx := context.Background()
+	c, cons, _ := setupConductor(t)
+	payload := &eth.ExecutionPayload{BlockNumber: 6, BlockHash: common.Hash{0x06}}
+	require.ErrorIs(t, c.CommitUnsafePayload(ctx, payload), ErrNotLeader)
+
+	cons.leader = true
+	require.NoError(t, c.CommitUnsafePayload(ctx, payload))
+	require.Equal(t, payload, cons.committed)
+
+	// A failed commit gives up leadership
+	cons.commitErr = errors.New("boom")
+	require.ErrorIs(t, c.CommitUnsafePayload(ctx, payload), cons.commitErr)
+	require.False(t, cons.Leader())
+}
@@ -0,0 +1,132 @@
+package conductor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+const (
+	lockFileName    = "leader.lock"
+	payloadFileName = "unsafe_payload.json"
+)
+
+var errLocked = errors.New("file is locked")
+
+var _ Consensus = (*FileConsensus)(nil)
+
+// FileConsensus is a [Consensus] backend for sequencers sharing a directory, e.g. on the same host.
+// Leadership is an exclusive lock on a file in the directory, which is released by the OS if the leader crashes.
+// The latest unsafe payload is committed to a file next to it.
+type FileConsensus struct {
+	mu   sync.Mutex
+	dir  string
+	lock *os.File // open while holding leadership
+}
+
+func NewFileConsensus(dir string) (*FileConsensus, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create conductor dir (%v): %w", dir, err)
+	}
+	return &FileConsensus{dir: dir}, nil
+}
+
+func (f *FileConsensus) Campaign(ctx context.Context) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lock != nil {
+		return true, nil
+	}
+	path := filepath.Join(f.dir, lockFileName)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return false, fmt.Errorf("open lock file (%v): %w", path, err)
+	}
+	if err := tryLock(file); errors.Is(err, errLocked) {
+		_ = file.Close()
+		return false, nil
+	} else if err != nil {
+		_ = file.Close()
+		return false, fmt.Errorf("lock file (%v): %w", path, err)
+	}
+	f.lock = file
+	return true, nil
+}
+
+func (f *FileConsensus) Leader() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lock != nil
+}
+
+func (f *FileConsensus) Resign() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lock == nil {
+		return nil
+	}
+	// Closing the file releases the lock
+	err := f.lock.Close()
+	f.lock = nil
+	return err
+}
+
+// CommitUnsafePayload writes the payload to a temp file before renaming it into place,
+// so the committed payload is never corrupted if the leader crashes while committing.
+func (f *FileConsensus) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lock == nil {
+		return ErrNotLeader
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	path := filepath.Join(f.dir, payloadFileName)
+	tmpFile := path + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
+	}
+	defer file.Close() // Ensure file is closed even if write or sync fails
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("write payload to temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync payload temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close payload temp file (%v): %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		return fmt.Errorf("move temp payload file to final location: %w", err)
+	}
+	return nil
+}
+
+func (f *FileConsensus) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	path := filepath.Join(f.dir, payloadFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read payload file (%v): %w", path, err)
+	}
+	var payload eth.ExecutionPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("invalid payload file (%v): %w", path, err)
+	}
+	return &payload, nil
+}
+
+func (f *FileConsensus) Close() error {
+	return f.Resign()
+}
@@ -0,0 +1,54 @@
+package conductor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+func TestFileConsensus(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a, err := NewFileConsensus(dir)
+	require.NoError(t, err)
+	b, err := NewFileConsensus(dir)
+	require.NoError(t, err)
+
+	payload, err := b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, payload, "no payload committed yet")
+
+	leader, err := a.Campaign(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+	require.True(t, a.Leader())
+
+	leader, err = b.Campaign(ctx)
+	require.NoError(t, err)
+	require.False(t, leader, "lock is held by a")
+	require.False(t, b.Leader())
+
+	committed := &eth.ExecutionPayload{BlockNumber: 10, BlockHash: common.Hash{0xaa}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{{0x01}}}
+	require.ErrorIs(t, b.CommitUnsafePayload(ctx, committed), ErrNotLeader)
+	require.NoError(t, a.CommitUnsafePayload(ctx, committed))
+
+	payload, err = b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, committed, payload)
+
+	require.NoError(t, a.Resign())
+	require.False(t, a.Leader())
+	leader, err = b.Campaign(ctx)
+	require.NoError(t, err)
+	require.True(t, leader, "lock is released on resign")
+
+	leader, err = a.Campaign(ctx)
+	require.NoError(t, err)
+	require.False(t, leader)
+	require.NoError(t, b.Close())
+	require.NoError(t, a.Close())
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package conductor
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLock(file *os.File) error {
+	return errors.New("file locks are not supported on this platform")
+}
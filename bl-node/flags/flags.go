@@ -45,6 +45,16 @@ var (
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
 	/* Optional Flags */
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use, required to read blob data after the Ecotone upgrade",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
+	BeaconFallbackAddrs = &cli.StringSliceFlag{
+		Name:    "l1.beacon-fallbacks",
+		Usage:   "Addresses of L1 blob archiver HTTP endpoints serving the Beacon blob sidecars API, used in order when the L1 beacon node cannot serve blobs (e.g. after pruning)",
+		EnvVars: prefixEnvVars("L1_BEACON_FALLBACKS"),
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:    "rpc.addr",
 		Usage:   "RPC listening address",
@@ -67,6 +77,11 @@ var (
 		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
 		EnvVars: prefixEnvVars("RPC_ADMIN_STATE"),
 	}
+	SafeDBPath = &cli.StringFlag{
+		Name:    "safedb.path",
+		Usage:   "File path used to persist the safe head at each L1 block, to serve optimism_safeHeadAtL1Block. Disabled if not set.",
+		EnvVars: prefixEnvVars("SAFEDB_PATH"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -132,6 +147,22 @@ var (
 		Usage:   "Initialize the sequencer in a stopped state. The sequencer can be started using the admin_startSequencer RPC",
 		EnvVars: prefixEnvVars("SEQUENCER_STOPPED"),
 	}
+	ConductorEnabledFlag = &cli.BoolFlag{
+		Name:    "conductor.enabled",
+		Usage:   "Run the sequencer in active/standby mode, only sequencing while this node is the leader of the sequencer cluster. Requires sequencer.enabled.",
+		EnvVars: prefixEnvVars("CONDUCTOR_ENABLED"),
+	}
+	ConductorLockDirFlag = &cli.StringFlag{
+		Name:    "conductor.lock-dir",
+		Usage:   "Directory shared by the sequencers of the cluster, holding the leader lock and the latest committed unsafe block.",
+		EnvVars: prefixEnvVars("CONDUCTOR_LOCK_DIR"),
+	}
+	ConductorIntervalFlag = &cli.DurationFlag{
+		Name:    "conductor.interval",
+		Usage:   "Interval at which the conductor checks leadership and campaigns to become the leader.",
+		EnvVars: prefixEnvVars("CONDUCTOR_INTERVAL"),
+		Value:   time.Second,
+	}
 	SequencerMaxSafeLagFlag = &cli.Uint64Flag{
 		Name:     "sequencer.max-safe-lag",
 		Usage:    "Maximum number of L2 blocks for restricting the distance between L2 safe and unsafe. Disabled if 0.",
@@ -243,6 +274,27 @@ var (
 		Required: false,
 		Value:    false,
 	}
+	CheckpointL2Hash = &cli.StringFlag{
+		Name: "l2.checkpoint.hash",
+		Usage: "Hash of a trusted L2 block to start syncing from, if the execution engine has not finalized it yet. " +
+			"The block must be the block of an output proposal. Defaults to the latest output proposal the execution engine has the block of.",
+		EnvVars: prefixEnvVars("L2_CHECKPOINT_HASH"),
+	}
+	CheckpointL1Origin = &cli.StringFlag{
+		Name:    "l2.checkpoint.l1-origin",
+		Usage:   "Hash of the L1 origin of the trusted L2 checkpoint block. Not checked if not set.",
+		EnvVars: prefixEnvVars("L2_CHECKPOINT_L1_ORIGIN"),
+	}
+	CheckpointURL = &cli.StringFlag{
+		Name:    "l2.checkpoint.url",
+		Usage:   "RPC endpoint of a trusted rollup node, to fetch the output of the checkpoint from, if the execution engine does not have the state of the checkpoint.",
+		EnvVars: prefixEnvVars("L2_CHECKPOINT_URL"),
+	}
+	CheckpointOutputOracle = &cli.StringFlag{
+		Name:    "l2.checkpoint.l2oo-address",
+		Usage:   "Address of the L2OutputOracle contract on L1, to verify the checkpoint against. Enables checkpoint sync.",
+		EnvVars: prefixEnvVars("L2_CHECKPOINT_L2OO_ADDRESS"),
+	}
 	BetaExtraNetworks = &cli.BoolFlag{
 		Name:    "beta.extra-networks",
 		Usage:   "Legacy flag, ignored, all superchain-registry networks are enabled by default.",
@@ -273,6 +325,8 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	BeaconAddr,
+	BeaconFallbackAddrs,
 	RPCListenAddr,
 	RPCListenPort,
 	RollupConfig,
@@ -286,12 +340,16 @@ var optionalFlags = []cli.Flag{
 	VerifierL1Confs,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
+	ConductorEnabledFlag,
+	ConductorLockDirFlag,
+	ConductorIntervalFlag,
 	SequencerMaxSafeLagFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
 	RPCAdminPersistence,
+	SafeDBPath,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
 	MetricsPortFlag,
@@ -306,6 +364,10 @@ var optionalFlags = []cli.Flag{
 	BackupL2UnsafeSyncRPCTrustRPC,
 	L2EngineSyncEnabled,
 	SkipSyncStartCheck,
+	CheckpointL2Hash,
+	CheckpointL1Origin,
+	CheckpointURL,
+	CheckpointOutputOracle,
 	BetaExtraNetworks,
 	RollupHalt,
 	RollupLoadProtocolVersions,
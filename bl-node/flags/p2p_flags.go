@@ -14,45 +14,49 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName         = "p2p.disable"
-	NoDiscoveryName        = "p2p.no-discovery"
-	ScoringName            = "p2p.scoring"
-	PeerScoringName        = "p2p.scoring.peers"
-	PeerScoreBandsName     = "p2p.score.bands"
-	BanningName            = "p2p.ban.peers"
-	BanningThresholdName   = "p2p.ban.threshold"
-	BanningDurationName    = "p2p.ban.duration"
-	TopicScoringName       = "p2p.scoring.topics"
-	P2PPrivPathName        = "p2p.priv.path"
-	P2PPrivRawName         = "p2p.priv.raw"
-	ListenIPName           = "p2p.listen.ip"
-	ListenTCPPortName      = "p2p.listen.tcp"
-	ListenUDPPortName      = "p2p.listen.udp"
-	AdvertiseIPName        = "p2p.advertise.ip"
-	AdvertiseTCPPortName   = "p2p.advertise.tcp"
-	AdvertiseUDPPortName   = "p2p.advertise.udp"
-	BootnodesName          = "p2p.bootnodes"
-	StaticPeersName        = "p2p.static"
-	NetRestrictName        = "p2p.netrestrict"
-	HostMuxName            = "p2p.mux"
-	HostSecurityName       = "p2p.security"
-	PeersLoName            = "p2p.peers.lo"
-	PeersHiName            = "p2p.peers.hi"
-	PeersGraceName         = "p2p.peers.grace"
-	NATName                = "p2p.nat"
-	UserAgentName          = "p2p.useragent"
-	TimeoutNegotiationName = "p2p.timeout.negotiation"
-	TimeoutAcceptName      = "p2p.timeout.accept"
-	TimeoutDialName        = "p2p.timeout.dial"
-	PeerstorePathName      = "p2p.peerstore.path"
-	DiscoveryPathName      = "p2p.discovery.path"
-	SequencerP2PKeyName    = "p2p.sequencer.key"
-	GossipMeshDName        = "p2p.gossip.mesh.d"
-	GossipMeshDloName      = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName      = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName    = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName        = "p2p.sync.req-resp"
+	DisableP2PName          = "p2p.disable"
+	NoDiscoveryName         = "p2p.no-discovery"
+	ScoringName             = "p2p.scoring"
+	PeerScoringName         = "p2p.scoring.peers"
+	PeerScoreBandsName      = "p2p.score.bands"
+	BanningName             = "p2p.ban.peers"
+	BanningThresholdName    = "p2p.ban.threshold"
+	BanningDurationName     = "p2p.ban.duration"
+	TopicScoringName        = "p2p.scoring.topics"
+	P2PPrivPathName         = "p2p.priv.path"
+	P2PPrivRawName          = "p2p.priv.raw"
+	ListenIPName            = "p2p.listen.ip"
+	ListenTCPPortName       = "p2p.listen.tcp"
+	ListenUDPPortName       = "p2p.listen.udp"
+	AdvertiseIPName         = "p2p.advertise.ip"
+	AdvertiseTCPPortName    = "p2p.advertise.tcp"
+	AdvertiseUDPPortName    = "p2p.advertise.udp"
+	BootnodesName           = "p2p.bootnodes"
+	StaticPeersName         = "p2p.static"
+	NetRestrictName         = "p2p.netrestrict"
+	HostMuxName             = "p2p.mux"
+	HostSecurityName        = "p2p.security"
+	PeersLoName             = "p2p.peers.lo"
+	PeersHiName             = "p2p.peers.hi"
+	PeersGraceName          = "p2p.peers.grace"
+	NATName                 = "p2p.nat"
+	UserAgentName           = "p2p.useragent"
+	TimeoutNegotiationName  = "p2p.timeout.negotiation"
+	TimeoutAcceptName       = "p2p.timeout.accept"
+	TimeoutDialName         = "p2p.timeout.dial"
+	PeerstorePathName       = "p2p.peerstore.path"
+	DiscoveryPathName       = "p2p.discovery.path"
+	SequencerP2PKeyName     = "p2p.sequencer.key"
+	GossipMeshDName         = "p2p.gossip.mesh.d"
+	GossipMeshDloName       = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName       = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName     = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName  = "p2p.gossip.mesh.floodpublish"
+	SyncReqRespName         = "p2p.sync.req-resp"
+	SyncCacheDepthName      = "p2p.sync.req-resp.cache-depth"
+	AttestationsName        = "p2p.attestations"
+	AttestationsKeyName     = "p2p.attestations.key"
+	AttestationsTrustedName = "p2p.attestations.trusted"
 )
 
 // None of these flags are strictly required.
@@ -348,5 +352,32 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "SYNC_REQ_RESP"),
 		},
+		&cli.Uint64Flag{
+			Name:     SyncCacheDepthName,
+			Usage:    "Number of recent unsafe payloads to keep in the peerstore database, to serve P2P req-resp sync requests from without loading them from the engine. 0 to disable.",
+			Value:    2048,
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SYNC_REQ_RESP_CACHE_DEPTH"),
+		},
+		&cli.BoolFlag{
+			Name:     AttestationsName,
+			Usage:    "Enables the gossip topic of signed safe head attestations, to compare the local safe head with the attested safe heads of trusted nodes.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "ATTESTATIONS"),
+		},
+		&cli.StringFlag{
+			Name:     AttestationsKeyName,
+			Usage:    "Hex-encoded private key for signing off on the safe head attestations published by this node. No attestations are published if empty.",
+			Required: false,
+			Value:    "",
+			EnvVars:  p2pEnv(envPrefix, "ATTESTATIONS_KEY"),
+		},
+		&cli.StringFlag{
+			Name:     AttestationsTrustedName,
+			Usage:    "Comma-separated list of the addresses of trusted attesters, to track the safe head attestations of.",
+			Required: false,
+			Value:    "",
+			EnvVars:  p2pEnv(envPrefix, "ATTESTATIONS_TRUSTED"),
+		},
 	}
 }
@@ -0,0 +1,131 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/p2p"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/metrics"
+)
+
+// attestInterval is the interval at which the local safe head is checked for changes to attest to.
+const attestInterval = 2 * time.Second
+
+type attestationPublisher interface {
+	Publish(ctx context.Context, att *eth.SafeHeadAttestation, signer p2p.Signer) error
+}
+
+// attestLoop publishes an attestation of the local safe and finalized L2 heads whenever they,
+// or the L1 block they were derived up to, change.
+func (n *OpNode) attestLoop(ctx context.Context, pub attestationPublisher, signer p2p.Signer) {
+	ticker := time.NewTicker(attestInterval)
+	defer ticker.Stop()
+
+	var last eth.SafeHeadAttestation
+	for {
+		select {
+		case <-ticker.C:
+			status, err := n.l2Driver.SyncStatus(ctx)
+			if err != nil {
+				n.log.Warn("Failed to get sync status to attest to", "err", err)
+				continue
+			}
+			if status.CurrentL1 == (eth.L1BlockRef{}) || status.SafeL2 == (eth.L2BlockRef{}) {
+				continue // nothing derived yet
+			}
+			att := eth.SafeHeadAttestation{
+				L1:          status.CurrentL1.ID(),
+				SafeL2:      status.SafeL2.ID(),
+				FinalizedL2: status.FinalizedL2.ID(),
+			}
+			if att.L1 == last.L1 && att.SafeL2 == last.SafeL2 && att.FinalizedL2 == last.FinalizedL2 {
+				continue
+			}
+			att.Timestamp = uint64(time.Now().Unix())
+			if err := pub.Publish(ctx, &att, signer); err != nil {
+				n.log.Warn("Failed to publish safe head attestation", "err", err)
+				continue
+			}
+			n.log.Debug("Published safe head attestation", "l1", att.L1, "safe", att.SafeL2, "finalized", att.FinalizedL2)
+			last = att
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type attestationSource interface {
+	Latest() []p2p.SignedAttestation
+}
+
+type attestationsAPI struct {
+	src attestationSource
+	dr  driverClient
+	log log.Logger
+	m   metrics.RPCMetricer
+}
+
+func NewAttestationsAPI(src attestationSource, dr driverClient, log log.Logger, m metrics.RPCMetricer) *attestationsAPI {
+	return &attestationsAPI{
+		src: src,
+		dr:  dr,
+		log: log,
+		m:   m,
+	}
+}
+
+// SafeHeadDivergence compares the latest safe head attestation of each trusted attester with the local chain.
+func (api *attestationsAPI) SafeHeadDivergence(ctx context.Context) ([]eth.SafeHeadDivergence, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_safeHeadDivergence")
+	defer recordDur()
+
+	status, err := api.dr.SyncStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	out := make([]eth.SafeHeadDivergence, 0)
+	for _, signed := range api.src.Latest() {
+		att := signed.Attestation
+		d := eth.SafeHeadDivergence{
+			Attester:    signed.Attester,
+			Attestation: att,
+			LocalSafeL2: status.SafeL2.ID(),
+			SafeLag:     int64(att.SafeL2.Number) - int64(status.SafeL2.Number),
+		}
+		local, err := api.localBlock(ctx, att.SafeL2.Number, status)
+		if err != nil {
+			return nil, err
+		}
+		d.LocalL2 = local
+		d.Diverged = local != (eth.BlockID{}) && local.Hash != att.SafeL2.Hash
+		if !d.Diverged {
+			localFinalized, err := api.localBlock(ctx, att.FinalizedL2.Number, status)
+			if err != nil {
+				return nil, err
+			}
+			d.Diverged = localFinalized != (eth.BlockID{}) && localFinalized.Hash != att.FinalizedL2.Hash
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// localBlock returns the local L2 block at the given height, or a zeroed block ID if the local chain does not reach it.
+func (api *attestationsAPI) localBlock(ctx context.Context, num uint64, status *eth.SyncStatus) (eth.BlockID, error) {
+	if num > status.UnsafeL2.Number {
+		return eth.BlockID{}, nil
+	}
+	ref, _, err := api.dr.BlockRefWithStatus(ctx, num)
+	if errors.Is(err, ethereum.NotFound) {
+		return eth.BlockID{}, nil
+	} else if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to get local L2 block %d: %w", num, err)
+	}
+	return ref.ID(), nil
+}
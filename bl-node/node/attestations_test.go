@@ -0,0 +1,71 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-node/metrics"
+	"github.com/BLASTchain/blast/bl-node/p2p"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+type staticAttestations []p2p.SignedAttestation
+
+func (s staticAttestations) Latest() []p2p.SignedAttestation {
+	return s
+}
+
+func TestSafeHeadDivergence(t *testing.T) {
+	status := &eth.SyncStatus{
+		UnsafeL2:    eth.L2BlockRef{Hash: common.Hash{0x30}, Number: 30},
+		SafeL2:      eth.L2BlockRef{Hash: common.Hash{0x20}, Number: 20},
+		FinalizedL2: eth.L2BlockRef{Hash: common.Hash{0x10}, Number: 10},
+	}
+	drClient := &mockDriverClient{}
+	drClient.On("SyncStatus").Return(status)
+	drClient.ExpectBlockRefWithStatus(10, eth.L2BlockRef{Hash: common.Hash{0x10}, Number: 10}, status, nil)
+	drClient.ExpectBlockRefWithStatus(20, eth.L2BlockRef{Hash: common.Hash{0x20}, Number: 20}, status, nil)
+	drClient.ExpectBlockRefWithStatus(25, eth.L2BlockRef{Hash: common.Hash{0x25}, Number: 25}, status, nil)
+	drClient.ExpectBlockRefWithStatus(28, eth.L2BlockRef{}, status, ethereum.NotFound)
+
+	matching := p2p.SignedAttestation{Attester: common.Address{0x01}, Attestation: eth.SafeHeadAttestation{
+		SafeL2: eth.BlockID{Hash: common.Hash{0x25}, Number: 25}, FinalizedL2: eth.BlockID{Hash: common.Hash{0x10}, Number: 10}}}
+	diverged := p2p.SignedAttestation{Attester: common.Address{0x02}, Attestation: eth.SafeHeadAttestation{
+		SafeL2: eth.BlockID{Hash: common.Hash{0xaa}, Number: 20}, FinalizedL2: eth.BlockID{Hash: common.Hash{0x10}, Number: 10}}}
+	finalizedDiverged := p2p.SignedAttestation{Attester: common.Address{0x03}, Attestation: eth.SafeHeadAttestation{
+		SafeL2: eth.BlockID{Hash: common.Hash{0x20}, Number: 20}, FinalizedL2: eth.BlockID{Hash: common.Hash{0xbb}, Number: 10}}}
+	ahead := p2p.SignedAttestation{Attester: common.Address{0x04}, Attestation: eth.SafeHeadAttestation{
+		SafeL2: eth.BlockID{Hash: common.Hash{0x40}, Number: 40}, FinalizedL2: eth.BlockID{Hash: common.Hash{0x10}, Number: 10}}}
+	missing := p2p.SignedAttestation{Attester: common.Address{0x05}, Attestation: eth.SafeHeadAttestation{
+		SafeL2: eth.BlockID{Hash: common.Hash{0x28}, Number: 28}, FinalizedL2: eth.BlockID{Hash: common.Hash{0x10}, Number: 10}}}
+
+	api := NewAttestationsAPI(staticAttestations{matching, diverged, finalizedDiverged, ahead, missing}, drClient, testlog.Logger(t, log.LvlError), metrics.NoopMetrics)
+	out, err := api.SafeHeadDivergence(context.Background())
+	require.NoError(t, err)
+	require.Len(t, out, 5)
+
+	require.Equal(t, matching.Attester, out[0].Attester)
+	require.False(t, out[0].Diverged)
+	require.Equal(t, int64(5), out[0].SafeLag)
+	require.Equal(t, status.SafeL2.ID(), out[0].LocalSafeL2)
+	require.Equal(t, eth.BlockID{Hash: common.Hash{0x25}, Number: 25}, out[0].LocalL2)
+
+	require.True(t, out[1].Diverged, "conflicting safe head")
+	require.Equal(t, int64(0), out[1].SafeLag)
+
+	require.True(t, out[2].Diverged, "conflicting finalized head")
+
+	require.False(t, out[3].Diverged, "cannot diverge from blocks we do not have yet")
+	require.Equal(t, eth.BlockID{}, out[3].LocalL2)
+	require.Equal(t, int64(20), out[3].SafeLag)
+
+	require.False(t, out[4].Diverged)
+	require.Equal(t, eth.BlockID{}, out[4].LocalL2)
+	drClient.Mock.AssertExpectations(t)
+}
@@ -0,0 +1,200 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/sources/batching"
+)
+
+// CheckpointConfig configures the trusted L2 checkpoint to start syncing from.
+// The checkpoint is verified against the output root that was proposed to the L2OutputOracle on L1,
+// and is used as finalized L2 block if the execution engine does not have a later finalized block yet.
+type CheckpointConfig struct {
+	// L2Hash is the hash of the trusted L2 block. If zero, the latest output proposal
+	// at or before the unsafe head of the execution engine is used.
+	L2Hash common.Hash
+	// L1Origin is the hash of the L1 origin of the trusted L2 block. Not checked if zero.
+	L1Origin common.Hash
+	// URL of a trusted rollup node, to fetch the output of the checkpoint from,
+	// if the execution engine does not have the state of the checkpoint block anymore.
+	URL string
+	// OutputOracle is the address of the L2OutputOracle contract on L1. Checkpoint sync is disabled if zero.
+	OutputOracle common.Address
+}
+
+func (c *CheckpointConfig) Enabled() bool {
+	return c.OutputOracle != (common.Address{})
+}
+
+func (c *CheckpointConfig) Check() error {
+	if !c.Enabled() && (c.L2Hash != (common.Hash{}) || c.L1Origin != (common.Hash{}) || c.URL != "") {
+		return errors.New("checkpoint requires the L2OutputOracle address to verify it against")
+	}
+	if c.L2Hash == (common.Hash{}) && c.L1Origin != (common.Hash{}) {
+		return errors.New("checkpoint L1 origin requires the checkpoint L2 block hash")
+	}
+	return nil
+}
+
+const (
+	methodGetL2Output           = "getL2Output"
+	methodGetL2OutputIndexAfter = "getL2OutputIndexAfter"
+	methodLatestBlockNumber     = "latestBlockNumber"
+)
+
+// outputOracle reads output proposals from the L2OutputOracle contract.
+type outputOracle struct {
+	multiCaller *batching.MultiCaller
+	contract    *batching.BoundContract
+}
+
+func newOutputOracle(addr common.Address, caller *batching.MultiCaller) (*outputOracle, error) {
+	oracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2OutputOracle ABI: %w", err)
+	}
+	return &outputOracle{
+		multiCaller: caller,
+		contract:    batching.NewBoundContract(oracleAbi, addr),
+	}, nil
+}
+
+func (o *outputOracle) LatestBlockNumber(ctx context.Context, l1 eth.BlockID) (uint64, error) {
+	result, err := o.multiCaller.SingleCall(ctx, batching.BlockByHash(l1.Hash), o.contract.Call(methodLatestBlockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load latest proposed block number: %w", err)
+	}
+	return result.GetBigInt(0).Uint64(), nil
+}
+
+// OutputAfter returns the first output proposal at or after the given L2 block number.
+func (o *outputOracle) OutputAfter(ctx context.Context, l1 eth.BlockID, l2BlockNum uint64) (outputRoot eth.Bytes32, l2Block uint64, err error) {
+	block := batching.BlockByHash(l1.Hash)
+	result, err := o.multiCaller.SingleCall(ctx, block, o.contract.Call(methodGetL2OutputIndexAfter, new(big.Int).SetUint64(l2BlockNum)))
+	if err != nil {
+		return eth.Bytes32{}, 0, fmt.Errorf("failed to load output index after L2 block %d: %w", l2BlockNum, err)
+	}
+	return o.OutputAt(ctx, l1, result.GetBigInt(0).Uint64())
+}
+
+// OutputAt returns the output proposal at the given index.
+func (o *outputOracle) OutputAt(ctx context.Context, l1 eth.BlockID, index uint64) (outputRoot eth.Bytes32, l2Block uint64, err error) {
+	result, err := o.multiCaller.SingleCall(ctx, batching.BlockByHash(l1.Hash), o.contract.Call(methodGetL2Output, new(big.Int).SetUint64(index)))
+	if err != nil {
+		return eth.Bytes32{}, 0, fmt.Errorf("failed to load output proposal %d: %w", index, err)
+	}
+	var proposal bindings.TypesOutputProposal
+	result.GetStruct(0, &proposal)
+	return proposal.OutputRoot, proposal.L2BlockNumber.Uint64(), nil
+}
+
+type checkpointL1 interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+type checkpointL2 interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+type checkpointOracle interface {
+	LatestBlockNumber(ctx context.Context, l1 eth.BlockID) (uint64, error)
+	OutputAfter(ctx context.Context, l1 eth.BlockID, l2BlockNum uint64) (outputRoot eth.Bytes32, l2Block uint64, err error)
+}
+
+type checkpointSource interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// verifyCheckpoint determines the checkpoint L2 block, and verifies it against the output root
+// that was proposed to L1 for it, as of the finalized L1 block.
+// The output root is computed from the execution engine, or from the trusted rollup node if one is configured.
+func verifyCheckpoint(ctx context.Context, lgr log.Logger, cfg *CheckpointConfig, l1 checkpointL1, l2 checkpointL2, oracle checkpointOracle, src checkpointSource) (eth.L2BlockRef, error) {
+	l1Finalized, err := l1.L1BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get finalized L1 block: %w", err)
+	}
+
+	var checkpoint eth.L2BlockRef
+	if cfg.L2Hash != (common.Hash{}) {
+		checkpoint, err = l2.L2BlockRefByHash(ctx, cfg.L2Hash)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("execution engine does not have checkpoint block %s, sync the engine to it first: %w", cfg.L2Hash, err)
+		}
+		if cfg.L1Origin != (common.Hash{}) && checkpoint.L1Origin.Hash != cfg.L1Origin {
+			return eth.L2BlockRef{}, fmt.Errorf("checkpoint block %s has L1 origin %s, expected %s", checkpoint, checkpoint.L1Origin, cfg.L1Origin)
+		}
+	} else {
+		// Use the latest proposed output that the execution engine has the block of
+		head, err := l2.L2BlockRefByLabel(ctx, eth.Unsafe)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to get L2 head: %w", err)
+		}
+		num, err := oracle.LatestBlockNumber(ctx, l1Finalized.ID())
+		if err != nil {
+			return eth.L2BlockRef{}, err
+		}
+		if num > head.Number {
+			return eth.L2BlockRef{}, fmt.Errorf("execution engine head %s is behind the latest proposed output at block %d, sync the engine to it first, or configure a checkpoint block", head, num)
+		}
+		checkpoint, err = l2.L2BlockRefByNumber(ctx, num)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to get checkpoint block %d: %w", num, err)
+		}
+	}
+
+	// The L1 origin of the checkpoint must be canonical
+	l1Origin, err := l1.L1BlockRefByNumber(ctx, checkpoint.L1Origin.Number)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get L1 origin %s of checkpoint: %w", checkpoint.L1Origin, err)
+	}
+	if l1Origin.Hash != checkpoint.L1Origin.Hash {
+		return eth.L2BlockRef{}, fmt.Errorf("L1 origin %s of checkpoint %s is not canonical, expected %s", checkpoint.L1Origin, checkpoint, l1Origin)
+	}
+
+	var outputRoot eth.Bytes32
+	if src != nil {
+		out, err := src.OutputAtBlock(ctx, checkpoint.Number)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to fetch output of checkpoint from trusted node: %w", err)
+		}
+		if out.BlockRef.Hash != checkpoint.Hash {
+			return eth.L2BlockRef{}, fmt.Errorf("trusted node has block %s at the checkpoint height, but execution engine has %s", out.BlockRef, checkpoint)
+		}
+		outputRoot = eth.OutputRoot(&eth.OutputV0{
+			StateRoot:                eth.Bytes32(out.StateRoot),
+			MessagePasserStorageRoot: eth.Bytes32(out.WithdrawalStorageRoot),
+			BlockHash:                checkpoint.Hash,
+		})
+	} else {
+		out, err := l2.OutputV0AtBlock(ctx, checkpoint.Hash)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to compute output of checkpoint %s, the execution engine may not have its state, configure a trusted node to fetch it from: %w", checkpoint, err)
+		}
+		outputRoot = eth.OutputRoot(out)
+	}
+
+	proposedRoot, proposedBlock, err := oracle.OutputAfter(ctx, l1Finalized.ID(), checkpoint.Number)
+	if err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	if proposedBlock != checkpoint.Number {
+		return eth.L2BlockRef{}, fmt.Errorf("no output was proposed for checkpoint %s, the next proposal is at block %d", checkpoint, proposedBlock)
+	}
+	if proposedRoot != outputRoot {
+		return eth.L2BlockRef{}, fmt.Errorf("output root %s of checkpoint %s does not match proposed output root %s", outputRoot, checkpoint, proposedRoot)
+	}
+	lgr.Info("Verified checkpoint against proposed output", "checkpoint", checkpoint, "output_root", outputRoot, "l1_finalized", l1Finalized)
+	return checkpoint, nil
+}
@@ -0,0 +1,231 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/sources/batching"
+	batchingTest "github.com/BLASTchain/blast/bl-service/sources/batching/test"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/bl-service/testutils"
+)
+
+var oracleAddr = common.Address{0x0a}
+
+type checkpointChains struct {
+	l1Finalized eth.L1BlockRef
+	l1          map[uint64]eth.L1BlockRef
+	l2Head      eth.L2BlockRef
+	l2          map[uint64]eth.L2BlockRef
+	outputs     map[common.Hash]*eth.OutputV0
+}
+
+func (c *checkpointChains) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	return c.l1Finalized, nil
+}
+
+func (c *checkpointChains) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	if ref, ok := c.l1[num]; ok {
+		return ref, nil
+	}
+	return eth.L1BlockRef{}, ethereum.NotFound
+}
+
+func (c *checkpointChains) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return c.l2Head, nil
+}
+
+func (c *checkpointChains) L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error) {
+	for _, ref := range c.l2 {
+		if ref.Hash == l2Hash {
+			return ref, nil
+		}
+	}
+	return eth.L2BlockRef{}, ethereum.NotFound
+}
+
+func (c *checkpointChains) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	if ref, ok := c.l2[num]; ok {
+		return ref, nil
+	}
+	return eth.L2BlockRef{}, ethereum.NotFound
+}
+
+func (c *checkpointChains) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	if out, ok := c.outputs[blockHash]; ok {
+		return out, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+type checkpointNode map[uint64]*eth.OutputResponse
+
+func (n checkpointNode) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if out, ok := n[blockNum]; ok {
+		return out, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func setupCheckpointTest(t *testing.T) (*checkpointChains, *batchingTest.AbiBasedRpc, *outputOracle) {
+	rng := rand.New(rand.NewSource(1234))
+	chains := &checkpointChains{
+		l1:      make(map[uint64]eth.L1BlockRef),
+		l2:      make(map[uint64]eth.L2BlockRef),
+		outputs: make(map[common.Hash]*eth.OutputV0),
+	}
+	l1 := testutils.RandomBlockRef(rng)
+	l1.Number = 100
+	chains.l1[l1.Number] = l1
+	chains.l1Finalized = l1
+	l2 := testutils.RandomL2BlockRef(rng)
+	l2.Number = 10
+	l2.L1Origin = l1.ID()
+	for i := 0; i < 20; i++ {
+		chains.l2[l2.Number] = l2
+		chains.outputs[l2.Hash] = &eth.OutputV0{StateRoot: eth.Bytes32(testutils.RandomHash(rng)), MessagePasserStorageRoot: eth.Bytes32(testutils.RandomHash(rng)), BlockHash: l2.Hash}
+		l2 = testutils.NextRandomL2Ref(rng, 2, l2, l1.ID())
+		l2.L1Origin = l1.ID()
+	}
+	chains.l2Head = chains.l2[29]
+
+	oracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	stubRpc := batchingTest.NewAbiBasedRpc(t, oracleAddr, oracleAbi)
+	oracle, err := newOutputOracle(oracleAddr, batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize))
+	require.NoError(t, err)
+	return chains, stubRpc, oracle
+}
+
+func expectOutputAfter(stubRpc *batchingTest.AbiBasedRpc, l1 eth.BlockID, l2BlockNum uint64, index uint64, outputRoot eth.Bytes32, proposedBlock uint64) {
+	block := batching.BlockByHash(l1.Hash)
+	stubRpc.SetResponse(oracleAddr, methodGetL2OutputIndexAfter, block,
+		[]interface{}{new(big.Int).SetUint64(l2BlockNum)}, []interface{}{new(big.Int).SetUint64(index)})
+	stubRpc.SetResponse(oracleAddr, methodGetL2Output, block,
+		[]interface{}{new(big.Int).SetUint64(index)},
+		[]interface{}{bindings.TypesOutputProposal{
+			OutputRoot:    outputRoot,
+			Timestamp:     big.NewInt(1000),
+			L2BlockNumber: new(big.Int).SetUint64(proposedBlock),
+		}})
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlError)
+	ctx := context.Background()
+
+	t.Run("trusted hash", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		expectOutputAfter(stubRpc, chains.l1Finalized.ID(), 20, 3, eth.OutputRoot(chains.outputs[checkpoint.Hash]), 20)
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, L1Origin: checkpoint.L1Origin.Hash, OutputOracle: oracleAddr}
+		out, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, out)
+	})
+
+	t.Run("latest proposal", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[25]
+		stubRpc.SetResponse(oracleAddr, methodLatestBlockNumber, batching.BlockByHash(chains.l1Finalized.Hash), nil, []interface{}{big.NewInt(25)})
+		expectOutputAfter(stubRpc, chains.l1Finalized.ID(), 25, 4, eth.OutputRoot(chains.outputs[checkpoint.Hash]), 25)
+		out, err := verifyCheckpoint(ctx, logger, &CheckpointConfig{OutputOracle: oracleAddr}, chains, chains, oracle, nil)
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, out)
+	})
+
+	t.Run("engine behind latest proposal", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		stubRpc.SetResponse(oracleAddr, methodLatestBlockNumber, batching.BlockByHash(chains.l1Finalized.Hash), nil, []interface{}{big.NewInt(30)})
+		_, err := verifyCheckpoint(ctx, logger, &CheckpointConfig{OutputOracle: oracleAddr}, chains, chains, oracle, nil)
+		require.ErrorContains(t, err, "behind the latest proposed output")
+	})
+
+	t.Run("output from trusted node", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		output := chains.outputs[checkpoint.Hash]
+		delete(chains.outputs, checkpoint.Hash) // pruned state
+		src := checkpointNode{20: &eth.OutputResponse{
+			BlockRef:              checkpoint,
+			StateRoot:             common.Hash(output.StateRoot),
+			WithdrawalStorageRoot: common.Hash(output.MessagePasserStorageRoot),
+		}}
+		expectOutputAfter(stubRpc, chains.l1Finalized.ID(), 20, 3, eth.OutputRoot(output), 20)
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorIs(t, err, ethereum.NotFound, "cannot compute output without state")
+		out, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, src)
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, out)
+	})
+
+	t.Run("trusted node on other chain", func(t *testing.T) {
+		chains, _, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		src := checkpointNode{20: &eth.OutputResponse{BlockRef: chains.l2[21]}}
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, src)
+		require.ErrorContains(t, err, "trusted node has block")
+	})
+
+	t.Run("mismatching output root", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		expectOutputAfter(stubRpc, chains.l1Finalized.ID(), 20, 3, eth.Bytes32{0x01}, 20)
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorContains(t, err, "does not match proposed output root")
+	})
+
+	t.Run("not a proposal block", func(t *testing.T) {
+		chains, stubRpc, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[21]
+		expectOutputAfter(stubRpc, chains.l1Finalized.ID(), 21, 4, eth.Bytes32{0x01}, 25)
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorContains(t, err, "no output was proposed")
+	})
+
+	t.Run("wrong L1 origin", func(t *testing.T) {
+		chains, _, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, L1Origin: common.Hash{0x01}, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorContains(t, err, "has L1 origin")
+	})
+
+	t.Run("non-canonical L1 origin", func(t *testing.T) {
+		chains, _, oracle := setupCheckpointTest(t)
+		checkpoint := chains.l2[20]
+		chains.l1[checkpoint.L1Origin.Number] = eth.L1BlockRef{Hash: common.Hash{0x02}, Number: checkpoint.L1Origin.Number}
+		cfg := &CheckpointConfig{L2Hash: checkpoint.Hash, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorContains(t, err, "is not canonical")
+	})
+
+	t.Run("unknown checkpoint", func(t *testing.T) {
+		chains, _, oracle := setupCheckpointTest(t)
+		cfg := &CheckpointConfig{L2Hash: common.Hash{0x03}, OutputOracle: oracleAddr}
+		_, err := verifyCheckpoint(ctx, logger, cfg, chains, chains, oracle, nil)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+}
+
+func TestCheckpointConfigCheck(t *testing.T) {
+	require.NoError(t, (&CheckpointConfig{}).Check())
+	require.NoError(t, (&CheckpointConfig{OutputOracle: oracleAddr}).Check())
+	require.NoError(t, (&CheckpointConfig{OutputOracle: oracleAddr, L2Hash: common.Hash{1}, L1Origin: common.Hash{2}, URL: "http://localhost"}).Check())
+	require.Error(t, (&CheckpointConfig{L2Hash: common.Hash{1}}).Check())
+	require.Error(t, (&CheckpointConfig{URL: "http://localhost"}).Check())
+	require.Error(t, (&CheckpointConfig{OutputOracle: oracleAddr, L1Origin: common.Hash{2}}).Check())
+}
@@ -208,3 +208,41 @@ func (cfg *PreparedL1Endpoint) Check() error {
 
 	return nil
 }
+
+type L1BeaconEndpointSetup interface {
+	// Setup a HTTP client to a L1 beacon node to pull blobs from, and HTTP clients
+	// to fallback blob archivers serving the same Beacon API.
+	// It may return a nil client with nil error if no beacon endpoint is configured.
+	Setup(ctx context.Context, log log.Logger) (cl client.HTTP, fb []client.HTTP, err error)
+	Check() error
+}
+
+type L1BeaconEndpointConfig struct {
+	BeaconAddr string // Address of L1 Beacon-node HTTP endpoint to use (beacon namespace required)
+	// Addresses of blob archiver HTTP endpoints to fall back to when the beacon node
+	// cannot serve blob sidecars, e.g. because they have already been pruned.
+	BeaconFallbackAddrs []string
+}
+
+var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
+
+// Setup creates HTTP clients for the beacon node and its fallbacks.
+// It will return nil without error if no beacon endpoint is configured.
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.HTTP, []client.HTTP, error) {
+	if cfg.BeaconAddr == "" {
+		return nil, nil, nil
+	}
+	fb := make([]client.HTTP, 0, len(cfg.BeaconFallbackAddrs))
+	for _, addr := range cfg.BeaconFallbackAddrs {
+		fb = append(fb, client.NewBasicHTTPClient(addr, log))
+	}
+	return client.NewBasicHTTPClient(cfg.BeaconAddr, log), fb, nil
+}
+
+func (cfg *L1BeaconEndpointConfig) Check() error {
+	// empty addr is valid, as it is optional until the Ecotone upgrade is scheduled.
+	if cfg.BeaconAddr == "" && len(cfg.BeaconFallbackAddrs) > 0 {
+		return errors.New("L1 beacon fallbacks are configured, but no L1 beacon endpoint is")
+	}
+	return nil
+}
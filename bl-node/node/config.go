@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/BLASTchain/blast/bl-node/conductor"
 	"github.com/BLASTchain/blast/bl-node/flags"
 	"github.com/BLASTchain/blast/bl-node/p2p"
 	"github.com/BLASTchain/blast/bl-node/rollup"
@@ -20,6 +21,7 @@ type Config struct {
 	L1     L1EndpointSetup
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
+	Beacon L1BeaconEndpointSetup
 
 	Driver driver.Config
 
@@ -29,6 +31,10 @@ type Config struct {
 	// if the node is sequencing and if the p2p stack is enabled
 	P2PSigner p2p.SignerSetup
 
+	// AttestationSigner will be used for signing off on the safe head attestations of this node,
+	// if the p2p stack and the safe head attestations topic are enabled. Optional.
+	AttestationSigner p2p.SignerSetup
+
 	RPC RPCConfig
 
 	P2P p2p.SetupP2P
@@ -42,6 +48,9 @@ type Config struct {
 
 	ConfigPersistence ConfigPersistence
 
+	// Conductor runs the sequencer in active/standby mode with the other sequencers of a cluster
+	Conductor conductor.Config
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -63,6 +72,12 @@ type Config struct {
 
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
+
+	// Path to the database of safe heads by L1 block. Disabled if empty.
+	SafeDBPath string
+
+	// Trusted L2 checkpoint to start syncing from
+	Checkpoint CheckpointConfig
 }
 
 type RPCConfig struct {
@@ -103,6 +118,14 @@ func (cfg *Config) LoadPersisted(log log.Logger) error {
 	if !cfg.Driver.SequencerEnabled {
 		return nil
 	}
+	if cfg.Conductor.Enabled {
+		// The sequencer is started by the conductor once the node is the leader
+		if !cfg.Driver.SequencerStopped {
+			log.Info(fmt.Sprintf("Overriding %v, sequencer is started by the conductor", flags.SequencerStoppedFlag.Name))
+		}
+		cfg.Driver.SequencerStopped = true
+		return nil
+	}
 	if state, err := cfg.ConfigPersistence.SequencerState(); err != nil {
 		return err
 	} else if state != StateUnset {
@@ -125,6 +148,11 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if cfg.Beacon != nil {
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
@@ -139,6 +167,15 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if err := cfg.Checkpoint.Check(); err != nil {
+		return fmt.Errorf("checkpoint config error: %w", err)
+	}
+	if err := cfg.Conductor.Check(); err != nil {
+		return fmt.Errorf("conductor config error: %w", err)
+	}
+	if cfg.Conductor.Enabled && !cfg.Driver.SequencerEnabled {
+		return errors.New("conductor requires the sequencer to be enabled")
+	}
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/metrics"
+)
+
+// eventsBufferSize is the number of events buffered per subscription, while a notification is being written
+// to the subscriber. The subscription is dropped by the driver when the buffer is full.
+const eventsBufferSize = 128
+
+type eventSource interface {
+	SubscribeEvents(ch chan<- eth.RollupEvent) event.Subscription
+}
+
+type eventsAPI struct {
+	src eventSource
+	log log.Logger
+	m   metrics.RPCMetricer
+}
+
+func NewEventsAPI(src eventSource, log log.Logger, m metrics.RPCMetricer) *eventsAPI {
+	return &eventsAPI{
+		src: src,
+		log: log,
+		m:   m,
+	}
+}
+
+// Events subscribes to the stream of rollup events: L2 head changes, L1 reorgs,
+// derivation pipeline resets, and sequencer starts and stops.
+// Clients subscribe with optimism_subscribe("events"), which requires a websocket connection.
+func (api *eventsAPI) Events(ctx context.Context) (*rpc.Subscription, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_subscribe_events")
+	defer recordDur()
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	events := make(chan eth.RollupEvent, eventsBufferSize)
+	sub := api.src.SubscribeEvents(events)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				if err := notifier.Notify(rpcSub.ID, ev); err != nil {
+					api.log.Warn("Failed to notify rollup event subscriber", "id", rpcSub.ID, "err", err)
+					return
+				}
+			case err := <-sub.Err():
+				// The driver dropped the subscriber, as it did not keep up with the events
+				api.log.Warn("Dropped rollup event subscriber", "id", rpcSub.ID, "err", err)
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
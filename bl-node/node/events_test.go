@@ -0,0 +1,88 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-node/metrics"
+	"github.com/BLASTchain/blast/bl-node/rollup"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/bl-service/testutils"
+)
+
+type feedEventSource struct {
+	feed event.FeedOf[eth.RollupEvent]
+}
+
+func (s *feedEventSource) SubscribeEvents(ch chan<- eth.RollupEvent) event.Subscription {
+	return s.feed.Subscribe(ch)
+}
+
+func TestEventsSubscription(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	src := &feedEventSource{}
+	server.EnableEvents(NewEventsAPI(src, log, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// subscriptions are not available over HTTP
+	httpClient, err := rpc.DialContext(ctx, "http://"+server.Addr().String())
+	require.NoError(t, err)
+	defer httpClient.Close()
+	_, err = httpClient.Subscribe(ctx, "optimism", make(chan eth.RollupEvent), "events")
+	require.ErrorIs(t, err, rpc.ErrNotificationsUnsupported)
+
+	client, err := rpc.DialContext(ctx, "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	events := make(chan eth.RollupEvent, 10)
+	sub, err := client.Subscribe(ctx, "optimism", events, "events")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// the subscription is registered with the source asynchronously
+	require.Eventually(t, func() bool {
+		return src.feed.Send(eth.RollupEvent{Kind: eth.PipelineResetEvent, Reason: "test"}) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	head := eth.L2BlockRef{Hash: [32]byte{1}, Number: 42}
+	origin := eth.L1BlockRef{Hash: [32]byte{2}, Number: 7}
+	src.feed.Send(eth.RollupEvent{Kind: eth.SafeHeadEvent, L2: &head, L1: &origin})
+
+	select {
+	case ev := <-events:
+		require.Equal(t, eth.RollupEvent{Kind: eth.PipelineResetEvent, Reason: "test"}, ev)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event")
+	}
+	select {
+	case ev := <-events:
+		require.Equal(t, eth.SafeHeadEvent, ev.Kind)
+		require.Equal(t, head, *ev.L2)
+		require.Equal(t, origin, *ev.L1)
+		require.Nil(t, ev.OldL1)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event")
+	}
+}
@@ -18,9 +18,12 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/bl-node/conductor"
 	"github.com/BLASTchain/blast/bl-node/heartbeat"
 	"github.com/BLASTchain/blast/bl-node/metrics"
+	"github.com/BLASTchain/blast/bl-node/node/safedb"
 	"github.com/BLASTchain/blast/bl-node/p2p"
+	"github.com/BLASTchain/blast/bl-node/rollup/derive"
 	"github.com/BLASTchain/blast/bl-node/rollup/driver"
 	"github.com/BLASTchain/blast/bl-node/version"
 	"github.com/BLASTchain/blast/bl-service/client"
@@ -28,6 +31,7 @@ import (
 	oppprof "github.com/BLASTchain/blast/bl-service/pprof"
 	"github.com/BLASTchain/blast/bl-service/retry"
 	"github.com/BLASTchain/blast/bl-service/sources"
+	"github.com/BLASTchain/blast/bl-service/sources/batching"
 )
 
 type OpNode struct {
@@ -39,15 +43,20 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1RPC     client.RPC              // L1 RPC, shared by the L1 client and L1 contract bindings
+	l1Source  *sources.L1Client       // L1 Client to fetch data from
+	beacon    *sources.L1BeaconClient // L1 Beacon API client to fetch blobs from, optional (may be nil)
+	l2Driver  *driver.Driver          // L2 Engine to Sync
+	l2Source  *sources.EngineClient   // L2 Execution Engine RPC bindings
+	rpcSync   *sources.SyncClient     // Alt-sync RPC client, optional (may be nil)
+	server    *rpcServer              // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P            // P2P node functionality
+	p2pSigner p2p.Signer              // p2p gogssip application messages will be signed with this signer
+	attSigner p2p.Signer              // safe head attestations will be signed with this signer, optional (may be nil)
+	tracer    Tracer                  // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig          // runtime configurables
+	conductor *conductor.Conductor    // Sequencer failover coordination, optional (may be nil)
+	safeDB    *safedb.SafeDB          // Safe head by L1 block database, optional (may be nil)
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
@@ -110,9 +119,15 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
+	if err := n.initL1BeaconAPI(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L1 beacon API: %w", err)
+	}
 	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
+	if err := n.initConductor(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init the sequencer conductor: %w", err)
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
@@ -125,6 +140,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
+	if err := n.initAttestationSigner(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init the attestation signer: %w", err)
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the RPC server: %w", err)
@@ -159,8 +177,8 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	// Set the RethDB path in the EthClientConfig, if there is one configured.
 	rpcCfg.EthClientConfig.RethDBPath = cfg.RethDBPath
 
-	n.l1Source, err = sources.NewL1Client(
-		client.NewInstrumentedRPC(l1Node, n.metrics), n.log, n.metrics.L1SourceCache, rpcCfg)
+	n.l1RPC = client.NewInstrumentedRPC(l1Node, n.metrics)
+	n.l1Source, err = sources.NewL1Client(n.l1RPC, n.log, n.metrics.L1SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
@@ -193,6 +211,59 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
+	var httpClient client.HTTP
+	var fallbackClients []client.HTTP
+	if cfg.Beacon != nil {
+		var err error
+		httpClient, fallbackClients, err = cfg.Beacon.Setup(ctx, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to setup L1 beacon client: %w", err)
+		}
+	}
+	if httpClient == nil {
+		if cfg.Rollup.EcotoneTime != nil {
+			return errors.New("the Ecotone upgrade is scheduled, but no L1 beacon endpoint is configured")
+		}
+		n.log.Info("No L1 beacon endpoint configured, blob data cannot be retrieved")
+		return nil
+	}
+	fallbacks := make([]sources.BlobSideCarsFetcher, 0, len(fallbackClients))
+	for _, fb := range fallbackClients {
+		fallbacks = append(fallbacks, sources.NewBeaconHTTPClient(fb))
+	}
+	n.beacon = sources.NewL1BeaconClient(sources.NewBeaconHTTPClient(httpClient), fallbacks...)
+
+	// Retry retrieval of the Beacon API version, to be more robust on startup against Beacon API connection issues.
+	beaconVersion, missingEndpoint := retry.Do[string](ctx, 5, retry.Exponential(), func() (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+		defer cancel()
+		return n.beacon.NodeVersion(ctx)
+	})
+	if missingEndpoint != nil {
+		return fmt.Errorf("failed to check L1 Beacon API version: %w", missingEndpoint)
+	}
+	n.log.Info("Connected to L1 Beacon API", "version", beaconVersion, "fallbacks", len(fallbacks))
+	return nil
+}
+
+func (n *OpNode) initCheckpoint(ctx context.Context, cfg *Config) (eth.L2BlockRef, error) {
+	oracle, err := newOutputOracle(cfg.Checkpoint.OutputOracle, batching.NewMultiCaller(n.l1RPC, batching.DefaultBatchSize))
+	if err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	var src checkpointSource
+	if cfg.Checkpoint.URL != "" {
+		rpcClient, err := client.NewRPC(ctx, n.log, cfg.Checkpoint.URL)
+		if err != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("failed to dial trusted checkpoint node: %w", err)
+		}
+		defer rpcClient.Close()
+		src = sources.NewRollupClient(rpcClient)
+	}
+	return verifyCheckpoint(ctx, n.log, &cfg.Checkpoint, n.l1Source, n.l2Source, oracle, src)
+}
+
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
 	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
@@ -304,11 +375,44 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	var l1Blobs derive.L1BlobsFetcher
+	if n.beacon != nil {
+		l1Blobs = n.beacon
+	}
+	if cfg.Checkpoint.Enabled() {
+		checkpoint, err := n.initCheckpoint(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to verify checkpoint: %w", err)
+		}
+		cfg.Sync.Checkpoint = &checkpoint
+	}
+
+	var safeHeadListener derive.SafeHeadListener
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
+		n.safeDB, err = safedb.NewSafeDB(n.log, cfg.SafeDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database: %w", err)
+		}
+		safeHeadListener = n.safeDB
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, l1Blobs, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, safeHeadListener, &cfg.Sync)
 
 	return nil
 }
 
+func (n *OpNode) initConductor(ctx context.Context, cfg *Config) error {
+	if !cfg.Conductor.Enabled {
+		return nil
+	}
+	cons, err := cfg.Conductor.Setup()
+	if err != nil {
+		return fmt.Errorf("failed to setup conductor consensus: %w", err)
+	}
+	n.conductor = conductor.NewConductor(n.log, cons, n.l2Driver, cfg.Conductor.Interval)
+	return nil
+}
+
 func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 	rpcSyncClient, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -330,8 +434,17 @@ func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 	if err != nil {
 		return err
 	}
+	server.EnableEvents(NewEventsAPI(n.l2Driver, n.log, n.metrics))
+	var safeDB safeDBReader = safedb.Disabled
+	if n.safeDB != nil {
+		safeDB = n.safeDB
+	}
+	server.EnableSafeDB(NewSafeDBAPI(safeDB, n.log, n.metrics))
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
+		if attestations := n.p2pNode.SafeHeadAttestations(); attestations != nil {
+			server.EnableSafeHeadAttestations(NewAttestationsAPI(attestations, n.l2Driver, n.log, n.metrics))
+		}
 	}
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
@@ -425,6 +538,20 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) error {
 	return err
 }
 
+func (n *OpNode) initAttestationSigner(ctx context.Context, cfg *Config) error {
+	// the attestation signer setup is optional
+	if cfg.AttestationSigner == nil {
+		return nil
+	}
+	if n.p2pNode == nil || n.p2pNode.SafeHeadAttestations() == nil {
+		n.log.Warn("Attestation signer is configured, but the safe head attestations topic is not enabled")
+		return nil
+	}
+	var err error
+	n.attSigner, err = cfg.AttestationSigner.SetupSigner(ctx)
+	return err
+}
+
 func (n *OpNode) Start(ctx context.Context) error {
 	n.log.Info("Starting execution engine driver")
 
@@ -443,6 +570,18 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Info("Started L2-RPC sync service")
 	}
 
+	// The conductor starts the sequencer once the node is the leader of the sequencer cluster
+	if n.conductor != nil {
+		n.conductor.Start()
+		n.log.Info("Started sequencer conductor")
+	}
+
+	// Attest to the local safe head, for other nodes to compare their safe head against
+	if n.attSigner != nil {
+		go n.attestLoop(n.resourcesCtx, n.p2pNode.SafeHeadAttestations(), n.attSigner)
+		n.log.Info("Started publishing safe head attestations")
+	}
+
 	log.Info("Rollup node started")
 	return nil
 }
@@ -488,6 +627,13 @@ func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
 func (n *OpNode) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
 	n.tracer.OnPublishL2Payload(ctx, payload)
 
+	// commit to the sequencer cluster first, so a standby sequencer never takes over from behind a published payload
+	if n.conductor != nil {
+		if err := n.conductor.CommitUnsafePayload(ctx, payload); err != nil {
+			return fmt.Errorf("%w: %s: %w", driver.ErrPayloadNotCommitted, payload.ID(), err)
+		}
+	}
+
 	// publish to p2p, if we are running p2p at all
 	if n.p2pNode != nil {
 		if n.p2pSigner == nil {
@@ -573,6 +719,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p signer: %w", err))
 		}
 	}
+	if n.attSigner != nil {
+		if err := n.attSigner.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close attestation signer: %w", err))
+		}
+	}
 
 	if n.resourcesClose != nil {
 		n.resourcesClose()
@@ -583,6 +734,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l1HeadsSub.Unsubscribe()
 	}
 
+	// stop the sequencer and give up leadership before closing the L2 driver
+	if n.conductor != nil {
+		if err := n.conductor.Stop(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close sequencer conductor: %w", err))
+		}
+	}
+
 	// close L2 driver
 	if n.l2Driver != nil {
 		if err := n.l2Driver.Close(); err != nil {
@@ -597,6 +755,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// close the safe head database after the driver stopped writing to it
+	if n.safeDB != nil {
+		if err := n.safeDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe head database: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
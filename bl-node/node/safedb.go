@@ -0,0 +1,51 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/node/safedb"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/metrics"
+)
+
+type safeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error)
+}
+
+type safeDBAPI struct {
+	db  safeDBReader
+	log log.Logger
+	m   metrics.RPCMetricer
+}
+
+func NewSafeDBAPI(db safeDBReader, log log.Logger, m metrics.RPCMetricer) *safeDBAPI {
+	return &safeDBAPI{
+		db:  db,
+		log: log,
+		m:   m,
+	}
+}
+
+// SafeHeadAtL1Block returns the safe L2 head at the given L1 block, i.e. the last safe head
+// that was derived from the L1 chain up to and including that block.
+func (api *safeDBAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+
+	l1Block, safeHead, err := api.db.SafeHeadAtL1(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, ethereum.NotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at L1 block %d: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
@@ -0,0 +1,16 @@
+package safedb
+
+import (
+	"context"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+type DisabledDB struct{}
+
+// Disabled is the safe head database to use when safe head tracking is not enabled.
+var Disabled = &DisabledDB{}
+
+func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	return eth.BlockID{}, eth.BlockID{}, ErrNotEnabled
+}
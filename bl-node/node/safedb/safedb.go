@@ -0,0 +1,163 @@
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/rollup/derive"
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrNotEnabled = errors.New("safe head database not enabled")
+)
+
+const (
+	// safeByL1BlockNumKey is the key prefix of the safe head entries, followed by the big-endian L1 block number.
+	safeByL1BlockNumKey byte = 0
+
+	// safeByL1BlockNumValueSize is the size of an entry: L1 block hash, L2 block hash, L2 block number.
+	safeByL1BlockNumValueSize = 32 + 32 + 8
+)
+
+func safeByL1BlockNum(l1BlockNum uint64) []byte {
+	var key [9]byte
+	key[0] = safeByL1BlockNumKey
+	binary.BigEndian.PutUint64(key[1:], l1BlockNum)
+	return key[:]
+}
+
+func encodeSafeByL1BlockNum(l1 common.Hash, l2 eth.BlockID) []byte {
+	val := make([]byte, safeByL1BlockNumValueSize)
+	copy(val[:32], l1[:])
+	copy(val[32:64], l2.Hash[:])
+	binary.BigEndian.PutUint64(val[64:], l2.Number)
+	return val
+}
+
+func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	if len(key) != 9 || key[0] != safeByL1BlockNumKey {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("invalid key: %x", key)
+	}
+	if len(val) != safeByL1BlockNumValueSize {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("invalid value size %d", len(val))
+	}
+	l1 = eth.BlockID{Hash: common.BytesToHash(val[:32]), Number: binary.BigEndian.Uint64(key[1:])}
+	l2 = eth.BlockID{Hash: common.BytesToHash(val[32:64]), Number: binary.BigEndian.Uint64(val[64:])}
+	return l1, l2, nil
+}
+
+// SafeDB persists the safe L2 head at each L1 block that the safe head changed at,
+// so the safe head at any processed L1 block can be looked up.
+type SafeDB struct {
+	// m ensures reads and writes of multiple keys are consistent
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+}
+
+var _ derive.SafeHeadListener = (*SafeDB)(nil)
+
+// NewSafeDB opens, or creates, the safe head database at the given path.
+func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head db at %q: %w", path, err)
+	}
+	return &SafeDB{
+		log: logger,
+		db:  db,
+	}, nil
+}
+
+// SafeHeadUpdated records the new safe head, as derived from the L1 chain up to and including the given L1 block.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Block eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Block)
+	if err := d.db.Set(safeByL1BlockNum(l1Block.Number), encodeSafeByL1BlockNum(l1Block.Hash, safeHead.ID()), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
+	return nil
+}
+
+// SafeHeadReset removes all entries from the first L1 block that recorded a safe head at or after resetSafeHead.
+// The L1 blocks that these were derived from may have been reorged out, and are recorded again as the derivation continues.
+func (d *SafeDB) SafeHeadReset(resetSafeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	iter, err := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNum(resetSafeHead.L1Origin.Number),
+		UpperBound: safeByL1BlockNum(math.MaxUint64),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for iter.First(); iter.Valid(); iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read safe head entry: %w", err)
+		}
+		_, l2, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		if l2.Number >= resetSafeHead.Number {
+			from := slices.Clone(iter.Key())
+			d.log.Debug("Truncating safe head entries", "from", binary.BigEndian.Uint64(from[1:]), "reset", resetSafeHead.ID())
+			if err := d.db.DeleteRange(from, safeByL1BlockNum(math.MaxUint64), pebble.Sync); err != nil {
+				return fmt.Errorf("failed to truncate safe head entries: %w", err)
+			}
+			return nil
+		}
+	}
+	return iter.Error()
+}
+
+// SafeHeadAtL1 returns the safe L2 head at the given L1 block number,
+// along with the L1 block at or before it at which that safe head was recorded.
+// ErrNotFound is returned if no safe head was recorded at or before the L1 block.
+func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, &pebble.IterOptions{
+		LowerBound: safeByL1BlockNum(0),
+		UpperBound: safeByL1BlockNum(math.MaxUint64),
+	})
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	if l1BlockNum == math.MaxUint64 {
+		iter.Last()
+	} else {
+		iter.SeekLT(safeByL1BlockNum(l1BlockNum + 1))
+	}
+	if !iter.Valid() {
+		if err := iter.Error(); err != nil {
+			return eth.BlockID{}, eth.BlockID{}, err
+		}
+		return eth.BlockID{}, eth.BlockID{}, ErrNotFound
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return eth.BlockID{}, eth.BlockID{}, fmt.Errorf("failed to read safe head entry: %w", err)
+	}
+	return decodeSafeByL1BlockNum(iter.Key(), val)
+}
+
+func (d *SafeDB) Close() error {
+	return d.db.Close()
+}
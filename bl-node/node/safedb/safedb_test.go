@@ -0,0 +1,96 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+func l1Block(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0x01, byte(num)}, Number: num}
+}
+
+func l2Block(num uint64, l1Origin uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: common.Hash{0x02, byte(num)}, Number: num, L1Origin: l1Block(l1Origin)}
+}
+
+func requireSafeHead(t *testing.T, db *SafeDB, l1Num uint64, expectedL1 eth.BlockID, expectedL2 eth.BlockID) {
+	l1, l2, err := db.SafeHeadAtL1(context.Background(), l1Num)
+	require.NoError(t, err)
+	require.Equal(t, expectedL1, l1)
+	require.Equal(t, expectedL2, l2)
+}
+
+func TestSafeHeadAtL1(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SafeHeadUpdated(l2Block(10, 95), l1Block(100)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(20, 100), l1Block(105)))
+	// the last update at the same L1 block wins
+	require.NoError(t, db.SafeHeadUpdated(l2Block(22, 101), l1Block(105)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(30, 106), l1Block(110)))
+
+	_, _, err = db.SafeHeadAtL1(context.Background(), 99)
+	require.ErrorIs(t, err, ErrNotFound)
+	requireSafeHead(t, db, 100, l1Block(100), l2Block(10, 95).ID())
+	requireSafeHead(t, db, 104, l1Block(100), l2Block(10, 95).ID())
+	requireSafeHead(t, db, 105, l1Block(105), l2Block(22, 101).ID())
+	requireSafeHead(t, db, 110, l1Block(110), l2Block(30, 106).ID())
+	requireSafeHead(t, db, math.MaxUint64, l1Block(110), l2Block(30, 106).ID())
+
+	// entries persist across restarts
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	requireSafeHead(t, db, 107, l1Block(105), l2Block(22, 101).ID())
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.SafeHeadUpdated(l2Block(10, 95), l1Block(100)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(20, 100), l1Block(105)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(30, 106), l1Block(110)))
+	require.NoError(t, db.SafeHeadUpdated(l2Block(40, 111), l1Block(115)))
+
+	// reset to a safe head that is between recorded safe heads
+	require.NoError(t, db.SafeHeadReset(l2Block(25, 103)))
+	requireSafeHead(t, db, 104, l1Block(100), l2Block(10, 95).ID())
+	requireSafeHead(t, db, 105, l1Block(105), l2Block(20, 100).ID())
+	requireSafeHead(t, db, 200, l1Block(105), l2Block(20, 100).ID())
+
+	// reset to a recorded safe head removes the entry of that safe head too
+	require.NoError(t, db.SafeHeadReset(l2Block(20, 100)))
+	requireSafeHead(t, db, 200, l1Block(100), l2Block(10, 95).ID())
+
+	// the derivation continues recording after a reset
+	require.NoError(t, db.SafeHeadUpdated(l2Block(21, 101), l1Block(104)))
+	requireSafeHead(t, db, 200, l1Block(104), l2Block(21, 101).ID())
+
+	// reset before all entries
+	require.NoError(t, db.SafeHeadReset(l2Block(5, 90)))
+	_, _, err = db.SafeHeadAtL1(context.Background(), 200)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDisabled(t *testing.T) {
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 100)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
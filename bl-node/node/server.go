@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/BLASTchain/blast/bl-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
@@ -29,7 +30,7 @@ type rpcServer struct {
 
 func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
 	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
-	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
+	// TODO: extend RPC config with options for IPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
 	r := &rpcServer{
 		endpoint: endpoint,
@@ -62,6 +63,33 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	})
 }
 
+func (s *rpcServer) EnableSafeHeadAttestations(api *attestationsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableEvents(api *eventsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableSafeDB(api *safeDBAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) Start() error {
 	srv := rpc.NewServer()
 	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
@@ -73,9 +101,11 @@ func (s *rpcServer) Start() error {
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
 	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	// Websocket connections are served on the same endpoint, for subscriptions.
+	wsHandler := node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), nil)
 
 	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
+	mux.Handle("/", httpOrWSHandler(nodeHandler, wsHandler))
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
 
 	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
@@ -94,6 +124,18 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.httpServer.Addr()
 }
 
+// httpOrWSHandler routes websocket upgrade requests to the websocket handler, and other requests to the HTTP handler.
+func httpOrWSHandler(httpHandler, wsHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+			strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+}
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
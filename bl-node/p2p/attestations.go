@@ -0,0 +1,245 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/rollup"
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+// attestationSize is the size of an encoded safe head attestation:
+// the L1 block, safe L2 block and finalized L2 block each as hash and uint64 number, followed by the uint64 timestamp.
+const attestationSize = 3*(32+8) + 8
+
+func safeHeadsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/safe_heads", cfg.L2ChainID.String())
+}
+
+// AttestationsConfig configures the optional gossip topic for safe head attestations.
+type AttestationsConfig struct {
+	// Enabled joins the safe head attestations topic.
+	Enabled bool
+	// Trusted are the addresses of the attesters to follow. Attestations of other signers are ignored.
+	Trusted []common.Address
+}
+
+// AttestationSigningHash computes the hash that is signed by the attester.
+func AttestationSigningHash(cfg *rollup.Config, attestationBytes []byte) (common.Hash, error) {
+	return SigningHash(SigningDomainSafeHeadsV1, cfg.L2ChainID, attestationBytes)
+}
+
+func encodeBlockID(out []byte, id eth.BlockID) {
+	copy(out[:32], id.Hash[:])
+	binary.BigEndian.PutUint64(out[32:40], id.Number)
+}
+
+func decodeBlockID(data []byte) eth.BlockID {
+	return eth.BlockID{Hash: common.BytesToHash(data[:32]), Number: binary.BigEndian.Uint64(data[32:40])}
+}
+
+// EncodeAttestation encodes the safe head attestation into a fixed-size byte representation.
+func EncodeAttestation(att *eth.SafeHeadAttestation) []byte {
+	out := make([]byte, attestationSize)
+	encodeBlockID(out[0:40], att.L1)
+	encodeBlockID(out[40:80], att.SafeL2)
+	encodeBlockID(out[80:120], att.FinalizedL2)
+	binary.BigEndian.PutUint64(out[120:128], att.Timestamp)
+	return out
+}
+
+// DecodeAttestation decodes a safe head attestation, as encoded by EncodeAttestation.
+func DecodeAttestation(data []byte) (*eth.SafeHeadAttestation, error) {
+	if len(data) != attestationSize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", attestationSize, len(data))
+	}
+	return &eth.SafeHeadAttestation{
+		L1:          decodeBlockID(data[0:40]),
+		SafeL2:      decodeBlockID(data[40:80]),
+		FinalizedL2: decodeBlockID(data[80:120]),
+		Timestamp:   binary.BigEndian.Uint64(data[120:128]),
+	}, nil
+}
+
+// SignedAttestation is a safe head attestation with the recovered address of the attester.
+type SignedAttestation struct {
+	Attester    common.Address
+	Attestation eth.SafeHeadAttestation
+}
+
+// SafeHeadAttestations publishes safe head attestations, and keeps track of the latest attestation of each trusted attester.
+type SafeHeadAttestations struct {
+	log  log.Logger
+	cfg  *rollup.Config
+	self peer.ID
+
+	trusted map[common.Address]struct{}
+
+	// p2pCancel cancels the gossip event-handling of the topic
+	p2pCancel context.CancelFunc
+	topic     *blockTopic
+
+	mu     sync.Mutex
+	latest map[common.Address]eth.SafeHeadAttestation
+}
+
+func JoinSafeHeadAttestations(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, conf AttestationsConfig) (*SafeHeadAttestations, error) {
+	p2pCtx, p2pCancel := context.WithCancel(context.Background())
+	a := &SafeHeadAttestations{
+		log:       log.New("topic", "safeHeads"),
+		cfg:       cfg,
+		self:      self,
+		trusted:   make(map[common.Address]struct{}),
+		p2pCancel: p2pCancel,
+		latest:    make(map[common.Address]eth.SafeHeadAttestation),
+	}
+	for _, addr := range conf.Trusted {
+		a.trusted[addr] = struct{}{}
+	}
+	validator := guardGossipValidator(log, logValidationResult(self, "validated safe head attestation", a.log, a.validate))
+	topic, err := newBlockTopic(p2pCtx, safeHeadsTopicV1(cfg), ps, a.log, a.onAttestation, validator)
+	if err != nil {
+		p2pCancel()
+		return nil, fmt.Errorf("failed to setup safe heads p2p: %w", err)
+	}
+	a.topic = topic
+	return a, nil
+}
+
+func (a *SafeHeadAttestations) validate(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+	// [REJECT] if the compression is not valid, or the message does not have the expected size
+	outLen, err := snappy.DecodedLen(message.Data)
+	if err != nil {
+		a.log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	if outLen != 65+attestationSize {
+		a.log.Warn("invalid attestation size", "size", outLen, "peer", id)
+		return pubsub.ValidationReject
+	}
+	data, err := snappy.Decode(nil, message.Data)
+	if err != nil {
+		a.log.Warn("invalid snappy compression", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	// message starts with compact-encoding secp256k1 encoded signature
+	signatureBytes, attestationBytes := data[:65], data[65:]
+
+	// [REJECT] if the signature is not valid
+	signingHash, err := AttestationSigningHash(a.cfg, attestationBytes)
+	if err != nil {
+		a.log.Warn("failed to compute attestation signing hash", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
+	if err != nil {
+		a.log.Warn("invalid attestation signature", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+	attester := crypto.PubkeyToAddress(*pub)
+
+	// [IGNORE] if the attester is not trusted, unless we are publishing it ourselves
+	if _, ok := a.trusted[attester]; !ok && id != a.self {
+		a.log.Debug("ignoring attestation of untrusted attester", "peer", id, "attester", attester)
+		return pubsub.ValidationIgnore
+	}
+
+	att, err := DecodeAttestation(attestationBytes)
+	if err != nil {
+		a.log.Warn("invalid attestation", "err", err, "peer", id)
+		return pubsub.ValidationReject
+	}
+
+	// [REJECT] if the finalized L2 head is ahead of the safe L2 head
+	if att.FinalizedL2.Number > att.SafeL2.Number {
+		a.log.Warn("attested finalized head is ahead of safe head", "peer", id, "safe", att.SafeL2, "finalized", att.FinalizedL2)
+		return pubsub.ValidationReject
+	}
+
+	now := uint64(time.Now().Unix())
+
+	// [REJECT] if the attestation is more than 5 seconds into the future
+	if att.Timestamp > now+5 {
+		a.log.Warn("attestation is too new", "timestamp", att.Timestamp, "peer", id)
+		return pubsub.ValidationReject
+	}
+	// [IGNORE] if the attestation is older than 60 seconds in the past
+	if att.Timestamp < now-60 {
+		a.log.Debug("attestation is too old", "timestamp", att.Timestamp, "peer", id)
+		return pubsub.ValidationIgnore
+	}
+
+	// [IGNORE] if the attestation is not newer than the latest attestation of the attester
+	a.mu.Lock()
+	prev, ok := a.latest[attester]
+	a.mu.Unlock()
+	if ok && att.Timestamp <= prev.Timestamp {
+		return pubsub.ValidationIgnore
+	}
+
+	message.ValidatorData = &SignedAttestation{Attester: attester, Attestation: *att}
+	return pubsub.ValidationAccept
+}
+
+func (a *SafeHeadAttestations) onAttestation(ctx context.Context, from peer.ID, msg any) error {
+	signed, ok := msg.(*SignedAttestation)
+	if !ok {
+		return fmt.Errorf("expected topic validator to parse and validate data into attestation, but got %T", msg)
+	}
+	if _, ok := a.trusted[signed.Attester]; !ok {
+		return nil // our own attestation, if we do not follow it
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if prev, ok := a.latest[signed.Attester]; ok && signed.Attestation.Timestamp <= prev.Timestamp {
+		return nil
+	}
+	a.latest[signed.Attester] = signed.Attestation
+	a.log.Debug("received safe head attestation", "attester", signed.Attester, "l1", signed.Attestation.L1, "safe", signed.Attestation.SafeL2, "peer", from)
+	return nil
+}
+
+// Publish signs the attestation with the signer, and publishes it on the safe heads topic.
+func (a *SafeHeadAttestations) Publish(ctx context.Context, att *eth.SafeHeadAttestation, signer Signer) error {
+	attestationBytes := EncodeAttestation(att)
+	sig, err := signer.Sign(ctx, SigningDomainSafeHeadsV1, a.cfg.L2ChainID, attestationBytes)
+	if err != nil {
+		return fmt.Errorf("failed to sign attestation with signer: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.Write(sig[:])
+	buf.Write(attestationBytes)
+	return a.topic.topic.Publish(ctx, snappy.Encode(nil, buf.Bytes()))
+}
+
+// Latest returns the latest attestation of each trusted attester, ordered by attester address.
+func (a *SafeHeadAttestations) Latest() []SignedAttestation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]SignedAttestation, 0, len(a.latest))
+	for attester, att := range a.latest {
+		out = append(out, SignedAttestation{Attester: attester, Attestation: att})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Attester[:], out[j].Attester[:]) < 0
+	})
+	return out
+}
+
+func (a *SafeHeadAttestations) Close() error {
+	a.p2pCancel()
+	return a.topic.Close()
+}
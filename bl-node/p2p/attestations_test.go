@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/rollup"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+func TestAttestationEncoding(t *testing.T) {
+	att := &eth.SafeHeadAttestation{
+		L1:          eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+		SafeL2:      eth.BlockID{Hash: common.Hash{0x02}, Number: 2000},
+		FinalizedL2: eth.BlockID{Hash: common.Hash{0x03}, Number: 1500},
+		Timestamp:   1234,
+	}
+	data := EncodeAttestation(att)
+	require.Len(t, data, attestationSize)
+	out, err := DecodeAttestation(data)
+	require.NoError(t, err)
+	require.Equal(t, att, out)
+
+	_, err = DecodeAttestation(data[1:])
+	require.Error(t, err)
+}
+
+func signAttestation(t *testing.T, cfg *rollup.Config, signer Signer, att *eth.SafeHeadAttestation) *pubsub.Message {
+	attestationBytes := EncodeAttestation(att)
+	sig, err := signer.Sign(context.Background(), SigningDomainSafeHeadsV1, cfg.L2ChainID, attestationBytes)
+	require.NoError(t, err)
+	data := snappy.Encode(nil, append(sig[:], attestationBytes...))
+	return &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+}
+
+func TestAttestationValidator(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+	trustedKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	trusted := crypto.PubkeyToAddress(trustedKey.PublicKey)
+
+	a := &SafeHeadAttestations{
+		log:     testlog.Logger(t, log.LvlCrit),
+		cfg:     cfg,
+		self:    peer.ID("self"),
+		trusted: map[common.Address]struct{}{trusted: {}},
+		latest:  make(map[common.Address]eth.SafeHeadAttestation),
+	}
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+	att := &eth.SafeHeadAttestation{
+		L1:          eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+		SafeL2:      eth.BlockID{Hash: common.Hash{0x02}, Number: 2000},
+		FinalizedL2: eth.BlockID{Hash: common.Hash{0x03}, Number: 1500},
+		Timestamp:   now,
+	}
+
+	// Valid attestation of a trusted attester
+	msg := signAttestation(t, cfg, NewLocalSigner(trustedKey), att)
+	require.Equal(t, pubsub.ValidationAccept, a.validate(ctx, "bob", msg))
+	require.Equal(t, &SignedAttestation{Attester: trusted, Attestation: *att}, msg.ValidatorData)
+	require.NoError(t, a.onAttestation(ctx, "bob", msg.ValidatorData))
+	require.Equal(t, []SignedAttestation{{Attester: trusted, Attestation: *att}}, a.Latest())
+
+	// The same attestation again is ignored
+	msg = signAttestation(t, cfg, NewLocalSigner(trustedKey), att)
+	require.Equal(t, pubsub.ValidationIgnore, a.validate(ctx, "bob", msg))
+
+	// Untrusted attesters are ignored, unless published by ourselves
+	msg = signAttestation(t, cfg, NewLocalSigner(otherKey), att)
+	require.Equal(t, pubsub.ValidationIgnore, a.validate(ctx, "bob", msg))
+	require.Equal(t, pubsub.ValidationAccept, a.validate(ctx, "self", msg))
+	require.NoError(t, a.onAttestation(ctx, "self", msg.ValidatorData))
+	require.Len(t, a.Latest(), 1, "untrusted attestations are not tracked")
+
+	// Stale attestations are ignored
+	stale := *att
+	stale.Timestamp = now - 120
+	require.Equal(t, pubsub.ValidationIgnore, a.validate(ctx, "bob", signAttestation(t, cfg, NewLocalSigner(trustedKey), &stale)))
+
+	// Attestations from the future are rejected
+	future := *att
+	future.Timestamp = now + 60
+	require.Equal(t, pubsub.ValidationReject, a.validate(ctx, "bob", signAttestation(t, cfg, NewLocalSigner(trustedKey), &future)))
+
+	// Inconsistent attestations are rejected
+	inconsistent := *att
+	inconsistent.Timestamp = now + 1
+	inconsistent.FinalizedL2.Number = 3000
+	require.Equal(t, pubsub.ValidationReject, a.validate(ctx, "bob", signAttestation(t, cfg, NewLocalSigner(trustedKey), &inconsistent)))
+
+	// Attestations signed for another chain have a different signer, and are thus ignored
+	msg = signAttestation(t, &rollup.Config{L2ChainID: big.NewInt(101)}, NewLocalSigner(trustedKey), att)
+	require.Equal(t, pubsub.ValidationIgnore, a.validate(ctx, "bob", msg))
+
+	// Invalid data is rejected
+	msg = &pubsub.Message{Message: &pubsub_pb.Message{Data: snappy.Encode(nil, make([]byte, 65+attestationSize-1))}}
+	require.Equal(t, pubsub.ValidationReject, a.validate(ctx, "bob", msg))
+}
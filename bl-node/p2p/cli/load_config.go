@@ -22,6 +22,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
 )
@@ -65,6 +66,11 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	}
 
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
+	conf.SyncCacheDepth = ctx.Uint64(flags.SyncCacheDepthName)
+
+	if err := loadAttestationsOptions(conf, ctx); err != nil {
+		return nil, fmt.Errorf("failed to load safe head attestations options: %w", err)
+	}
 
 	return conf, nil
 }
@@ -332,6 +338,22 @@ func parsePriv(data string) (*crypto.Secp256k1PrivateKey, error) {
 	return (p).(*crypto.Secp256k1PrivateKey), nil
 }
 
+func loadAttestationsOptions(conf *p2p.Config, ctx *cli.Context) error {
+	conf.Attestations.Enabled = ctx.Bool(flags.AttestationsName)
+	addrs := strings.Split(ctx.String(flags.AttestationsTrustedName), ",")
+	for i, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("failed to parse address of trusted attester %d (out of %d): %q", i, len(addrs), addr)
+		}
+		conf.Attestations.Trusted = append(conf.Attestations.Trusted, common.HexToAddress(addr))
+	}
+	return nil
+}
+
 func loadGossipOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.MeshD = ctx.Int(flags.GossipMeshDName)
 	conf.MeshDLo = ctx.Int(flags.GossipMeshDloName)
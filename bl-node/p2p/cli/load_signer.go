@@ -32,3 +32,16 @@ func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
 
 	return nil, nil
 }
+
+// LoadAttestationSignerSetup loads a configuration for the Signer of safe head attestations to be set up later
+func LoadAttestationSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+	key := ctx.String(flags.AttestationsKeyName)
+	if key == "" {
+		return nil, nil
+	}
+	priv, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attestations key: %w", err)
+	}
+	return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
+}
@@ -50,6 +50,11 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// ReqRespSyncCache returns the cache of recent unsafe payloads to serve req-resp sync requests from.
+	// Returns nil if the cache is disabled.
+	ReqRespSyncCache() *PayloadCache
+	// SafeHeadAttestations returns the configuration of the safe head attestations gossip topic.
+	SafeHeadAttestations() AttestationsConfig
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -118,6 +123,11 @@ type Config struct {
 	Store ds.Batching
 
 	EnableReqRespSync bool
+	// SyncCacheDepth is the number of recent unsafe payloads to persist in the Store,
+	// to serve req-resp sync requests from. The cache is disabled if 0.
+	SyncCacheDepth uint64
+
+	Attestations AttestationsConfig
 }
 
 func DefaultConnManager(conf *Config) (connmgr.ConnManager, error) {
@@ -160,6 +170,17 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) SafeHeadAttestations() AttestationsConfig {
+	return conf.Attestations
+}
+
+func (conf *Config) ReqRespSyncCache() *PayloadCache {
+	if conf.SyncCacheDepth == 0 || conf.Store == nil {
+		return nil
+	}
+	return NewPayloadCache(conf.Store, conf.SyncCacheDepth)
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
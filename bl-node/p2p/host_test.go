@@ -206,6 +206,10 @@ func TestP2PFull(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, uint(1), stats.Connected)
 
+	history, err := p2pClientA.PeerScoreHistory(ctx, hostB.ID())
+	require.NoError(t, err)
+	require.NotNil(t, history)
+
 	// disconnect
 	require.NoError(t, p2pClientA.DisconnectPeer(ctx, hostB.ID()))
 	peerDump, err = p2pClientA.Peers(ctx, false)
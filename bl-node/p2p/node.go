@@ -46,6 +46,7 @@ type NodeP2P struct {
 	dv5Udp   *discover.UDPv5  // p2p discovery service
 	gs       *pubsub.PubSub   // p2p gossip router
 	gsOut    GossipOut        // p2p gossip application interface for publishing
+	attest   *SafeHeadAttestations
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
 }
@@ -106,6 +107,12 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		}
 		// Activate the P2P req-resp sync if enabled by feature-flag.
 		if setup.ReqRespSyncEnabled() {
+			// Keep recent unsafe payloads around, to serve sync requests without loading each payload from the engine.
+			if cache := setup.ReqRespSyncCache(); cache != nil {
+				if canonical, ok := l2Chain.(CanonicalL2Chain); ok {
+					l2Chain = NewCachedL2Chain(log, canonical, cache)
+				}
+			}
 			n.syncCl = NewSyncClient(log, rollupCfg, n.host.NewStream, gossipIn.OnUnsafeL2Payload, metrics, n.appScorer)
 			n.host.Network().Notify(&network.NotifyBundle{
 				ConnectedF: func(nw network.Network, conn network.Conn) {
@@ -128,6 +135,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleSyncRangeRequest)
+				n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -142,6 +151,12 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
+		if attestCfg := setup.SafeHeadAttestations(); attestCfg.Enabled {
+			n.attest, err = JoinSafeHeadAttestations(n.host.ID(), n.gs, log, rollupCfg, attestCfg)
+			if err != nil {
+				return fmt.Errorf("failed to join safe heads gossip topic: %w", err)
+			}
+		}
 		log.Info("started p2p host", "addrs", n.host.Addrs(), "peerID", n.host.ID().String())
 
 		tcpPort, err := FindActiveTCPPort(n.host)
@@ -199,6 +214,11 @@ func (n *NodeP2P) GossipOut() GossipOut {
 	return n.gsOut
 }
 
+// SafeHeadAttestations returns the safe head attestations gossip topic, or nil if it is not enabled.
+func (n *NodeP2P) SafeHeadAttestations() *SafeHeadAttestations {
+	return n.attest
+}
+
 func (n *NodeP2P) ConnectionGater() gating.BlockingConnectionGater {
 	return n.gater
 }
@@ -207,6 +227,14 @@ func (n *NodeP2P) ConnectionManager() connmgr.ConnManager {
 	return n.connMgr
 }
 
+func (n *NodeP2P) SyncClient() *SyncClient {
+	return n.syncCl
+}
+
+func (n *NodeP2P) AppScorer() ApplicationScorer {
+	return n.appScorer
+}
+
 func (n *NodeP2P) Peers() []peer.ID {
 	return n.host.Network().Peers()
 }
@@ -262,6 +290,11 @@ func (n *NodeP2P) Close() error {
 			result = multierror.Append(result, fmt.Errorf("failed to close gossip cleanly: %w", err))
 		}
 	}
+	if n.attest != nil {
+		if err := n.attest.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close safe heads gossip cleanly: %w", err))
+		}
+	}
 	if n.host != nil {
 		if err := n.host.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p host cleanly: %w", err))
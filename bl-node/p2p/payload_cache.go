@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+var payloadsBase = ds.NewKey("/payloads/unsafe")
+
+// PayloadCache is an on-disk ring buffer of recent unsafe payloads, to serve req-resp sync requests from.
+// The cache does not track reorgs: readers must check that a cached payload is still canonical.
+// Payloads are stored in a slot by block number modulo the depth of the cache,
+// replacing the payload previously stored in that slot.
+type PayloadCache struct {
+	store ds.Batching
+	depth uint64
+}
+
+func NewPayloadCache(store ds.Batching, depth uint64) *PayloadCache {
+	return &PayloadCache{store: store, depth: depth}
+}
+
+func (c *PayloadCache) key(num uint64) ds.Key {
+	return payloadsBase.ChildString(fmt.Sprintf("%d", num%c.depth))
+}
+
+// Add stores the payload, replacing any payload in the same slot.
+// The entry is encoded as the block number, the block version, and the snappy compressed SSZ payload.
+func (c *PayloadCache) Add(payload *eth.ExecutionPayload) error {
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return fmt.Errorf("failed to encode payload %s: %w", payload.ID(), err)
+	}
+	version := eth.BlockV1
+	if payload.CanyonBlock() {
+		version = eth.BlockV2
+	}
+	data := make([]byte, 9, 9+snappy.MaxEncodedLen(buf.Len()))
+	binary.LittleEndian.PutUint64(data[:8], uint64(payload.BlockNumber))
+	data[8] = byte(version)
+	data = append(data, snappy.Encode(nil, buf.Bytes())...)
+	if err := c.store.Put(context.Background(), c.key(uint64(payload.BlockNumber)), data); err != nil {
+		return fmt.Errorf("failed to store payload %s: %w", payload.ID(), err)
+	}
+	return nil
+}
+
+// PayloadByNumber returns the cached payload, or ethereum.NotFound if it is not in the cache.
+func (c *PayloadCache) PayloadByNumber(ctx context.Context, num uint64) (*eth.ExecutionPayload, error) {
+	data, err := c.store.Get(ctx, c.key(num))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ethereum.NotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load cached payload %d: %w", num, err)
+	}
+	if len(data) < 9 {
+		return nil, fmt.Errorf("invalid cached payload %d: entry too short", num)
+	}
+	if binary.LittleEndian.Uint64(data[:8]) != num { // slot was taken by another block
+		return nil, ethereum.NotFound
+	}
+	version := eth.BlockVersion(data[8])
+	ssz, err := snappy.Decode(nil, data[9:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress cached payload %d: %w", num, err)
+	}
+	var payload eth.ExecutionPayload
+	if err := payload.UnmarshalSSZ(version, uint32(len(ssz)), bytes.NewReader(ssz)); err != nil {
+		return nil, fmt.Errorf("failed to decode cached payload %d: %w", num, err)
+	}
+	return &payload, nil
+}
+
+// CanonicalL2Chain is an L2Chain that can look up the canonical block at a given number.
+type CanonicalL2Chain interface {
+	L2Chain
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// cachedL2Chain serves payloads from the cache, and falls back to the engine, caching the result.
+// Only payloads loaded from the engine are cached, and cached payloads are only served
+// if they are still canonical, so payloads that were reorged out are replaced on the next request.
+type cachedL2Chain struct {
+	log   log.Logger
+	l2    CanonicalL2Chain
+	cache *PayloadCache
+}
+
+var _ L2Chain = (*cachedL2Chain)(nil)
+
+func NewCachedL2Chain(log log.Logger, l2 CanonicalL2Chain, cache *PayloadCache) L2Chain {
+	return &cachedL2Chain{log: log, l2: l2, cache: cache}
+}
+
+func (c *cachedL2Chain) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	payload, err := c.cache.PayloadByNumber(ctx, number)
+	if err == nil {
+		ref, err := c.l2.L2BlockRefByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Hash == payload.BlockHash {
+			return payload, nil
+		}
+		c.log.Debug("Cached payload is not canonical", "cached", payload.ID(), "canonical", ref.ID())
+	} else if !errors.Is(err, ethereum.NotFound) {
+		c.log.Warn("Failed to load payload from cache", "num", number, "err", err)
+	}
+	payload, err = c.l2.PayloadByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.Add(payload); err != nil {
+		c.log.Warn("Failed to cache payload", "id", payload.ID(), "err", err)
+	}
+	return payload, nil
+}
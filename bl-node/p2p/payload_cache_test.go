@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+func TestPayloadCache(t *testing.T) {
+	ctx := context.Background()
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	cache := NewPayloadCache(store, 4)
+
+	_, err := cache.PayloadByNumber(ctx, 1)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	a := &eth.ExecutionPayload{BlockNumber: 1, BlockHash: common.Hash{0x01}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{{0x01}}}
+	require.NoError(t, cache.Add(a))
+	got, err := cache.PayloadByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, a, got)
+
+	withdrawals := types.Withdrawals{}
+	b := &eth.ExecutionPayload{BlockNumber: 2, BlockHash: common.Hash{0x02}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{}, Withdrawals: &withdrawals}
+	require.NoError(t, cache.Add(b))
+	got, err = cache.PayloadByNumber(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, b, got, "block version is preserved")
+
+	// Block 5 takes the slot of block 1
+	c := &eth.ExecutionPayload{BlockNumber: 5, BlockHash: common.Hash{0x05}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{}}
+	require.NoError(t, cache.Add(c))
+	_, err = cache.PayloadByNumber(ctx, 1)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	got, err = cache.PayloadByNumber(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, c, got)
+
+	// The cache persists in the store
+	got, err = NewPayloadCache(store, 4).PayloadByNumber(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, b, got)
+}
+
+func TestCachedL2ChainReorg(t *testing.T) {
+	ctx := context.Background()
+	a := &eth.ExecutionPayload{BlockNumber: 1, BlockHash: common.Hash{0x0a}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{}}
+	b := &eth.ExecutionPayload{BlockNumber: 1, BlockHash: common.Hash{0x0b}, ExtraData: eth.BytesMax32{}, Transactions: []eth.Data{}}
+	data := &syncTestData{payloads: map[uint64]*eth.ExecutionPayload{1: a}}
+	var engineRequests int
+	engine := mockCanonicalChain{
+		mockPayloadFn: func(n uint64) (*eth.ExecutionPayload, error) {
+			engineRequests++
+			p, ok := data.getPayload(n)
+			if !ok {
+				return nil, ethereum.NotFound
+			}
+			return p, nil
+		},
+		data: data,
+	}
+	cache := NewPayloadCache(sync.MutexWrap(ds.NewMapDatastore()), 4)
+	chain := NewCachedL2Chain(testlog.Logger(t, log.LvlInfo), engine, cache)
+
+	got, err := chain.PayloadByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, a, got)
+	got, err = chain.PayloadByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, a, got)
+	require.Equal(t, 1, engineRequests, "canonical payload is served from the cache")
+
+	// After a reorg the cached payload is replaced by the canonical payload of the engine
+	data.addPayload(b)
+	got, err = chain.PayloadByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, b, got)
+	require.Equal(t, 2, engineRequests)
+	got, err = cache.PayloadByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, b, got)
+}
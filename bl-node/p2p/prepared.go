@@ -25,6 +25,8 @@ type Prepared struct {
 	UDPv5     *discover.UDPv5
 
 	EnableReqRespSync bool
+	SyncCache         *PayloadCache
+	Attestations      AttestationsConfig
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -92,3 +94,11 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) ReqRespSyncCache() *PayloadCache {
+	return p.SyncCache
+}
+
+func (p *Prepared) SafeHeadAttestations() AttestationsConfig {
+	return p.Attestations
+}
@@ -20,8 +20,7 @@ type PeerInfo struct {
 	ENR             string   `json:"ENR"`       // might not always be known, e.g. if the peer connected us instead of us discovering them
 	Addresses       []string `json:"addresses"` // multi-addresses. may be mix of LAN / docker / external IPs. All of them are communicated.
 	Protocols       []string `json:"protocols"` // negotiated protocols list
-	//GossipScore float64
-	//PeerScore float64
+
 	Connectedness network.Connectedness `json:"connectedness"` // "NotConnected", "Connected", "CanConnect" (gracefully disconnected), or "CannotConnect" (tried but failed)
 	Direction     network.Direction     `json:"direction"`     // "Unknown", "Inbound" (if the peer contacted us), "Outbound" (if we connected to them)
 	Protected     bool                  `json:"protected"`     // Protected peers do not get
@@ -30,7 +29,11 @@ type PeerInfo struct {
 
 	GossipBlocks bool `json:"gossipBlocks"` // if the peer is in our gossip topic
 
-	PeerScores store.PeerScores `json:"scores"`
+	GossipScore float64          `json:"gossipScore"`      // total gossipsub score, including the weighted application score
+	AppScore    float64          `json:"applicationScore"` // application score from the req-resp sync behavior of the peer
+	PeerScores  store.PeerScores `json:"scores"`
+
+	SyncStats *PeerSyncStats `json:"syncStats,omitempty"` // nil if the peer is not registered for req-resp sync
 }
 
 type PeerDump struct {
@@ -45,6 +48,7 @@ type API interface {
 	Self(ctx context.Context) (*PeerInfo, error)
 	Peers(ctx context.Context, connected bool) (*PeerDump, error)
 	PeerStats(ctx context.Context) (*PeerStats, error)
+	PeerScoreHistory(ctx context.Context, id peer.ID) ([]store.ScoreSnapshot, error)
 	DiscoveryTable(ctx context.Context) ([]*enode.Node, error)
 	BlockPeer(ctx context.Context, p peer.ID) error
 	UnblockPeer(ctx context.Context, p peer.ID) error
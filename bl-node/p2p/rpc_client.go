@@ -6,6 +6,8 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/BLASTchain/blast/bl-node/p2p/store"
+
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -44,6 +46,12 @@ func (c *Client) PeerStats(ctx context.Context) (*PeerStats, error) {
 	return out, err
 }
 
+func (c *Client) PeerScoreHistory(ctx context.Context, id peer.ID) ([]store.ScoreSnapshot, error) {
+	var out []store.ScoreSnapshot
+	err := c.c.CallContext(ctx, &out, prefixRPC("peerScoreHistory"), id)
+	return out, err
+}
+
 func (c *Client) DiscoveryTable(ctx context.Context) ([]*enode.Node, error) {
 	var out []*enode.Node
 	err := c.c.CallContext(ctx, &out, prefixRPC("discoveryTable"))
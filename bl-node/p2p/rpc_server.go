@@ -36,6 +36,7 @@ var (
 	ErrDisabledDiscovery   = errors.New("discovery disabled")
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
+	ErrNoScoreBook         = errors.New("no peer score book")
 )
 
 type Node interface {
@@ -53,6 +54,10 @@ type Node interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// SyncClient returns the req-resp sync client, nil if req-resp sync is disabled
+	SyncClient() *SyncClient
+	// AppScorer returns the application scorer of peers, nil if peer scoring is disabled
+	AppScorer() ApplicationScorer
 }
 
 type APIBackend struct {
@@ -114,6 +119,7 @@ func dumpPeer(id peer.ID, nw network.Network, pstore peerstore.Peerstore, connMg
 	if eps, ok := pstore.(store.ExtendedPeerstore); ok {
 		if dat, err := eps.GetPeerScores(id); err == nil {
 			info.PeerScores = dat
+			info.GossipScore = dat.Gossip.Total
 		}
 	}
 	if dat, err := pstore.Get(id, "ProtocolVersion"); err == nil {
@@ -187,6 +193,12 @@ func (s *APIBackend) Peers(ctx context.Context, connected bool) (*PeerDump, erro
 			s.log.Debug("failed to dump peer info in RPC request", "peer", id, "err", err)
 			continue
 		}
+		if appScorer := s.node.AppScorer(); appScorer != nil {
+			peerInfo.AppScore = appScorer.ApplicationScore(id)
+		}
+		if syncCl := s.node.SyncClient(); syncCl != nil {
+			peerInfo.SyncStats = syncCl.PeerSyncStats(id)
+		}
 		// We don't use the peer.ID type as key,
 		// since JSON decoding can't use the provided json unmarshaler (on *string type).
 		dump.Peers[id.String()] = peerInfo
@@ -240,6 +252,16 @@ func (s *APIBackend) PeerStats(_ context.Context) (*PeerStats, error) {
 	return stats, nil
 }
 
+func (s *APIBackend) PeerScoreHistory(_ context.Context, id peer.ID) ([]store.ScoreSnapshot, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_peerScoreHistory")
+	defer recordDur()
+	eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore)
+	if !ok {
+		return nil, ErrNoScoreBook
+	}
+	return eps.GetPeerScoreHistory(id)
+}
+
 func (s *APIBackend) DiscoveryTable(_ context.Context) ([]*enode.Node, error) {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_discoveryTable")
 	defer recordDur()
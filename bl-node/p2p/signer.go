@@ -15,6 +15,8 @@ import (
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+var SigningDomainSafeHeadsV1 = [32]byte{31: 1}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -83,6 +83,12 @@ type PeerScores struct {
 	ReqResp ReqRespScores `json:"reqResp"`
 }
 
+// ScoreSnapshot is the state of the scores of a peer at a point in time.
+type ScoreSnapshot struct {
+	Time   int64      `json:"time"` // unix timestamp in seconds
+	Scores PeerScores `json:"scores"`
+}
+
 // ScoreDatastore defines a type-safe API for getting and setting libp2p peer score information
 type ScoreDatastore interface {
 	// GetPeerScores returns the current scores for the specified peer
@@ -93,6 +99,9 @@ type ScoreDatastore interface {
 
 	// SetScore applies the given store diff to the specified peer
 	SetScore(id peer.ID, diff ScoreDiff) (PeerScores, error)
+
+	// GetPeerScoreHistory returns periodic snapshots of the scores of the specified peer, oldest first
+	GetPeerScoreHistory(id peer.ID) ([]ScoreSnapshot, error)
 }
 
 // ScoreDiff defines a type-safe batch of changes to apply to the peer-scoring record of the peer.
@@ -14,14 +14,19 @@ import (
 
 const (
 	scoreCacheSize = 100
+	// scoreHistoryInterval is the minimum interval between snapshots in the score history of a peer
+	scoreHistoryInterval = 5 * time.Minute
+	// scoreHistorySize is the number of snapshots retained in the score history of a peer
+	scoreHistorySize = 36
 )
 
 var scoresBase = ds.NewKey("/peers/scores")
 
 // LastUpdate requires atomic update operations. Use the helper functions SetLastUpdated and LastUpdated to modify and access this field.
 type scoreRecord struct {
-	LastUpdate int64      `json:"lastUpdate"` // unix timestamp in seconds
-	PeerScores PeerScores `json:"peerScores"`
+	LastUpdate int64           `json:"lastUpdate"` // unix timestamp in seconds
+	PeerScores PeerScores      `json:"peerScores"`
+	History    []ScoreSnapshot `json:"history,omitempty"`
 }
 
 func (s *scoreRecord) SetLastUpdated(t time.Time) {
@@ -88,11 +93,40 @@ func (d *scoreBook) GetPeerScore(id peer.ID) (float64, error) {
 	return scores.Gossip.Total, nil
 }
 
+func (d *scoreBook) GetPeerScoreHistory(id peer.ID) ([]ScoreSnapshot, error) {
+	record, err := d.book.GetRecord(id)
+	if err == UnknownRecordErr {
+		return []ScoreSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]ScoreSnapshot{}, record.History...), nil
+}
+
 func (d *scoreBook) SetScore(id peer.ID, diff ScoreDiff) (PeerScores, error) {
-	v, err := d.book.SetRecord(id, diff)
+	v, err := d.book.SetRecord(id, recordHistory{diff: diff})
 	return v.PeerScores, err
 }
 
+// recordHistory applies the diff, and snapshots the resulting scores
+// if the last snapshot in the history is older than the history interval.
+type recordHistory struct {
+	diff ScoreDiff
+}
+
+func (h recordHistory) Apply(rec *scoreRecord) {
+	h.diff.Apply(rec)
+	now := rec.LastUpdated().Unix()
+	if n := len(rec.History); n > 0 && now-rec.History[n-1].Time < int64(scoreHistoryInterval/time.Second) {
+		return
+	}
+	rec.History = append(rec.History, ScoreSnapshot{Time: now, Scores: rec.PeerScores})
+	if len(rec.History) > scoreHistorySize {
+		rec.History = rec.History[len(rec.History)-scoreHistorySize:]
+	}
+}
+
 func (d *scoreBook) Close() {
 	d.book.Close()
 }
@@ -162,6 +162,48 @@ func TestCloseCompletes(t *testing.T) {
 	require.NoError(t, store.Close())
 }
 
+func TestScoreHistory(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	logger := testlog.Logger(t, log.LvlInfo)
+	clock := clock.NewDeterministicClock(time.Unix(1000, 0))
+	book, err := newScoreBook(ctx, logger, clock, sync.MutexWrap(ds.NewMapDatastore()), 24*time.Hour)
+	require.NoError(t, err)
+	id := peer.ID("aaaa")
+
+	history, err := book.GetPeerScoreHistory(id)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	setScoreRequired(t, book, id, &GossipScores{Total: 1})
+	// Updates within the history interval only change the current score
+	clock.AdvanceTime(scoreHistoryInterval - time.Second)
+	setScoreRequired(t, book, id, &GossipScores{Total: 2})
+	clock.AdvanceTime(time.Second)
+	setScoreRequired(t, book, id, &GossipScores{Total: 3})
+
+	history, err = book.GetPeerScoreHistory(id)
+	require.NoError(t, err)
+	require.Equal(t, []ScoreSnapshot{
+		{Time: 1000, Scores: PeerScores{Gossip: GossipScores{Total: 1}}},
+		{Time: 1000 + int64(scoreHistoryInterval/time.Second), Scores: PeerScores{Gossip: GossipScores{Total: 3}}},
+	}, history)
+	scores, err := book.GetPeerScores(id)
+	require.NoError(t, err)
+	require.Equal(t, PeerScores{Gossip: GossipScores{Total: 3}}, scores)
+
+	// Only the latest snapshots are retained
+	for i := 0; i < scoreHistorySize; i++ {
+		clock.AdvanceTime(scoreHistoryInterval)
+		setScoreRequired(t, book, id, &GossipScores{Total: float64(10 + i)})
+	}
+	history, err = book.GetPeerScoreHistory(id)
+	require.NoError(t, err)
+	require.Len(t, history, scoreHistorySize)
+	require.Equal(t, float64(10), history[0].Scores.Gossip.Total)
+	require.Equal(t, float64(10+scoreHistorySize-1), history[scoreHistorySize-1].Scores.Gossip.Total)
+}
+
 func TestPrune(t *testing.T) {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -59,12 +60,19 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Max number of payloads to request in a single range request. Every payload costs a rate-limit token,
+	// so this must not exceed the per-peer burst.
+	maxRangeRequestSize = 8
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -139,6 +147,7 @@ type SyncPeerScorer interface {
 //
 // - Peers each have their own routine for processing requests.
 //   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - Requests for consecutive block numbers are batched into a single range request, if the peer supports it.
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -191,6 +200,7 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -232,6 +242,25 @@ type SyncClient struct {
 	// Don't allow anything to be added to the wait-group while, or after, we are shutting down.
 	// This is protected by peersLock.
 	closingPeers bool
+
+	statsLock sync.Mutex
+	// sync statistics per peer, for the peers that are registered for sync duties
+	stats map[peer.ID]*peerSyncStats
+}
+
+// PeerSyncStats are the req-resp sync statistics of a peer, since it was registered for sync duties.
+type PeerSyncStats struct {
+	Requests         uint64        `json:"requests"`
+	ValidResponses   uint64        `json:"validResponses"`
+	ErrorResponses   uint64        `json:"errorResponses"`
+	RejectedPayloads uint64        `json:"rejectedPayloads"`
+	LastRequest      int64         `json:"lastRequest"` // unix timestamp in seconds, 0 if no requests were made
+	AvgLatency       time.Duration `json:"avgLatency"`
+}
+
+type peerSyncStats struct {
+	PeerSyncStats
+	totalLatency time.Duration
 }
 
 func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rcv receivePayloadFn, metrics SyncClientMetrics, appScorer SyncPeerScorer) *SyncClient {
@@ -244,7 +273,9 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		appScorer:       appScorer,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange: PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
+		stats:           make(map[peer.ID]*peerSyncStats),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
 		requests:        make(chan rangeRequest), // blocking
@@ -287,6 +318,9 @@ func (s *SyncClient) AddPeer(id peer.ID) {
 	// add new peer routine
 	ctx, cancel := context.WithCancel(s.resCtx)
 	s.peers[id] = cancel
+	s.statsLock.Lock()
+	s.stats[id] = new(peerSyncStats)
+	s.statsLock.Unlock()
 	go s.peerLoop(ctx, id)
 }
 
@@ -434,6 +468,9 @@ func (s *SyncClient) onQuarantineEvict(key common.Hash, value syncResult) {
 		s.log.Debug("evicting untrusted payload from quarantine", "id", value.payload.ID(), "peer", value.peer)
 		// Down-score peer for having provided us a bad block that never turned out to be canonical
 		s.appScorer.onRejectedPayload(value.peer)
+		s.updateStats(value.peer, func(stats *peerSyncStats) {
+			stats.RejectedPayloads++
+		})
 	} else {
 		s.log.Debug("evicting trusted payload from quarantine", "id", value.payload.ID(), "peer", value.peer)
 	}
@@ -501,6 +538,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	defer func() {
 		s.peersLock.Lock()
 		delete(s.peers, id) // clean up
+		s.statsLock.Lock()
+		delete(s.stats, id)
+		s.statsLock.Unlock()
 		s.log.Debug("stopped syncing loop of peer", "id", id)
 		s.wg.Done()
 		s.peersLock.Unlock()
@@ -513,54 +553,155 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
 
+	// Assume the peer supports range requests, until it negotiates the single payload protocol instead.
+	rangeSupported := true
+	// A request that was taken from the queue, but did not fit in the previous batch.
+	var pending *peerRequest
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
+			abortRequests(pending)
 			return
 		}
 		// wait for peer to be available for more work
 		if err := rl.Wait(ctx); err != nil {
+			abortRequests(pending)
 			return
 		}
 
 		// once the peer is available, wait for a sync request.
-		select {
-		case pr := <-s.peerRequests:
-			// We already established the peer is available w.r.t. rate-limiting,
-			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
-			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				s.appScorer.onResponseError(id)
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, to back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
-					return
-				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+		var pr peerRequest
+		if pending != nil {
+			pr, pending = *pending, nil
+		} else {
+			select {
+			case pr = <-s.peerRequests:
+			case <-ctx.Done():
+				return
+			}
+		}
+		batch := []peerRequest{pr}
+		if rangeSupported {
+			batch, pending = s.collectBatch(batch)
+		}
+		// Every payload of the batch costs a rate-limit token, we took the first one already.
+		if n := len(batch) - 1; n > 0 {
+			if err := s.globalRL.WaitN(ctx, n); err != nil {
+				abortRequests(pending, batch...)
+				return
+			}
+			if err := rl.WaitN(ctx, n); err != nil {
+				abortRequests(pending, batch...)
+				return
 			}
-			took := time.Since(start)
+		}
 
-			resultCode := byte(0)
+		// We already established the peer is available w.r.t. rate-limiting,
+		// and this is the only loop over this peer, so we can request now.
+		start := time.Now()
+		var err error
+		if len(batch) > 1 {
+			rangeSupported, err = s.doRangeRequest(ctx, id, batch)
+			if !rangeSupported {
+				log.Info("Peer does not support range requests, requesting payloads one by one")
+			}
+		} else {
+			err = s.doRequest(ctx, id, pr.num)
+		}
+		if err != nil {
+			// mark as complete if there's an error: we are not sending any more results and can complete immediately.
+			abortRequests(nil, batch...)
+			log.Warn("failed p2p sync request", "num", pr.num, "count", len(batch), "err", err)
+			s.appScorer.onResponseError(id)
+			// If we hit an error, then count it as many requests.
+			// We'd like to avoid making more requests for a while, to back off.
+			if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+				abortRequests(pending)
+				return
+			}
+		} else {
+			log.Debug("completed p2p sync request", "num", pr.num, "count", len(batch))
+			s.appScorer.onValidResponse(id)
+		}
+		took := time.Since(start)
+		// statistics are counted per requested payload, whether batched or not
+		s.updateStats(id, func(stats *peerSyncStats) {
+			n := uint64(len(batch))
+			stats.Requests += n
 			if err != nil {
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-				} else {
-					resultCode = 1
-				}
+				stats.ErrorResponses += n
+			} else {
+				stats.ValidResponses += n
+			}
+			stats.LastRequest = start.Unix()
+			stats.totalLatency += took
+		})
+
+		resultCode := byte(0)
+		if err != nil {
+			if re, ok := err.(requestResultErr); ok {
+				resultCode = re.ResultCode()
+			} else {
+				resultCode = 1
 			}
+		}
+		for _, pr := range batch {
 			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
-		case <-ctx.Done():
-			return
 		}
 	}
 }
 
+// collectBatch extends the batch with queued requests for the next lower block numbers, without blocking.
+// A queued request that does not continue the batch is returned separately, to be processed next.
+func (s *SyncClient) collectBatch(batch []peerRequest) ([]peerRequest, *peerRequest) {
+	for len(batch) < maxRangeRequestSize {
+		select {
+		case next := <-s.peerRequests:
+			if next.num+1 != batch[len(batch)-1].num {
+				return batch, &next
+			}
+			batch = append(batch, next)
+		default:
+			return batch, nil
+		}
+	}
+	return batch, nil
+}
+
+// abortRequests marks the requests as complete, without result, so they can be rescheduled.
+func abortRequests(pending *peerRequest, batch ...peerRequest) {
+	if pending != nil {
+		pending.complete.Store(true)
+	}
+	for _, pr := range batch {
+		pr.complete.Store(true)
+	}
+}
+
+func (s *SyncClient) updateStats(id peer.ID, fn func(stats *peerSyncStats)) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	if stats, ok := s.stats[id]; ok {
+		fn(stats)
+	}
+}
+
+// PeerSyncStats returns the sync statistics of the peer, or nil if the peer is not registered for sync duties.
+func (s *SyncClient) PeerSyncStats(id peer.ID) *PeerSyncStats {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	stats, ok := s.stats[id]
+	if !ok {
+		return nil
+	}
+	out := stats.PeerSyncStats
+	if out.Requests > 0 {
+		out.AvgLatency = stats.totalLatency / time.Duration(out.Requests)
+	}
+	return &out
+}
+
 type requestResultErr byte
 
 func (r requestResultErr) Error() string {
@@ -580,6 +721,11 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 		return fmt.Errorf("failed to open stream: %w", err)
 	}
 	defer str.Close()
+	return s.requestPayload(ctx, id, str, expectedBlockNum)
+}
+
+// requestPayload requests a single payload on a stream of the payload_by_number protocol.
+func (s *SyncClient) requestPayload(ctx context.Context, id peer.ID, str network.Stream, expectedBlockNum uint64) error {
 	// set write timeout (if available)
 	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
 	if err := binary.Write(str, binary.LittleEndian, expectedBlockNum); err != nil {
@@ -620,7 +766,128 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	if err != nil {
 		return fmt.Errorf("failed to read response: %w", err)
 	}
+	res, err := s.decodePayload(data, expectedBlockNum)
+	if err != nil {
+		return err
+	}
+	if err := str.CloseRead(); err != nil {
+		return fmt.Errorf("failed to close reading side")
+	}
+	select {
+	case s.results <- syncResult{payload: res, peer: id}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+	}
+	return nil
+}
+
+// doRangeRequest requests the batch of consecutive payloads, ordered by descending block number, in a single stream.
+// If the peer does not support range requests, the payloads are requested one by one, and false is returned.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, batch []peerRequest) (rangeSupported bool, err error) {
+	// open stream to peer, with a fallback to the single payload protocol to learn if ranges are unsupported
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange, s.payloadByNumber)
+	reqCancel()
+	if err != nil {
+		return true, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	if str.Protocol() != s.payloadsByRange {
+		if err := s.requestPayload(ctx, id, str, batch[0].num); err != nil {
+			return false, err
+		}
+		for _, pr := range batch[1:] {
+			if err := s.doRequest(ctx, id, pr.num); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	return true, s.requestRange(ctx, id, str, batch[len(batch)-1].num, uint64(len(batch)))
+}
+
+// requestRange requests count payloads, starting at the given block number, on a stream of the payloads_by_range protocol.
+func (s *SyncClient) requestRange(ctx context.Context, id peer.ID, str network.Stream, start uint64, count uint64) error {
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [16]byte
+	binary.LittleEndian.PutUint64(req[:8], start)
+	binary.LittleEndian.PutUint64(req[8:], count)
+	if _, err := str.Write(req[:]); err != nil {
+		return fmt.Errorf("failed to write range request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
 
+	for i := uint64(0); i < count; i++ {
+		num := start + i
+		// set read timeout per chunk (if available)
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		data, err := readRangeChunk(str)
+		if err != nil {
+			return fmt.Errorf("failed to read payload %d of range response: %w", num, err)
+		}
+		res, err := s.decodePayload(data, num)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.results <- syncResult{payload: res, peer: id}:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+	}
+	if err := str.CloseRead(); err != nil {
+		return fmt.Errorf("failed to close reading side")
+	}
+	return nil
+}
+
+// readRangeChunk reads a single payload chunk of a range response, and returns the decompressed SSZ data.
+// Each chunk is encoded as:
+// 0 - resultCode: success = 0
+// 1:5 - version: 0
+// 5:9 - length of the snappy block compressed SSZ payload
+// 9: - snappy block compressed SSZ payload
+// If the result code is not 0, the chunk ends after the result code.
+func readRangeChunk(r io.Reader) ([]byte, error) {
+	var header [9]byte
+	if _, err := io.ReadFull(r, header[:1]); err != nil {
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := header[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	if _, err := io.ReadFull(r, header[1:]); err != nil {
+		return nil, fmt.Errorf("failed to read header of response chunk: %w", err)
+	}
+	if version := binary.LittleEndian.Uint32(header[1:5]); version != 0 {
+		return nil, fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
+	}
+	size := binary.LittleEndian.Uint32(header[5:9])
+	if size > maxGossipSize {
+		return nil, fmt.Errorf("response chunk of %d bytes exceeds limit", size)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read response chunk: %w", err)
+	}
+	// Limit the output, to not decompress more data than desired (zip-bomb)
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("invalid compressed response chunk: %w", err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("decompressed response chunk of %d bytes exceeds limit", n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response chunk: %w", err)
+	}
+	return data, nil
+}
+
+// decodePayload decodes the SSZ payload data, and verifies it is a valid block with the expected number.
+func (s *SyncClient) decodePayload(data []byte, expectedBlockNum uint64) (*eth.ExecutionPayload, error) {
 	expectedBlockTime := s.cfg.TimestampForBlock(expectedBlockNum)
 
 	blockVersion := eth.BlockV1
@@ -629,21 +896,12 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	}
 	var res eth.ExecutionPayload
 	if err := res.UnmarshalSSZ(blockVersion, uint32(len(data)), bytes.NewReader(data)); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if err := str.CloseRead(); err != nil {
-		return fmt.Errorf("failed to close reading side")
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 	if err := verifyBlock(&res, expectedBlockNum); err != nil {
-		return fmt.Errorf("received execution payload is invalid: %w", err)
+		return nil, fmt.Errorf("received execution payload is invalid: %w", err)
 	}
-	select {
-	case s.results <- syncResult{payload: &res, peer: id}:
-	case <-ctx.Done():
-		return fmt.Errorf("failed to process response, sync client is too busy: %w", err)
-	}
-	return nil
+	return &res, nil
 }
 
 func verifyBlock(payload *eth.ExecutionPayload, expectedNum uint64) error {
@@ -720,13 +978,7 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 	resultCode := byte(0)
 	if err != nil {
 		log.Warn("failed to serve p2p sync request", "req", req, "err", err)
-		if errors.Is(err, ethereum.NotFound) {
-			resultCode = 1
-		} else if errors.Is(err, invalidRequestErr) {
-			resultCode = 2
-		} else {
-			resultCode = 3
-		}
+		resultCode = serverResultCode(err)
 		// try to write error code, so the other peer can understand the reason for failure.
 		_, _ = stream.Write([]byte{resultCode})
 	} else {
@@ -737,35 +989,66 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
+func serverResultCode(err error) byte {
+	if errors.Is(err, ethereum.NotFound) {
+		return 1
+	} else if errors.Is(err, invalidRequestErr) {
+		return 2
+	} else {
+		return 3
+	}
+}
 
-	// take a token from the global rate-limiter,
+// rateLimit takes n tokens from the global and the per-peer rate-limiters, and waits as long as necessary.
+func (srv *ReqRespServer) rateLimit(ctx context.Context, peerId peer.ID, n int) error {
+	// take tokens from the global rate-limiter,
 	// to make sure there's not too much concurrent server work between different peers.
-	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	if err := srv.globalRequestsRL.WaitN(ctx, n); err != nil {
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 	}
 
 	// find rate limiting data of peer, or add otherwise
 	srv.peerStatsLock.Lock()
+	defer srv.peerStatsLock.Unlock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
 			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
 		}
 		srv.peerRateLimits.Add(peerId, ps)
-		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
+		ps.Requests.ReserveN(time.Now(), n) // count the hit, but make it delay the next request rather than immediately waiting
 	} else {
 		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
 
 		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
-		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+		if err := ps.Requests.WaitN(ctx, n); err != nil {
+			return fmt.Errorf("timed out waiting for peer sync rate limit: %w", err)
 		}
 	}
-	srv.peerStatsLock.Unlock()
+	return nil
+}
+
+// checkRequestRange checks the requested block numbers are within the expected range of blocks.
+func (srv *ReqRespServer) checkRequestRange(first, last uint64) error {
+	if first < srv.cfg.Genesis.L2.Number {
+		return fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", first, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+	if last > max {
+		return fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", last, max, invalidRequestErr)
+	}
+	return nil
+}
+
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.rateLimit(ctx, stream.Conn().RemotePeer(), 1); err != nil {
+		return 0, err
+	}
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -780,15 +1063,8 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 
 	// Check the request is within the expected range of blocks
-	if req < srv.cfg.Genesis.L2.Number {
-		return req, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req, srv.cfg.Genesis.L2.Number, invalidRequestErr)
-	}
-	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
-	if err != nil {
-		return req, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
-	}
-	if req > max {
-		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, invalidRequestErr)
+	if err := srv.checkRequestRange(req, req); err != nil {
+		return req, err
 	}
 
 	payload, err := srv.l2.PayloadByNumber(ctx, req)
@@ -818,3 +1094,97 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 	return req, nil
 }
+
+// HandleSyncRangeRequest is a stream handler function to register the L2 unsafe payloads range alt-sync protocol.
+// The request is the start block number and the number of payloads, both as little-endian uint64.
+// The payloads are returned in ascending order, each as a separate chunk, see readRangeChunk for the encoding.
+// If a payload cannot be served, an error result code is written, and the stream is closed.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleSyncRangeRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, err := srv.handleSyncRangeRequest(ctx, stream)
+	cancel()
+
+	resultCode := byte(0)
+	if err != nil {
+		log.Warn("failed to serve p2p sync range request", "start", req.start, "count", req.count, "err", err)
+		resultCode = serverResultCode(err)
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served sync range response", "start", req.start, "count", req.count)
+	}
+	srv.metrics.ServerPayloadByNumberEvent(req.start, resultCode, time.Since(start))
+}
+
+type syncRangeRequest struct {
+	start uint64
+	count uint64
+}
+
+func (srv *ReqRespServer) handleSyncRangeRequest(ctx context.Context, stream network.Stream) (syncRangeRequest, error) {
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request, before rate-limiting, since every requested payload costs a token.
+	var data [16]byte
+	if _, err := io.ReadFull(stream, data[:]); err != nil {
+		return syncRangeRequest{}, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	req := syncRangeRequest{
+		start: binary.LittleEndian.Uint64(data[:8]),
+		count: binary.LittleEndian.Uint64(data[8:]),
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, fmt.Errorf("failed to close reading-side of a P2P sync range request call: %w", err)
+	}
+	if req.count == 0 || req.count > maxRangeRequestSize {
+		return req, fmt.Errorf("cannot serve range of %d payloads, max is %d: %w", req.count, maxRangeRequestSize, invalidRequestErr)
+	}
+	if req.start > math.MaxUint64-req.count {
+		return req, fmt.Errorf("range overflows: %w", invalidRequestErr)
+	}
+	if err := srv.checkRequestRange(req.start, req.start+req.count-1); err != nil {
+		return req, err
+	}
+
+	if err := srv.rateLimit(ctx, stream.Conn().RemotePeer(), int(req.count)); err != nil {
+		return req, err
+	}
+
+	var buf bytes.Buffer
+	for num := req.start; num < req.start+req.count; num++ {
+		payload, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, fmt.Errorf("peer requested unknown block %d by range: %w", num, err)
+			} else {
+				return req, fmt.Errorf("failed to retrieve payload %d to serve to peer: %w", num, err)
+			}
+		}
+		buf.Reset()
+		if _, err := payload.MarshalSSZ(&buf); err != nil {
+			return req, fmt.Errorf("failed to encode payload %d: %w", num, err)
+		}
+		compressed := snappy.Encode(nil, buf.Bytes())
+
+		// We set write deadline, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+
+		// 0 - resultCode: success = 0
+		// 1:5 - version: 0
+		// 5:9 - length of compressed payload
+		var header [9]byte
+		binary.LittleEndian.PutUint32(header[5:], uint32(len(compressed)))
+		if _, err := stream.Write(header[:]); err != nil {
+			return req, fmt.Errorf("failed to write response chunk header: %w", err)
+		}
+		if _, err := stream.Write(compressed); err != nil {
+			return req, fmt.Errorf("failed to write payload %d to sync response: %w", num, err)
+		}
+	}
+	return req, nil
+}
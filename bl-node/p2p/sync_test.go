@@ -4,9 +4,12 @@ import (
 	"context"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -31,6 +34,21 @@ func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.
 
 var _ L2Chain = mockPayloadFn(nil)
 
+// mockCanonicalChain serves payloads, and the canonical blocks of the test data
+type mockCanonicalChain struct {
+	mockPayloadFn
+	data *syncTestData
+}
+
+func (m mockCanonicalChain) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	if _, ok := m.data.getPayload(num); !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return m.data.getBlockRef(num), nil
+}
+
+var _ CanonicalL2Chain = mockCanonicalChain{}
+
 type syncTestData struct {
 	sync.RWMutex
 	payloads map[uint64]*eth.ExecutionPayload
@@ -155,6 +173,86 @@ func TestSinglePeerSync(t *testing.T) {
 		require.True(t, ok, "expecting known payload")
 		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
 	}
+
+	// the sync statistics of the peer are updated after each request completes
+	require.Eventually(t, func() bool {
+		stats := cl.PeerSyncStats(hostA.ID())
+		return stats != nil && stats.ValidResponses >= 9
+	}, 10*time.Second, 10*time.Millisecond)
+	stats := cl.PeerSyncStats(hostA.ID())
+	require.Equal(t, stats.Requests, stats.ValidResponses+stats.ErrorResponses)
+	require.NotZero(t, stats.LastRequest)
+	require.Nil(t, cl.PeerSyncStats(hostB.ID()), "no stats for unregistered peers")
+}
+
+func TestRangeSync(t *testing.T) {
+	t.Parallel()
+
+	logger := testlog.Logger(t, log.LvlError)
+
+	cfg, payloads := setupSyncTestData(25)
+
+	// Serving payloads from a cache in front of the engine, counting the engine requests
+	var engineRequests atomic.Uint64
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayload, error) {
+		engineRequests.Add(1)
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+	cache := NewPayloadCache(dssync.MutexWrap(ds.NewMapDatastore()), 64)
+	cached := NewCachedL2Chain(logger, mockCanonicalChain{servePayload, payloads}, cache)
+
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB, hostC := hosts[0], hosts[1], hosts[2]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, supporting both the single payload and range protocols
+	srv := NewReqRespServer(cfg, cached, metrics.NoopMetrics)
+	var rangeRequests atomic.Uint64
+	payloadsByRange := MakeStreamHandler(ctx, logger.New("role", "server"), func(ctx context.Context, log log.Logger, stream network.Stream) {
+		rangeRequests.Add(1)
+		srv.HandleSyncRangeRequest(ctx, log, stream)
+	})
+	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), MakeStreamHandler(ctx, logger.New("role", "server"), srv.HandleSyncRequest))
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	syncFrom := func(h host.Host) {
+		cl := NewSyncClient(logger.New("role", "client"), cfg, h.NewStream, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+		cl.AddPeer(hostA.ID())
+		cl.Start()
+		defer cl.Close()
+
+		require.NoError(t, cl.RequestL2Range(ctx, payloads.getBlockRef(10), payloads.getBlockRef(20)))
+		for i := uint64(19); i > 10; i-- {
+			p := <-received
+			require.Equal(t, uint64(p.BlockNumber), i, "expecting payloads in order")
+			exp, ok := payloads.getPayload(uint64(p.BlockNumber))
+			require.True(t, ok, "expecting known payload")
+			require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
+		}
+	}
+
+	syncFrom(hostB)
+	require.NotZero(t, rangeRequests.Load(), "expecting batched requests")
+	require.Equal(t, uint64(9), engineRequests.Load(), "engine is asked for each payload once")
+
+	// A second client is served from the cache
+	syncFrom(hostC)
+	require.Equal(t, uint64(9), engineRequests.Load(), "payloads are served from the cache")
 }
 
 func TestMultiPeerSync(t *testing.T) {
@@ -0,0 +1,192 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/rollup"
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+// blobOrCalldata is a piece of batcher data: either calldata of a regular batcher transaction,
+// or a reference to a blob that still needs to be fetched and filled in.
+type blobOrCalldata struct {
+	// union type. exactly one of calldata or blob should be non-nil
+	blob     *eth.Blob
+	calldata *eth.Data
+}
+
+// BlobDataSource fetches blobs or calldata as appropriate and transforms them into usable rollup data.
+// Like the CalldataSource, the constructor never fails, and fetching is re-attempted on the next call to `Next`.
+type BlobDataSource struct {
+	data         []eth.Data
+	ref          eth.L1BlockRef
+	batcherAddr  common.Address
+	cfg          *rollup.Config
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	log          log.Logger
+}
+
+// NewBlobDataSource creates a new blob data source.
+func NewBlobDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	return &BlobDataSource{
+		ref:          ref,
+		cfg:          cfg,
+		fetcher:      fetcher,
+		log:          log.New("origin", ref),
+		batcherAddr:  batcherAddr,
+		blobsFetcher: blobsFetcher,
+	}
+}
+
+// Next returns the next piece of batcher data, or an io.EOF error if no data remains. It returns ResetError if it cannot
+// find the referenced block or a referenced blob, or TemporaryError for any other failure to fetch a block or blob.
+func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
+	if ds.data == nil {
+		var err error
+		if ds.data, err = ds.open(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ds.data) == 0 {
+		return nil, io.EOF
+	}
+
+	data := ds.data[0]
+	ds.data = ds.data[1:]
+	return data, nil
+}
+
+// open fetches and returns the blob or calldata (as appropriate) from all valid batcher
+// transactions in the referenced block. Returns an empty (non-nil) array if no batcher
+// transactions are found.
+func (ds *BlobDataSource) open(ctx context.Context) ([]eth.Data, error) {
+	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, NewResetError(fmt.Errorf("failed to open blob data source: %w", err))
+		}
+		return nil, NewTemporaryError(fmt.Errorf("failed to open blob data source: %w", err))
+	}
+
+	data, hashes := dataAndHashesFromTxs(txs, ds.cfg, ds.batcherAddr, ds.log)
+
+	if len(hashes) == 0 {
+		// there are no blobs to fetch so we can return immediately
+		return toData(data), nil
+	}
+
+	// download the actual blob bodies corresponding to the indexed blob hashes
+	blobs, err := ds.blobsFetcher.GetBlobs(ctx, ds.ref, hashes)
+	if errors.Is(err, ethereum.NotFound) {
+		// If the L1 block was available, then the blobs should be available too. The only
+		// exception is if the blob retention window has expired, which we will ultimately handle
+		// by failing over to a blob archival service.
+		return nil, NewResetError(fmt.Errorf("failed to fetch blobs: %w", err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch blobs: %w", err))
+	}
+
+	// go back over the data array and populate data with the blobs
+	if err := fillBlobPointers(data, blobs); err != nil {
+		return nil, NewCriticalError(err)
+	}
+
+	// decode the blobs into raw frame data
+	out := make([]eth.Data, 0, len(data))
+	for i, d := range data {
+		if d.calldata != nil {
+			out = append(out, *d.calldata)
+			continue
+		}
+		blobData, err := d.blob.ToData()
+		if err != nil {
+			// invalid blob encodings are ignored, like invalid frames in calldata
+			ds.log.Warn("ignoring blob due to parse failure", "index", i, "err", err)
+			continue
+		}
+		out = append(out, blobData)
+	}
+	return out, nil
+}
+
+// dataAndHashesFromTxs extracts calldata and datahashes from the input transactions and returns them. It
+// creates a placeholder blobOrCalldata element for each returned blob hash that must be populated
+// by fillBlobPointers after blob bodies are retrieved.
+func dataAndHashesFromTxs(txs types.Transactions, config *rollup.Config, batcherAddr common.Address, log log.Logger) ([]blobOrCalldata, []eth.IndexedBlobHash) {
+	data := []blobOrCalldata{}
+	var hashes []eth.IndexedBlobHash
+	blobIndex := 0 // index of each blob in the block's blob sidecar
+	l1Signer := config.L1Signer()
+	for _, tx := range txs {
+		// skip any non-batcher transactions
+		if !isValidBatchTx(tx, l1Signer, config.BatchInboxAddress, batcherAddr, log) {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		// handle non-blob batcher transactions by extracting their calldata
+		if tx.Type() != types.BlobTxType {
+			calldata := eth.Data(tx.Data())
+			data = append(data, blobOrCalldata{nil, &calldata})
+			continue
+		}
+		// handle blob batcher transactions by extracting their blob hashes, ignoring any calldata.
+		if len(tx.Data()) > 0 {
+			log.Warn("blob tx has calldata, which will be ignored", "txhash", tx.Hash())
+		}
+		for _, h := range tx.BlobHashes() {
+			idh := eth.IndexedBlobHash{
+				Index: uint64(blobIndex),
+				Hash:  h,
+			}
+			hashes = append(hashes, idh)
+			data = append(data, blobOrCalldata{nil, nil}) // will fill in blob pointers after we download them below
+			blobIndex += 1
+		}
+	}
+	return data, hashes
+}
+
+// fillBlobPointers goes back through the data array and fills in the pointers to the fetched blob
+// bodies. There should be exactly one placeholder blobOrCalldata element for each blob, otherwise
+// error is returned.
+func fillBlobPointers(data []blobOrCalldata, blobs []*eth.Blob) error {
+	blobIndex := 0
+	for i := range data {
+		if data[i].calldata != nil {
+			continue
+		}
+		if blobIndex >= len(blobs) {
+			return fmt.Errorf("didn't get enough blobs")
+		}
+		if blobs[blobIndex] == nil {
+			return fmt.Errorf("found a nil blob")
+		}
+		data[i].blob = blobs[blobIndex]
+		blobIndex++
+	}
+	if blobIndex != len(blobs) {
+		return fmt.Errorf("got too many blobs")
+	}
+	return nil
+}
+
+// toData converts a list of calldata-only elements into plain data.
+func toData(data []blobOrCalldata) []eth.Data {
+	out := make([]eth.Data, 0, len(data))
+	for _, d := range data {
+		if d.calldata != nil {
+			out = append(out, *d.calldata)
+		}
+	}
+	return out
+}
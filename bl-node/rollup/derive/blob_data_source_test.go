@@ -0,0 +1,214 @@
+package derive
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-node/rollup"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/bl-service/testutils"
+)
+
+type mockBlobsFetcher struct {
+	blobs map[common.Hash]*eth.Blob
+}
+
+func (m *mockBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	out := make([]*eth.Blob, 0, len(hashes))
+	for _, h := range hashes {
+		out = append(out, m.blobs[h.Hash])
+	}
+	return out, nil
+}
+
+type mockTxFetcher struct {
+	txs types.Transactions
+}
+
+func (m *mockTxFetcher) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	return nil, m.txs, nil
+}
+
+func TestDataAndHashesFromTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	privateKey := testutils.InsecureRandomKey(rng)
+	batcherAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	batchInboxAddr := testutils.RandomAddress(rng)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	chainId := new(big.Int).SetUint64(rng.Uint64())
+	cfg := &rollup.Config{
+		L1ChainID:         chainId,
+		BatchInboxAddress: batchInboxAddr,
+	}
+	signer := cfg.L1Signer()
+
+	// create a valid non-blob batcher transaction and make sure it's picked up
+	txData := &types.LegacyTx{
+		Nonce:    rng.Uint64(),
+		GasPrice: new(big.Int).SetUint64(rng.Uint64()),
+		Gas:      2_000_000,
+		To:       &batchInboxAddr,
+		Value:    big.NewInt(10),
+		Data:     testutils.RandomData(rng, rng.Intn(1000)),
+	}
+	calldataTx, _ := types.SignNewTx(privateKey, signer, txData)
+	txs := types.Transactions{calldataTx}
+	data, blobHashes := dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 0, len(blobHashes))
+
+	// create a valid blob batcher tx and make sure it's picked up
+	blobHash := testutils.RandomHash(rng)
+	blobTxData := &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		To:         batchInboxAddr,
+		Data:       testutils.RandomData(rng, rng.Intn(1000)),
+		BlobHashes: []common.Hash{blobHash},
+	}
+	blobTx, _ := types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.Nil(t, data[0].calldata)
+
+	// try again with both the blob & calldata transactions and make sure both are picked up
+	txs = types.Transactions{blobTx, calldataTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, 2, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.NotNil(t, data[1].calldata)
+
+	// make sure blob tx to the batch inbox is ignored if not signed by the batcher
+	wrongSigner := testutils.InsecureRandomKey(rng)
+	blobTx, _ = types.SignNewTx(wrongSigner, signer, blobTxData)
+	txs = types.Transactions{blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, 0, len(data))
+	require.Equal(t, 0, len(blobHashes))
+
+	// make sure blob tx ignored if the tx isn't going to the batch inbox addr, even if the
+	// signature is valid, but the blob index still advances.
+	blobTxData.To = testutils.RandomAddress(rng)
+	otherTx, _ := types.SignNewTx(privateKey, signer, blobTxData)
+	blobTxData.To = batchInboxAddr
+	blobTx, _ = types.SignNewTx(privateKey, signer, blobTxData)
+	txs = types.Transactions{otherTx, blobTx}
+	data, blobHashes = dataAndHashesFromTxs(txs, cfg, batcherAddr, logger)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, 1, len(blobHashes))
+	require.Equal(t, uint64(1), blobHashes[0].Index)
+}
+
+func TestFillBlobPointers(t *testing.T) {
+	blob := eth.Blob{}
+	rng := rand.New(rand.NewSource(1234))
+	calldata := eth.Data{}
+
+	for i := 0; i < 100; i++ {
+		// create a random length input data array w/ len = [0-10)
+		dataLen := rng.Intn(10)
+		data := make([]blobOrCalldata, dataLen)
+
+		// pick some subset of those to be blobs, and the rest calldata
+		blobLen := 0
+		if dataLen != 0 {
+			blobLen = rng.Intn(dataLen)
+		}
+		calldataLen := dataLen - blobLen
+
+		// fill in the calldata entries at random indices
+		for j := 0; j < calldataLen; j++ {
+			randomIndex := rng.Intn(dataLen)
+			for data[randomIndex].calldata != nil {
+				randomIndex = (randomIndex + 1) % dataLen
+			}
+			data[randomIndex].calldata = &calldata
+		}
+
+		// create the input blobs array and call fillBlobPointers on it
+		blobs := make([]*eth.Blob, blobLen)
+		for j := 0; j < blobLen; j++ {
+			blobs[j] = &blob
+		}
+		err := fillBlobPointers(data, blobs)
+		require.NoError(t, err)
+
+		// check that we get the expected number of calldata vs blobs results
+		blobCount := 0
+		calldataCount := 0
+		for j := 0; j < dataLen; j++ {
+			if data[j].calldata != nil {
+				calldataCount++
+			}
+			if data[j].blob != nil {
+				blobCount++
+			}
+		}
+		require.Equal(t, blobLen, blobCount)
+		require.Equal(t, calldataLen, calldataCount)
+	}
+
+	require.Error(t, fillBlobPointers(make([]blobOrCalldata, 2), []*eth.Blob{&blob}), "too few blobs")
+	require.Error(t, fillBlobPointers(make([]blobOrCalldata, 1), []*eth.Blob{&blob, &blob}), "too many blobs")
+}
+
+func TestBlobDataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	privateKey := testutils.InsecureRandomKey(rng)
+	batcherAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	batchInboxAddr := testutils.RandomAddress(rng)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(900),
+		BatchInboxAddress: batchInboxAddr,
+	}
+	signer := cfg.L1Signer()
+
+	frameData := testutils.RandomData(rng, 1000)
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(frameData))
+	blobHash := testutils.RandomHash(rng)
+	blobTx, err := types.SignNewTx(privateKey, signer, &types.BlobTx{
+		ChainID:    uint256.NewInt(900),
+		Gas:        100_000,
+		To:         batchInboxAddr,
+		BlobHashes: []common.Hash{blobHash},
+	})
+	require.NoError(t, err)
+	calldata := testutils.RandomData(rng, 100)
+	calldataTx, err := types.SignNewTx(privateKey, signer, &types.DynamicFeeTx{
+		ChainID: big.NewInt(900),
+		Gas:     100_000,
+		To:      &batchInboxAddr,
+		Data:    calldata,
+	})
+	require.NoError(t, err)
+
+	src := NewBlobDataSource(context.Background(), testlog.Logger(t, log.LvlInfo), cfg,
+		&mockTxFetcher{txs: types.Transactions{blobTx, calldataTx}},
+		&mockBlobsFetcher{blobs: map[common.Hash]*eth.Blob{blobHash: &blob}},
+		testutils.RandomBlockRef(rng), batcherAddr)
+
+	data, err := src.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, eth.Data(frameData), data)
+	data, err = src.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, eth.Data(calldata), data)
+	_, err = src.Next(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -145,12 +145,18 @@ type EngineQueue struct {
 	l1Fetcher L1Fetcher
 
 	syncCfg *sync.Config
+
+	// safeHeadNotifs is notified of safe head changes, optional (may be nil)
+	safeHeadNotifs SafeHeadListener
+	// lastNotifiedSafeHead is the last safe head that safeHeadNotifs was notified of
+	lastNotifiedSafeHead eth.L2BlockRef
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+// The safeHeadNotifs listener may be nil if safe head changes do not have to be tracked.
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadNotifs SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -161,6 +167,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadNotifs,
 	}
 }
 
@@ -280,6 +287,9 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 	}
 	eq.origin = newOrigin
 	eq.postProcessSafeL2() // make sure we track the last L2 safe head for every new L1 block
+	if err := eq.notifySafeHead(); err != nil {
+		return err
+	}
 	// try to finalize the L2 blocks we have synced so far (no-op if L1 finality is behind)
 	if err := eq.tryFinalizePastL2Blocks(ctx); err != nil {
 		return err
@@ -413,6 +423,20 @@ func (eq *EngineQueue) postProcessSafeL2() {
 	}
 }
 
+// notifySafeHead notifies the safe head listener, if any, of a change of the safe head since the last notification.
+func (eq *EngineQueue) notifySafeHead() error {
+	if eq.safeHeadNotifs == nil || eq.safeHead == eq.lastNotifiedSafeHead {
+		return nil
+	}
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.safeHead, eq.origin.ID()); err != nil {
+		// The engine already has the new safe head. Reset the pipeline, so the safe head rolls back,
+		// and the notification is retried when the safe head is derived again.
+		return NewResetError(fmt.Errorf("failed to notify safe head listener of %s at L1 block %s: %w", eq.safeHead, eq.origin, err))
+	}
+	eq.lastNotifiedSafeHead = eq.safeHead
+	return nil
+}
+
 func (eq *EngineQueue) logSyncProgress(reason string) {
 	eq.log.Info("Sync progress",
 		"reason", reason,
@@ -813,6 +837,12 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err))
 	}
+	if eq.safeHeadNotifs != nil {
+		if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+			return NewTemporaryError(fmt.Errorf("failed to notify safe head listener of reset to %s: %w", safe, err))
+		}
+	}
+	eq.lastNotifiedSafeHead = safe
 	eq.log.Debug("Reset engine queue", "safeHead", safe, "unsafe", unsafe, "safe_timestamp", safe.Time, "unsafe_timestamp", unsafe.Time, "l1Origin", l1Origin)
 	eq.unsafeHead = unsafe
 	eq.engineSyncTarget = unsafe
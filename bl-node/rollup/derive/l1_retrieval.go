@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,7 +12,7 @@ import (
 )
 
 type DataAvailabilitySource interface {
-	OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter
+	OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error)
 }
 
 type NextBlockProvider interface {
@@ -53,7 +54,9 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		} else if err != nil {
 			return nil, err
 		}
-		l1r.datas = l1r.dataSrc.OpenData(ctx, next.ID(), l1r.prev.SystemConfig().BatcherAddr)
+		if l1r.datas, err = l1r.dataSrc.OpenData(ctx, next, l1r.prev.SystemConfig().BatcherAddr); err != nil {
+			return nil, fmt.Errorf("failed to open data source: %w", err)
+		}
 	}
 
 	l1r.log.Debug("fetching next piece of data")
@@ -62,7 +65,7 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		l1r.datas = nil
 		return nil, io.EOF
 	} else if err != nil {
-		// CalldataSource appropriately wraps the error so avoid double wrapping errors here.
+		// The data source appropriately wraps the error so avoid double wrapping errors here.
 		return nil, err
 	} else {
 		return data, nil
@@ -73,7 +76,10 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 // Note that we open up the `l1r.datas` here because it is requires to maintain the
 // internal invariants that later propagate up the derivation pipeline.
 func (l1r *L1Retrieval) Reset(ctx context.Context, base eth.L1BlockRef, sysCfg eth.SystemConfig) error {
-	l1r.datas = l1r.dataSrc.OpenData(ctx, base.ID(), sysCfg.BatcherAddr)
+	var err error
+	if l1r.datas, err = l1r.dataSrc.OpenData(ctx, base, sysCfg.BatcherAddr); err != nil {
+		return fmt.Errorf("failed to open data source: %w", err)
+	}
 	l1r.log.Info("Reset of L1Retrieval done", "origin", base)
 	return io.EOF
 }
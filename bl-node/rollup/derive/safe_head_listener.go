@@ -0,0 +1,18 @@
+package derive
+
+import (
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+// SafeHeadListener is notified of changes to the safe head, to e.g. track which L2 block was safe at which L1 block.
+type SafeHeadListener interface {
+	// SafeHeadUpdated is called when the safe head changes. The safe head may advance by more than one block.
+	// The l1Block is the L1 block that the derivation pipeline was at when the new safe head was processed:
+	// the L1 chain up to and including this block has all data to derive the new safe head.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+
+	// SafeHeadReset is called when the derivation pipeline is reset, and the safe head rolls back to resetSafeHead.
+	// Any data recorded for L2 blocks after and including resetSafeHead should be discarded,
+	// as the L1 blocks that these were derived from may have been reorged out.
+	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
+}
@@ -0,0 +1,104 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+// ErrSlowSubscriber is returned by the Err channel of a subscription that was dropped,
+// because its channel was full when an event was sent.
+var ErrSlowSubscriber = errors.New("rollup event subscriber is too slow")
+
+// eventFeed delivers rollup events to subscribers without blocking the sender.
+// Subscribers that do not keep up are dropped, so the driver never waits on them.
+// The zero value is ready to use.
+type eventFeed struct {
+	mu   sync.Mutex
+	subs map[*eventSubscription]struct{}
+}
+
+// Subscribe adds a subscriber of the feed. The channel should be buffered, as events are dropped
+// together with the subscription when the channel is full.
+func (f *eventFeed) Subscribe(ch chan<- eth.RollupEvent) event.Subscription {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[*eventSubscription]struct{})
+	}
+	sub := &eventSubscription{feed: f, ch: ch, err: make(chan error, 1)}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+// Send delivers the event to all subscribers, dropping those with a full channel.
+func (f *eventFeed) Send(ev eth.RollupEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for sub := range f.subs {
+		select {
+		case sub.ch <- ev:
+		default:
+			delete(f.subs, sub)
+			sub.close(ErrSlowSubscriber)
+		}
+	}
+}
+
+func (f *eventFeed) remove(sub *eventSubscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, sub)
+}
+
+type eventSubscription struct {
+	feed *eventFeed
+	ch   chan<- eth.RollupEvent
+	err  chan error
+	once sync.Once
+}
+
+func (s *eventSubscription) Unsubscribe() {
+	s.feed.remove(s)
+	s.close(nil)
+}
+
+func (s *eventSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *eventSubscription) close(err error) {
+	s.once.Do(func() {
+		if err != nil {
+			s.err <- err
+		}
+		close(s.err)
+	})
+}
+
+type l1BlockRefByNumberFetcher interface {
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+// l1Reorged returns whether the new L1 head reorged out the previous L1 head. A new head that does not build on
+// the previous head directly is checked against the canonical L1 block at the height of the previous head.
+func l1Reorged(ctx context.Context, l1 l1BlockRefByNumberFetcher, prev, newHead eth.L1BlockRef) (bool, error) {
+	switch {
+	case prev == (eth.L1BlockRef{}) || prev.Hash == newHead.Hash:
+		return false, nil
+	case newHead.Number <= prev.Number:
+		return true, nil
+	case newHead.Number == prev.Number+1:
+		return newHead.ParentHash != prev.Hash, nil
+	}
+	canonical, err := l1.L1BlockRefByNumber(ctx, prev.Number)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L1 block %d: %w", prev.Number, err)
+	}
+	return canonical.Hash != prev.Hash, nil
+}
@@ -0,0 +1,83 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/testutils"
+)
+
+func TestEventFeed(t *testing.T) {
+	var feed eventFeed
+	// Sending without subscribers does not block
+	feed.Send(eth.RollupEvent{Kind: eth.UnsafeHeadEvent})
+
+	fast := make(chan eth.RollupEvent, 2)
+	fastSub := feed.Subscribe(fast)
+	slow := make(chan eth.RollupEvent, 1)
+	slowSub := feed.Subscribe(slow)
+
+	feed.Send(eth.RollupEvent{Kind: eth.UnsafeHeadEvent})
+	feed.Send(eth.RollupEvent{Kind: eth.SafeHeadEvent})
+	require.Len(t, fast, 2)
+	require.Len(t, slow, 1)
+
+	// The slow subscriber is dropped instead of blocking the sender
+	require.ErrorIs(t, <-slowSub.Err(), ErrSlowSubscriber)
+	_, ok := <-slowSub.Err()
+	require.False(t, ok, "error channel is closed")
+	<-fast
+	<-fast
+	feed.Send(eth.RollupEvent{Kind: eth.FinalizedHeadEvent})
+	require.Equal(t, eth.FinalizedHeadEvent, (<-fast).Kind)
+	require.Len(t, slow, 1)
+
+	// Unsubscribing closes the error channel without an error
+	fastSub.Unsubscribe()
+	fastSub.Unsubscribe()
+	_, ok = <-fastSub.Err()
+	require.False(t, ok)
+	feed.Send(eth.RollupEvent{Kind: eth.UnsafeHeadEvent})
+	require.Empty(t, fast)
+	slowSub.Unsubscribe()
+}
+
+func TestL1Reorged(t *testing.T) {
+	prev := eth.L1BlockRef{Hash: common.Hash{0xa}, Number: 10}
+	l1 := &testutils.MockL1Source{}
+	ctx := context.Background()
+
+	check := func(newHead eth.L1BlockRef) bool {
+		reorged, err := l1Reorged(ctx, l1, prev, newHead)
+		require.NoError(t, err)
+		return reorged
+	}
+
+	require.False(t, check(prev), "same head")
+	require.False(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 11, ParentHash: prev.Hash}), "child")
+	require.True(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 10}), "same height")
+	require.True(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 9}), "lower height")
+
+	// A higher head that does not build on the previous head
+	require.True(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 11, ParentHash: common.Hash{0xc}}), "child of a sibling")
+
+	// A jump of more than one block is checked against the canonical chain
+	l1.ExpectL1BlockRefByNumber(10, prev, nil)
+	require.False(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 13}), "descendant")
+	l1.ExpectL1BlockRefByNumber(10, eth.L1BlockRef{Hash: common.Hash{0xc}, Number: 10}, nil)
+	require.True(t, check(eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 13}), "reorged")
+	l1.ExpectL1BlockRefByNumber(10, eth.L1BlockRef{}, errors.New("boom"))
+	_, err := l1Reorged(ctx, l1, prev, eth.L1BlockRef{Hash: common.Hash{0xb}, Number: 13})
+	require.Error(t, err)
+	l1.AssertExpectations(t)
+
+	// Without a previous head there is nothing to reorg
+	reorged, err := l1Reorged(ctx, l1, eth.L1BlockRef{}, prev)
+	require.NoError(t, err)
+	require.False(t, reorged)
+}
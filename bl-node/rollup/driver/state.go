@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/BLASTchain/blast/bl-node/rollup"
@@ -55,6 +56,9 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// events is the feed of rollup events, for subscribers of the event stream
+	events eventFeed
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -229,7 +233,12 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
+	// the heads that were last emitted as events
+	var heads eventHeads
+
 	for {
+		s.emitHeadEvents(&heads)
+
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
 		// And avoid sequencing if the derivation pipeline indicates the engine is not ready.
@@ -274,7 +283,15 @@ func (s *Driver) eventLoop() {
 			if s.network != nil && payload != nil {
 				// Publishing of unsafe data via p2p is optional.
 				// Errors are not severe enough to change/halt sequencing but should be logged and metered.
-				if err := s.network.PublishL2Payload(ctx, payload); err != nil {
+				if err := s.network.PublishL2Payload(ctx, payload); errors.Is(err, ErrPayloadNotCommitted) {
+					// The sequencer must not build on top of a block that a standby sequencer may never see.
+					s.log.Error("Stopping sequencer, failed to commit newly created block", "id", payload.ID(), "err", err)
+					s.metrics.RecordPublishingError()
+					if err := s.stopSequencing(ctx); err != nil {
+						s.log.Error("Failed to stop sequencer", "err", err)
+					}
+					continue
+				} else if err != nil {
 					s.log.Warn("failed to publish newly created block", "id", payload.ID(), "err", err)
 					s.metrics.RecordPublishingError()
 				}
@@ -296,6 +313,15 @@ func (s *Driver) eventLoop() {
 			reqStep()
 
 		case newL1Head := <-s.l1HeadSig:
+			prev := s.l1State.L1Head()
+			reorgCtx, cancel := context.WithTimeout(ctx, time.Second*2)
+			reorged, err := l1Reorged(reorgCtx, s.l1, prev, newL1Head)
+			cancel()
+			if err != nil {
+				s.log.Warn("Failed to check for L1 reorg", "old", prev, "new", newL1Head, "err", err)
+			} else if reorged {
+				s.events.Send(eth.RollupEvent{Kind: eth.L1ReorgEvent, L1: &newL1Head, OldL1: &prev})
+			}
 			s.l1State.HandleNewL1HeadBlock(newL1Head)
 			reqStep() // a new L1 head may mean we have the data to not get an EOF again.
 		case newL1Safe := <-s.l1SafeSig:
@@ -328,6 +354,7 @@ func (s *Driver) eventLoop() {
 				s.log.Warn("Derivation pipeline is reset", "err", err)
 				s.derivation.Reset()
 				s.metrics.RecordPipelineReset()
+				s.events.Send(eth.RollupEvent{Kind: eth.PipelineResetEvent, Reason: err.Error()})
 				continue
 			} else if err != nil && errors.Is(err, derive.ErrTemporary) {
 				s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
@@ -354,6 +381,7 @@ func (s *Driver) eventLoop() {
 			s.log.Warn("Derivation pipeline is manually reset")
 			s.derivation.Reset()
 			s.metrics.RecordPipelineReset()
+			s.events.Send(eth.RollupEvent{Kind: eth.PipelineResetEvent, Reason: "manual reset"})
 			close(respCh)
 		case resp := <-s.startSequencer:
 			unsafeHead := s.derivation.UnsafeL2Head().Hash
@@ -369,21 +397,15 @@ func (s *Driver) eventLoop() {
 				s.log.Info("Sequencer has been started")
 				s.driverConfig.SequencerStopped = false
 				close(resp.err)
+				s.emitSequencerEvent(eth.SequencerStartedEvent)
 				planSequencerAction() // resume sequencing
 			}
 		case respCh := <-s.stopSequencer:
 			if s.driverConfig.SequencerStopped {
 				respCh <- hashAndError{err: errors.New("sequencer not running")}
+			} else if err := s.stopSequencing(ctx); err != nil {
+				respCh <- hashAndError{err: err}
 			} else {
-				if err := s.sequencerNotifs.SequencerStopped(); err != nil {
-					respCh <- hashAndError{err: fmt.Errorf("sequencer start notification: %w", err)}
-					continue
-				}
-				s.log.Warn("Sequencer has been stopped")
-				s.driverConfig.SequencerStopped = true
-				// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
-				// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
-				s.sequencer.CancelBuildingBlock(ctx)
 				respCh <- hashAndError{hash: s.derivation.UnsafeL2Head().Hash}
 			}
 		case respCh := <-s.sequencerActive:
@@ -394,6 +416,20 @@ func (s *Driver) eventLoop() {
 	}
 }
 
+// stopSequencing stops the running sequencer, and should only be called synchronously with the driver event loop.
+func (s *Driver) stopSequencing(ctx context.Context) error {
+	if err := s.sequencerNotifs.SequencerStopped(); err != nil {
+		return fmt.Errorf("sequencer stop notification: %w", err)
+	}
+	s.log.Warn("Sequencer has been stopped")
+	s.driverConfig.SequencerStopped = true
+	// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
+	// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
+	s.sequencer.CancelBuildingBlock(ctx)
+	s.emitSequencerEvent(eth.SequencerStoppedEvent)
+	return nil
+}
+
 // ResetDerivationPipeline forces a reset of the derivation pipeline.
 // It waits for the reset to occur. It simply unblocks the caller rather
 // than fully cancelling the reset request upon a context cancellation.
@@ -537,6 +573,49 @@ func (s *Driver) snapshot(event string) {
 		"l2FinalizedHead", deferJSONString{s.derivation.Finalized()})
 }
 
+// SubscribeEvents subscribes to the rollup events of the driver.
+// The driver event loop does not wait for subscribers: a subscriber is dropped with ErrSlowSubscriber
+// when its channel is full, so subscribers must read events promptly.
+func (s *Driver) SubscribeEvents(ch chan<- eth.RollupEvent) event.Subscription {
+	return s.events.Subscribe(ch)
+}
+
+// eventHeads tracks the L2 heads that were last emitted as events.
+type eventHeads struct {
+	unsafe    eth.L2BlockRef
+	safe      eth.L2BlockRef
+	finalized eth.L2BlockRef
+}
+
+// emitHeadEvents emits an event for each of the L2 heads that changed since the last call.
+// Heads that are not initialized yet, e.g. during a pipeline reset, are not emitted.
+func (s *Driver) emitHeadEvents(last *eventHeads) {
+	if head := s.derivation.UnsafeL2Head(); head != last.unsafe {
+		last.unsafe = head
+		if head != (eth.L2BlockRef{}) {
+			s.events.Send(eth.RollupEvent{Kind: eth.UnsafeHeadEvent, L2: &head})
+		}
+	}
+	if head := s.derivation.SafeL2Head(); head != last.safe {
+		last.safe = head
+		if head != (eth.L2BlockRef{}) {
+			origin := s.derivation.Origin()
+			s.events.Send(eth.RollupEvent{Kind: eth.SafeHeadEvent, L2: &head, L1: &origin})
+		}
+	}
+	if head := s.derivation.Finalized(); head != last.finalized {
+		last.finalized = head
+		if head != (eth.L2BlockRef{}) {
+			s.events.Send(eth.RollupEvent{Kind: eth.FinalizedHeadEvent, L2: &head})
+		}
+	}
+}
+
+func (s *Driver) emitSequencerEvent(kind eth.RollupEventKind) {
+	head := s.derivation.UnsafeL2Head()
+	s.events.Send(eth.RollupEvent{Kind: kind, L2: &head})
+}
+
 type hashAndError struct {
 	hash common.Hash
 	err  error
@@ -1,8 +1,16 @@
 package sync
 
+import (
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
 type Config struct {
 	// EngineSync is true when the EngineQueue can trigger execution engine P2P sync.
 	EngineSync bool `json:"engine_sync"`
 	// SkipSyncStartCheck skip the sanity check of consistency of L1 origins of the unsafe L2 blocks when determining the sync-starting point. This defers the L1-origin verification, and is recommended to use in when utilizing l2.engine-sync
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
+	// Checkpoint is a trusted L2 block to start syncing from, verified against L1 by the rollup node.
+	// If the finalized head of the execution engine is before the checkpoint, the checkpoint is used as finalized head,
+	// and the sync-starting point is not searched for before it. Nil if checkpoint sync is disabled.
+	Checkpoint *eth.L2BlockRef `json:"checkpoint,omitempty"`
 }
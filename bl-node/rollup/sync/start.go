@@ -113,6 +113,18 @@ func FindL2Heads(ctx context.Context, cfg *rollup.Config, l1 L1Chain, l2 L2Chain
 	lgr.Info("Loaded current L2 heads", "unsafe", result.Unsafe, "safe", result.Safe, "finalized", result.Finalized,
 		"unsafe_origin", result.Unsafe.L1Origin, "safe_origin", result.Safe.L1Origin)
 
+	// Start from the trusted checkpoint, instead of walking back the L2 chain before it
+	if cp := syncCfg.Checkpoint; cp != nil && result.Finalized.Number < cp.Number {
+		lgr.Info("Starting from trusted checkpoint", "checkpoint", cp, "finalized", result.Finalized)
+		result.Finalized = *cp
+		if result.Safe.Number < cp.Number {
+			result.Safe = *cp
+		}
+		if result.Unsafe.Number < cp.Number {
+			result.Unsafe = *cp
+		}
+	}
+
 	// Remember original unsafe block to determine reorg depth
 	prevUnsafe := result.Unsafe
 
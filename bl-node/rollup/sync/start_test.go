@@ -50,6 +50,7 @@ type syncStartTestCase struct {
 
 	PreFinalizedL2 rune
 	PreSafeL2      rune
+	Checkpoint     rune // trusted L2 checkpoint, disabled if zero
 
 	GenesisL1    rune
 	GenesisL1Num uint64
@@ -76,7 +77,13 @@ func (c *syncStartTestCase) Run(t *testing.T) {
 	}
 	lgr := log.New()
 	lgr.SetHandler(log.DiscardHandler())
-	result, err := FindL2Heads(context.Background(), cfg, chain, chain, lgr, &Config{})
+	syncCfg := &Config{}
+	if c.Checkpoint != 0 {
+		checkpoint, err := chain.L2BlockRefByHash(context.Background(), runeToHash(c.Checkpoint))
+		require.NoError(t, err)
+		syncCfg.Checkpoint = &checkpoint
+	}
+	result, err := FindL2Heads(context.Background(), cfg, chain, chain, lgr, syncCfg)
 	if c.ExpectedErr != nil {
 		require.ErrorIs(t, err, c.ExpectedErr, "expected error")
 		return
@@ -317,6 +324,54 @@ func TestFindSyncStart(t *testing.T) {
 			SeqWindowSize:  1,
 			ExpectedErr:    TooDeepReorgErr,
 		},
+		{
+			Name:           "checkpoint ahead of finalized",
+			GenesisL1Num:   0,
+			L1:             "abcdefgh",
+			L2:             "ABCDEFGH",
+			NewL1:          "abcdefgh",
+			PreFinalizedL2: 'A',
+			PreSafeL2:      'A',
+			Checkpoint:     'F',
+			GenesisL1:      'a',
+			GenesisL2:      'A',
+			UnsafeL2Head:   'H',
+			SeqWindowSize:  2,
+			SafeL2Head:     'F',
+			ExpectedErr:    nil,
+		},
+		{
+			Name:           "checkpoint ahead of unsafe",
+			GenesisL1Num:   0,
+			L1:             "abcdefgh",
+			L2:             "ABCDEFGH",
+			NewL1:          "abcdefgh",
+			PreFinalizedL2: 'A',
+			PreSafeL2:      'A',
+			Checkpoint:     'H',
+			GenesisL1:      'a',
+			GenesisL2:      'A',
+			UnsafeL2Head:   'H',
+			SeqWindowSize:  2,
+			SafeL2Head:     'H',
+			ExpectedErr:    nil,
+		},
+		{
+			Name:           "checkpoint behind finalized",
+			GenesisL1Num:   0,
+			L1:             "abcdefgh",
+			L2:             "ABCDEFGH",
+			NewL1:          "abcdefgh",
+			PreFinalizedL2: 'E',
+			PreSafeL2:      'E',
+			Checkpoint:     'C',
+			GenesisL1:      'a',
+			GenesisL2:      'A',
+			UnsafeL2Head:   'H',
+			SeqWindowSize:  2,
+			SafeL2Head:     'E',
+			ExpectedErr:    nil,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -81,6 +81,11 @@ type Config struct {
 
 	SpanBatchTime *uint64 `json:"span_batch_time,omitempty"`
 
+	// EcotoneTime sets the activation time of the Ecotone network upgrade, which enables EIP-4844 blob data availability.
+	// Batcher data is read from blobs as well as calldata once the L1 origin timestamp >= *EcotoneTime.
+	// Active if EcotoneTime != nil && timestamp >= *EcotoneTime, inactive otherwise.
+	EcotoneTime *uint64 `json:"ecotone_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -260,8 +265,10 @@ func (cfg *Config) Check() error {
 	return nil
 }
 
+// L1Signer returns the signer for L1 transactions. The Cancun signer also accepts
+// all older transaction types, and is required to recover the sender of blob transactions.
 func (c *Config) L1Signer() types.Signer {
-	return types.NewLondonSigner(c.L1ChainID)
+	return types.NewCancunSigner(c.L1ChainID)
 }
 
 // IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
@@ -278,6 +285,11 @@ func (c *Config) IsSpanBatch(timestamp uint64) bool {
 	return c.SpanBatchTime != nil && timestamp >= *c.SpanBatchTime
 }
 
+// IsEcotone returns true if the Ecotone hardfork is active at or past the given timestamp.
+func (c *Config) IsEcotone(timestamp uint64) bool {
+	return c.EcotoneTime != nil && timestamp >= *c.EcotoneTime
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -307,6 +319,7 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
 	banner += fmt.Sprintf("  - Canyon: %s\n", fmtForkTimeOrUnset(c.CanyonTime))
 	banner += fmt.Sprintf("  - SpanBatch: %s\n", fmtForkTimeOrUnset(c.SpanBatchTime))
+	banner += fmt.Sprintf("  - Ecotone: %s\n", fmtForkTimeOrUnset(c.EcotoneTime))
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
 	return banner
@@ -334,6 +347,7 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
 		"canyon_time", fmtForkTimeOrUnset(c.CanyonTime),
 		"span_batch_time", fmtForkTimeOrUnset(c.SpanBatchTime),
+		"ecotone_time", fmtForkTimeOrUnset(c.EcotoneTime),
 	)
 }
 
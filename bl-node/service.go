@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/bl-node/conductor"
 	"github.com/BLASTchain/blast/bl-node/flags"
 	"github.com/BLASTchain/blast/bl-node/node"
 	p2pcli "github.com/BLASTchain/blast/bl-node/p2p/cli"
@@ -50,6 +51,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
 
+	attestationSignerSetup, err := p2pcli.LoadAttestationSignerSetup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attestation signer: %w", err)
+	}
+
 	p2pConfig, err := p2pcli.NewConfig(ctx, rollupConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p config: %w", err)
@@ -64,8 +70,15 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
+	beaconEndpoint := NewBeaconEndpointConfig(ctx)
+
 	syncConfig := NewSyncConfig(ctx)
 
+	checkpointConfig, err := NewCheckpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint config: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -75,6 +88,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
 		L2Sync: l2SyncEndpoint,
+		Beacon: beaconEndpoint,
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
@@ -94,6 +108,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		},
 		P2P:                         p2pConfig,
 		P2PSigner:                   p2pSignerSetup,
+		AttestationSigner:           attestationSignerSetup,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
 		Heartbeat: node.HeartbeatConfig{
@@ -102,9 +117,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
 		ConfigPersistence: configPersistence,
-		Sync:              *syncConfig,
-		RollupHalt:        haltOption,
-		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		Conductor: conductor.Config{
+			Enabled:  ctx.Bool(flags.ConductorEnabledFlag.Name),
+			LockDir:  ctx.String(flags.ConductorLockDirFlag.Name),
+			Interval: ctx.Duration(flags.ConductorIntervalFlag.Name),
+		},
+		Sync:       *syncConfig,
+		RollupHalt: haltOption,
+		RethDBPath: ctx.String(flags.L1RethDBPath.Name),
+		SafeDBPath: ctx.String(flags.SafeDBPath.Name),
+		Checkpoint: *checkpointConfig,
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -117,6 +139,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
+func NewBeaconEndpointConfig(ctx *cli.Context) *node.L1BeaconEndpointConfig {
+	return &node.L1BeaconEndpointConfig{
+		BeaconAddr:          ctx.String(flags.BeaconAddr.Name),
+		BeaconFallbackAddrs: ctx.StringSlice(flags.BeaconFallbackAddrs.Name),
+	}
+}
+
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
@@ -249,3 +278,26 @@ func NewSyncConfig(ctx *cli.Context) *sync.Config {
 		SkipSyncStartCheck: ctx.Bool(flags.SkipSyncStartCheck.Name),
 	}
 }
+
+func NewCheckpointConfig(ctx *cli.Context) (*node.CheckpointConfig, error) {
+	cfg := &node.CheckpointConfig{
+		URL: ctx.String(flags.CheckpointURL.Name),
+	}
+	if v := ctx.String(flags.CheckpointL2Hash.Name); v != "" {
+		if err := cfg.L2Hash.UnmarshalText([]byte(v)); err != nil {
+			return nil, fmt.Errorf("invalid checkpoint L2 block hash: %w", err)
+		}
+	}
+	if v := ctx.String(flags.CheckpointL1Origin.Name); v != "" {
+		if err := cfg.L1Origin.UnmarshalText([]byte(v)); err != nil {
+			return nil, fmt.Errorf("invalid checkpoint L1 origin hash: %w", err)
+		}
+	}
+	if v := ctx.String(flags.CheckpointOutputOracle.Name); v != "" {
+		if !common.IsHexAddress(v) {
+			return nil, fmt.Errorf("invalid L2OutputOracle address: %q", v)
+		}
+		cfg.OutputOracle = common.HexToAddress(v)
+	}
+	return cfg, nil
+}
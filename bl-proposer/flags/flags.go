@@ -32,13 +32,29 @@ var (
 		Usage:   "HTTP provider URL for the rollup node",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+
+	// Optional flags
 	L2OOAddressFlag = &cli.StringFlag{
 		Name:    "l2oo-address",
-		Usage:   "Address of the L2OutputOracle contract",
+		Usage:   "Address of the L2OutputOracle contract. Required unless --game-factory-address is set.",
 		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
-
-	// Optional flags
+	DisputeGameFactoryAddressFlag = &cli.StringFlag{
+		Name:    "game-factory-address",
+		Usage:   "Address of the DisputeGameFactory contract. When set, outputs are proposed by creating dispute games instead of using the L2OutputOracle.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
+	}
+	ProposalIntervalFlag = &cli.DurationFlag{
+		Name:    "proposal-interval",
+		Usage:   "Interval between dispute games created by the proposer. Only used with --game-factory-address.",
+		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
+	}
+	DisputeGameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "Dispute game type to create when proposing outputs. Only used with --game-factory-address.",
+		Value:   0,
+		EnvVars: prefixEnvVars("GAME_TYPE"),
+	}
 	PollIntervalFlag = &cli.DurationFlag{
 		Name:    "poll-interval",
 		Usage:   "How frequently to poll L2 for new blocks",
@@ -57,10 +73,13 @@ var (
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	RollupRpcFlag,
-	L2OOAddressFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	L2OOAddressFlag,
+	DisputeGameFactoryAddressFlag,
+	ProposalIntervalFlag,
+	DisputeGameTypeFlag,
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
 	L2OutputHDPathFlag,
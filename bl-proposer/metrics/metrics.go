@@ -28,6 +28,7 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordDisputeGameCreated()
 }
 
 type Metrics struct {
@@ -41,6 +42,8 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	gamesCreated prometheus.Counter
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -75,6 +78,11 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the bl-proposer has finished starting up",
 		}),
+		gamesCreated: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dispute_games_created",
+			Help:      "Number of dispute games created by the proposer",
+		}),
 	}
 }
 
@@ -113,6 +121,11 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordDisputeGameCreated should be called when a dispute game is created to propose an output
+func (m *Metrics) RecordDisputeGameCreated() {
+	m.gamesCreated.Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -1,6 +1,7 @@
 package proposer
 
 import (
+	"context"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -70,3 +71,71 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, tx.Data())
 }
+
+// TestCreateGameABIPacking ensures that the manual ABI packing of the DisputeGameFactory create call
+// is the same as going through the bound contract.
+func TestCreateGameABIPacking(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}}, 50_000_000)
+	_, _, contract, err := bindings.DeployDisputeGameFactory(opts, backend)
+	require.NoError(t, err)
+	rng := rand.New(rand.NewSource(1234))
+
+	abi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+
+	output := testutils.RandomOutputResponse(rng)
+	gameType := uint8(3)
+	l1BlockNumber := uint64(1234)
+
+	txData, err := createGameTxData(abi, gameType, output, l1BlockNumber)
+	require.NoError(t, err)
+
+	// set a gas limit to disable gas estimation. No implementation is registered for the game type.
+	opts.GasLimit = 100_000
+	extraData := disputeGameExtraData(output.BlockRef.Number, l1BlockNumber)
+	tx, err := contract.Create(opts, gameType, output.OutputRoot, extraData)
+	require.NoError(t, err)
+
+	require.Equal(t, txData, tx.Data())
+	require.Len(t, extraData, 64)
+	require.Equal(t, new(big.Int).SetUint64(output.BlockRef.Number), new(big.Int).SetBytes(extraData[:32]))
+	require.Equal(t, new(big.Int).SetUint64(l1BlockNumber), new(big.Int).SetBytes(extraData[32:]))
+}
+
+// TestCheckpointedBlock ensures the checkpointed L1 block is read from the receipt of a BlockOracle checkpoint.
+func TestCheckpointedBlock(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}}, 50_000_000)
+	addr, _, contract, err := bindings.DeployBlockOracle(opts, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	backend.Commit()
+
+	tx, err := contract.Checkpoint(opts)
+	require.NoError(t, err)
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+
+	abi, err := bindings.BlockOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l1BlockNumber, err := checkpointedBlock(abi, addr, receipt)
+	require.NoError(t, err)
+	require.Equal(t, receipt.BlockNumber.Uint64()-1, l1BlockNumber)
+	info, err := contract.Load(&bind.CallOpts{}, new(big.Int).SetUint64(l1BlockNumber))
+	require.NoError(t, err)
+	require.NotZero(t, info.Hash)
+
+	// the event must be emitted by the BlockOracle
+	_, err = checkpointedBlock(abi, common.Address{0xaa}, receipt)
+	require.ErrorContains(t, err, "no Checkpoint event")
+}
@@ -1,6 +1,8 @@
 package proposer
 
 import (
+	"errors"
+	"math"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,8 +29,21 @@ type Config struct {
 	L1Client           *ethclient.Client
 	RollupClient       *sources.RollupClient
 	AllowNonFinalized  bool
+
+	// DisputeGameFactoryAddr is the address of the DisputeGameFactory. When set, outputs are proposed
+	// by creating dispute games every ProposalInterval instead of using the L2OutputOracle.
+	DisputeGameFactoryAddr common.Address
+	ProposalInterval       time.Duration
+	DisputeGameType        uint8
 }
 
+var (
+	ErrMissingProposalContract = errors.New("either the L2OutputOracle or the DisputeGameFactory address must be set")
+	ErrConflictingContracts    = errors.New("only one of the L2OutputOracle and DisputeGameFactory addresses may be set")
+	ErrMissingProposalInterval = errors.New("proposal interval must be set when using the DisputeGameFactory")
+	ErrInvalidDisputeGameType  = errors.New("dispute game type must fit in a uint8")
+)
+
 // CLIConfig is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is transformed into a `Config` before the L2 output submitter is started.
@@ -44,6 +59,16 @@ type CLIConfig struct {
 	// L2OOAddress is the L2OutputOracle contract address.
 	L2OOAddress string
 
+	// DGFAddress is the DisputeGameFactory contract address.
+	// Exactly one of L2OOAddress and DGFAddress must be set.
+	DGFAddress string
+
+	// ProposalInterval is the delay between creating dispute games.
+	ProposalInterval time.Duration
+
+	// DisputeGameType is the type of dispute game to create.
+	DisputeGameType uint
+
 	// PollInterval is the delay between querying L2 for more transaction
 	// and creating a new batch.
 	PollInterval time.Duration
@@ -64,6 +89,20 @@ type CLIConfig struct {
 }
 
 func (c CLIConfig) Check() error {
+	if c.L2OOAddress == "" && c.DGFAddress == "" {
+		return ErrMissingProposalContract
+	}
+	if c.L2OOAddress != "" && c.DGFAddress != "" {
+		return ErrConflictingContracts
+	}
+	if c.DGFAddress != "" {
+		if c.ProposalInterval == 0 {
+			return ErrMissingProposalInterval
+		}
+		if c.DisputeGameType > math.MaxUint8 {
+			return ErrInvalidDisputeGameType
+		}
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -85,10 +124,13 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		// Required Flags
 		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
 		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
-		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
+		L2OOAddress:       ctx.String(flags.L2OOAddressFlag.Name),
+		DGFAddress:        ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
+		ProposalInterval:  ctx.Duration(flags.ProposalIntervalFlag.Name),
+		DisputeGameType:   ctx.Uint(flags.DisputeGameTypeFlag.Name),
 		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
 		RPCConfig:         oprpc.ReadCLIConfig(ctx),
 		LogConfig:         oplog.ReadCLIConfig(ctx),
@@ -0,0 +1,329 @@
+package proposer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/txmgr"
+)
+
+// ProposedGame is a dispute game created by the proposer.
+type ProposedGame struct {
+	Address       common.Address `json:"address"`
+	GameType      uint8          `json:"gameType"`
+	RootClaim     common.Hash    `json:"rootClaim"`
+	L2BlockNumber uint64         `json:"l2BlockNumber"`
+	L1BlockNumber uint64         `json:"l1BlockNumber"`
+	TxHash        common.Hash    `json:"txHash"`
+}
+
+// gameCheckpoint is the L1 block checkpointed in the BlockOracle to propose the output of an L2 block.
+type gameCheckpoint struct {
+	l2BlockNumber uint64
+	l1BlockNumber uint64
+}
+
+// disputeGameExtraData encodes the extra data of a game proposing the output of the L2 block:
+// the abi encoded L2 block number, followed by the L1 block number checkpointed in the BlockOracle.
+func disputeGameExtraData(l2BlockNumber uint64, l1BlockNumber uint64) []byte {
+	extraData := make([]byte, 64)
+	binary.BigEndian.PutUint64(extraData[24:32], l2BlockNumber)
+	binary.BigEndian.PutUint64(extraData[56:], l1BlockNumber)
+	return extraData
+}
+
+// CreateGameTxData creates the transaction data for the DisputeGameFactory create function
+func (l *L2OutputSubmitter) CreateGameTxData(output *eth.OutputResponse, l1BlockNumber uint64) ([]byte, error) {
+	return createGameTxData(l.dgfABI, l.gameType, output, l1BlockNumber)
+}
+
+// createGameTxData creates the transaction data for the DisputeGameFactory create function
+func createGameTxData(abi *abi.ABI, gameType uint8, output *eth.OutputResponse, l1BlockNumber uint64) ([]byte, error) {
+	return abi.Pack(
+		"create",
+		gameType,
+		output.OutputRoot,
+		disputeGameExtraData(output.BlockRef.Number, l1BlockNumber))
+}
+
+// FetchDGFOutputInfo gets the output to propose in a new dispute game.
+// It returns: the output, if a game should be created, error
+func (l *L2OutputSubmitter) FetchDGFOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	currentBlockNumber, err := l.currentBlockNumber(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if currentBlockNumber <= l.lastProposedBlock() {
+		l.log.Debug("no new L2 blocks to propose", "currentBlockNumber", currentBlockNumber)
+		return nil, false, nil
+	}
+	return l.fetchDGFOutput(ctx, currentBlockNumber)
+}
+
+// currentBlockNumber returns the latest L2 block that may be proposed.
+func (l *L2OutputSubmitter) currentBlockNumber(ctx context.Context) (uint64, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	status, err := l.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.log.Error("proposer unable to get sync status", "err", err)
+		return 0, err
+	}
+
+	// Use either the finalized or safe head depending on the config. Finalized head is default & safer.
+	if l.allowNonFinalized {
+		return status.SafeL2.Number, nil
+	}
+	return status.FinalizedL2.Number, nil
+}
+
+// fetchDGFOutput fetches the output at the block, unless a dispute game already exists for it.
+func (l *L2OutputSubmitter) fetchDGFOutput(ctx context.Context, blockNumber uint64) (*eth.OutputResponse, bool, error) {
+	output, shouldPropose, err := l.fetchOutput(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil || !shouldPropose {
+		return nil, false, err
+	}
+
+	// Only a retried proposal can have created the game already, as the L1 block of the
+	// game is checkpointed for each proposal
+	l1BlockNumber, ok := l.checkpointFor(output.BlockRef.Number)
+	if !ok {
+		return output, true, nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	existing, err := l.dgfContract.Games(&bind.CallOpts{Context: cCtx}, l.gameType, output.OutputRoot, disputeGameExtraData(output.BlockRef.Number, l1BlockNumber))
+	if err != nil {
+		l.log.Error("proposer unable to check for existing game", "err", err)
+		return nil, false, err
+	}
+	if existing.Proxy != (common.Address{}) {
+		l.log.Info("Dispute game already exists for output", "game", existing.Proxy, "l2blocknum", output.BlockRef.Number)
+		l.recordGame(ProposedGame{
+			Address:       existing.Proxy,
+			GameType:      l.gameType,
+			RootClaim:     common.Hash(output.OutputRoot),
+			L2BlockNumber: output.BlockRef.Number,
+			L1BlockNumber: l1BlockNumber,
+		})
+		return nil, false, nil
+	}
+	return output, true, nil
+}
+
+// checkpointFor returns the L1 block checkpointed by an earlier attempt to propose the output of the L2 block.
+func (l *L2OutputSubmitter) checkpointFor(l2BlockNumber uint64) (uint64, bool) {
+	l.gamesLock.Lock()
+	defer l.gamesLock.Unlock()
+	if l.checkpoint == nil || l.checkpoint.l2BlockNumber != l2BlockNumber {
+		return 0, false
+	}
+	return l.checkpoint.l1BlockNumber, true
+}
+
+// checkpointL1Block checkpoints an L1 block in the BlockOracle, for the game proposing the output of the L2 block.
+// The game's L1 head must contain the output, so the block is checkpointed once the output is to be proposed. It
+// is checkpointed once per proposal, and reused if the proposal is retried.
+func (l *L2OutputSubmitter) checkpointL1Block(ctx context.Context, l2BlockNumber uint64) (uint64, error) {
+	if l1BlockNumber, ok := l.checkpointFor(l2BlockNumber); ok {
+		return l1BlockNumber, nil
+	}
+	data, err := l.blockOracleABI.Pack("checkpoint")
+	if err != nil {
+		return 0, err
+	}
+	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &l.blockOracleAddr,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to checkpoint L1 block: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return 0, fmt.Errorf("checkpoint tx %v reverted", receipt.TxHash)
+	}
+	l1BlockNumber, err := checkpointedBlock(l.blockOracleABI, l.blockOracleAddr, receipt)
+	if err != nil {
+		return 0, err
+	}
+	l.log.Info("proposer checkpointed L1 block", "tx_hash", receipt.TxHash, "l1blocknum", l1BlockNumber, "l2blocknum", l2BlockNumber)
+
+	l.gamesLock.Lock()
+	defer l.gamesLock.Unlock()
+	l.checkpoint = &gameCheckpoint{l2BlockNumber: l2BlockNumber, l1BlockNumber: l1BlockNumber}
+	return l1BlockNumber, nil
+}
+
+// checkpointedBlock finds the L1 block checkpointed by the transaction from the Checkpoint event in its receipt.
+func checkpointedBlock(blockOracleABI *abi.ABI, blockOracleAddr common.Address, receipt *types.Receipt) (uint64, error) {
+	event := blockOracleABI.Events["Checkpoint"]
+	for _, entry := range receipt.Logs {
+		if entry.Address != blockOracleAddr || len(entry.Topics) < 2 || entry.Topics[0] != event.ID {
+			continue
+		}
+		return entry.Topics[1].Big().Uint64(), nil
+	}
+	return 0, fmt.Errorf("no Checkpoint event in receipt of tx %v", receipt.TxHash)
+}
+
+// sendCreateGameTransaction creates a dispute game for the output through the underlying transaction manager.
+func (l *L2OutputSubmitter) sendCreateGameTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	l1BlockNumber, err := l.checkpointL1Block(ctx, output.BlockRef.Number)
+	if err != nil {
+		return err
+	}
+	data, err := l.CreateGameTxData(output, l1BlockNumber)
+	if err != nil {
+		return err
+	}
+	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData:      data,
+		To:          &l.dgfContractAddr,
+		GasLimit:    0,
+		OnPublished: l.setPendingTxHash,
+	})
+	if err != nil {
+		return err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		l.log.Error("proposer game creation tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		return nil
+	}
+	game, err := l.createdGame(receipt)
+	if err != nil {
+		return err
+	}
+	game.GameType = l.gameType
+	game.RootClaim = common.Hash(output.OutputRoot)
+	game.L2BlockNumber = output.BlockRef.Number
+	game.L1BlockNumber = l1BlockNumber
+	l.recordGame(game)
+	l.recordProposal(output, receipt.TxHash, &game.Address)
+	l.metr.RecordDisputeGameCreated()
+	l.log.Info("proposer created dispute game",
+		"tx_hash", receipt.TxHash,
+		"game", game.Address,
+		"l2blocknum", game.L2BlockNumber,
+		"l1blocknum", game.L1BlockNumber)
+	return nil
+}
+
+// createdGame finds the game created by the transaction from the DisputeGameCreated event in its receipt.
+func (l *L2OutputSubmitter) createdGame(receipt *types.Receipt) (ProposedGame, error) {
+	event := l.dgfABI.Events["DisputeGameCreated"]
+	for _, entry := range receipt.Logs {
+		if entry.Address != l.dgfContractAddr || len(entry.Topics) < 2 || entry.Topics[0] != event.ID {
+			continue
+		}
+		return ProposedGame{
+			Address: common.BytesToAddress(entry.Topics[1].Bytes()),
+			TxHash:  receipt.TxHash,
+		}, nil
+	}
+	return ProposedGame{}, fmt.Errorf("no DisputeGameCreated event in receipt of tx %v", receipt.TxHash)
+}
+
+func (l *L2OutputSubmitter) recordGame(game ProposedGame) {
+	l.gamesLock.Lock()
+	defer l.gamesLock.Unlock()
+	l.games = append(l.games, game)
+	l.lastProposedL2Block = max(l.lastProposedL2Block, game.L2BlockNumber)
+}
+
+func (l *L2OutputSubmitter) lastProposedBlock() uint64 {
+	l.gamesLock.Lock()
+	defer l.gamesLock.Unlock()
+	return l.lastProposedL2Block
+}
+
+// latestGameL2Block returns the L2 block proposed by the latest game of the game type in the factory,
+// or 0 if there is none.
+func (l *L2OutputSubmitter) latestGameL2Block(ctx context.Context, caller bind.ContractCaller) (uint64, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	count, err := l.dgfContract.GameCount(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	for i := new(big.Int).Sub(count, common.Big1); i.Sign() >= 0; i.Sub(i, common.Big1) {
+		l2BlockNumber, ok, err := l.gameL2Block(ctx, caller, i)
+		if err != nil {
+			return 0, err
+		} else if ok {
+			return l2BlockNumber, nil
+		}
+	}
+	return 0, nil
+}
+
+// gameL2Block returns the L2 block proposed by the game at the index of the factory, if it is of the game type.
+func (l *L2OutputSubmitter) gameL2Block(ctx context.Context, caller bind.ContractCaller, index *big.Int) (uint64, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	game, err := l.dgfContract.GameAtIndex(&bind.CallOpts{Context: cCtx}, index)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch game %v: %w", index, err)
+	}
+	if game.GameType != l.gameType {
+		return 0, false, nil
+	}
+	gameContract, err := bindings.NewFaultDisputeGameCaller(game.Proxy, caller)
+	if err != nil {
+		return 0, false, err
+	}
+	l2BlockNumber, err := gameContract.L2BlockNumber(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch L2 block number of game %v: %w", game.Proxy, err)
+	}
+	return l2BlockNumber.Uint64(), true, nil
+}
+
+// ProposedGames returns the dispute games created by the proposer since it was started.
+func (l *L2OutputSubmitter) ProposedGames() []ProposedGame {
+	l.gamesLock.Lock()
+	defer l.gamesLock.Unlock()
+	return append([]ProposedGame(nil), l.games...)
+}
+
+// loopDGF is responsible for creating dispute games for new outputs every proposal interval, until done is closed
+func (l *L2OutputSubmitter) loopDGF(ctx context.Context, done <-chan struct{}) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.proposalInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			output, shouldPropose, err := l.FetchDGFOutputInfo(ctx)
+			if err != nil {
+				break
+			}
+			if !shouldPropose {
+				break
+			}
+			cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+			if err := l.proposeOutput(cCtx, done, output); err != nil {
+				l.log.Error("Failed to send dispute game creation transaction",
+					"err", err,
+					"l2blocknum", output.BlockRef.Number)
+				cancel()
+				break
+			}
+			cancel()
+
+		case <-done:
+			return
+		}
+	}
+}
@@ -14,12 +14,14 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/BLASTchain/blast/bl-bindings/bindings"
 	"github.com/BLASTchain/blast/bl-proposer/flags"
 	"github.com/BLASTchain/blast/bl-proposer/metrics"
+	"github.com/BLASTchain/blast/bl-proposer/rpc"
 	opservice "github.com/BLASTchain/blast/bl-service"
 	"github.com/BLASTchain/blast/bl-service/dial"
 	"github.com/BLASTchain/blast/bl-service/eth"
@@ -33,6 +35,11 @@ import (
 
 var supportedL2OutputVersion = eth.Bytes32{}
 
+var (
+	ErrProposerNotRunning = errors.New("proposer is not running")
+	ErrCannotPropose      = errors.New("output is not ready to be proposed")
+)
+
 // Main is the entrypoint into the L2 Output Submitter. This method executes the
 // service and blocks until the service exits.
 func Main(version string, cliCtx *cli.Context) error {
@@ -107,7 +114,8 @@ func Main(version string, cliCtx *cli.Context) error {
 	rpcCfg := cfg.RPCConfig
 	server := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, oprpc.WithLogger(l))
 	if rpcCfg.EnableAdmin {
-		server.AddAPI(oprpc.ToGethAdminAPI(oprpc.NewCommonAdminAPI(&m.RPCMetrics, l)))
+		adminAPI := rpc.NewAdminAPI(l2OutputSubmitter, &m.RPCMetrics, l)
+		server.AddAPI(rpc.GetAdminAPI(adminAPI))
 		l.Info("Admin RPC enabled")
 	}
 	if err := server.Start(); err != nil {
@@ -133,6 +141,15 @@ type L2OutputSubmitter struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	mutex   sync.Mutex
+	running bool
+
+	// proposalLock ensures proposals from the loop and the admin API are made one at a time
+	proposalLock    sync.Mutex
+	statusLock      sync.Mutex
+	lastProposal    *rpc.Proposal
+	pendingProposal *rpc.Proposal
+
 	// RollupClient is used to retrieve output roots from
 	rollupClient *sources.RollupClient
 
@@ -140,6 +157,23 @@ type L2OutputSubmitter struct {
 	l2ooContractAddr common.Address
 	l2ooABI          *abi.ABI
 
+	// Set when outputs are proposed by creating dispute games instead of using the L2OutputOracle.
+	dgfContract      *bindings.DisputeGameFactoryCaller
+	dgfContractAddr  common.Address
+	dgfABI           *abi.ABI
+	gameType         uint8
+	proposalInterval time.Duration
+	// The L1 block of a game must be checkpointed in the BlockOracle of the game implementation
+	blockOracleAddr common.Address
+	blockOracleABI  *abi.ABI
+
+	gamesLock sync.Mutex
+	games     []ProposedGame
+	// lastProposedL2Block is the L2 block proposed by the latest game of the game type in the factory
+	lastProposedL2Block uint64
+	// checkpoint is the L1 block checkpointed for the latest proposal, reused if the proposal is retried
+	checkpoint *gameCheckpoint
+
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
@@ -161,7 +195,13 @@ func NewL2OutputSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Me
 
 // NewL2OutputSubmitterConfigFromCLIConfig creates the proposer config from the CLI config.
 func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
-	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
+	var l2ooAddress, dgfAddress common.Address
+	var err error
+	if cfg.DGFAddress != "" {
+		dgfAddress, err = opservice.ParseAddress(cfg.DGFAddress)
+	} else {
+		l2ooAddress, err = opservice.ParseAddress(cfg.L2OOAddress)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -190,17 +230,24 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		RollupClient:       rollupClient,
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 		TxManager:          txManager,
+
+		DisputeGameFactoryAddr: dgfAddress,
+		ProposalInterval:       cfg.ProposalInterval,
+		DisputeGameType:        uint8(cfg.DisputeGameType),
 	}, nil
 
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
+	if cfg.DisputeGameFactoryAddr != (common.Address{}) {
+		return newDGFOutputSubmitter(cfg, l, m)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
 	if err != nil {
-		cancel()
 		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", cfg.L2OutputOracleAddr, err)
 	}
 
@@ -208,24 +255,19 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 	defer cCancel()
 	version, err := l2ooContract.Version(&bind.CallOpts{Context: cCtx})
 	if err != nil {
-		cancel()
 		return nil, err
 	}
 	log.Info("Connected to L2OutputOracle", "address", cfg.L2OutputOracleAddr, "version", version)
 
 	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
 	if err != nil {
-		cancel()
 		return nil, err
 	}
 
 	return &L2OutputSubmitter{
-		txMgr:  cfg.TxManager,
-		done:   make(chan struct{}),
-		log:    l,
-		ctx:    ctx,
-		cancel: cancel,
-		metr:   m,
+		txMgr: cfg.TxManager,
+		log:   l,
+		metr:  m,
 
 		rollupClient: cfg.RollupClient,
 
@@ -239,16 +281,254 @@ func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2Outp
 	}, nil
 }
 
+// newDGFOutputSubmitter creates a new L2 Output Submitter that proposes outputs by creating dispute games
+func newDGFOutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(cfg.DisputeGameFactoryAddr, cfg.L1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DisputeGameFactory at address %s: %w", cfg.DisputeGameFactoryAddr, err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	version, err := dgfContract.Version(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return nil, err
+	}
+	impl, err := dgfContract.GameImpls(&bind.CallOpts{Context: cCtx}, cfg.DisputeGameType)
+	if err != nil {
+		return nil, err
+	}
+	if impl == (common.Address{}) {
+		return nil, fmt.Errorf("no implementation for dispute game type %d in DisputeGameFactory at address %s", cfg.DisputeGameType, cfg.DisputeGameFactoryAddr)
+	}
+	gameImpl, err := bindings.NewFaultDisputeGameCaller(impl, cfg.L1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create FaultDisputeGame at address %s: %w", impl, err)
+	}
+	blockOracle, err := gameImpl.BLOCKORACLE(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the BlockOracle of the game implementation: %w", err)
+	}
+	l.Info("Connected to DisputeGameFactory", "address", cfg.DisputeGameFactoryAddr, "version", version, "gameType", cfg.DisputeGameType, "impl", impl, "blockOracle", blockOracle)
+
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	blockOracleABI, err := bindings.BlockOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	submitter := &L2OutputSubmitter{
+		txMgr: cfg.TxManager,
+		log:   l,
+		metr:  m,
+
+		rollupClient: cfg.RollupClient,
+
+		dgfContract:      dgfContract,
+		dgfContractAddr:  cfg.DisputeGameFactoryAddr,
+		dgfABI:           parsed,
+		gameType:         cfg.DisputeGameType,
+		proposalInterval: cfg.ProposalInterval,
+		blockOracleAddr:  blockOracle,
+		blockOracleABI:   blockOracleABI,
+
+		allowNonFinalized: cfg.AllowNonFinalized,
+		pollInterval:      cfg.PollInterval,
+		networkTimeout:    cfg.NetworkTimeout,
+	}
+
+	// Resume proposing after the latest game, which may have been created before a restart
+	submitter.lastProposedL2Block, err = submitter.latestGameL2Block(ctx, cfg.L1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the latest dispute game: %w", err)
+	}
+	l.Info("Loaded the latest dispute game", "l2blocknum", submitter.lastProposedL2Block)
+	return submitter, nil
+}
+
 func (l *L2OutputSubmitter) Start() error {
+	return l.StartL2OutputSubmitting()
+}
+
+// Stop stops the proposer loop if it is running.
+func (l *L2OutputSubmitter) Stop() {
+	if err := l.StopL2OutputSubmitting(); err != nil && !errors.Is(err, ErrProposerNotRunning) {
+		l.log.Error("Failed to stop L2 Output Submitter", "err", err)
+	}
+}
+
+func (l *L2OutputSubmitter) StartL2OutputSubmitting() error {
+	l.log.Info("Starting Proposer")
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.running {
+		return errors.New("proposer is already running")
+	}
+	l.running = true
+
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+	l.done = make(chan struct{})
+
+	// The loop is handed its context and done channel, as they are replaced on restart
 	l.wg.Add(1)
-	go l.loop()
+	if l.dgfContract != nil {
+		go l.loopDGF(l.ctx, l.done)
+	} else {
+		go l.loop(l.ctx, l.done)
+	}
+
+	l.log.Info("Proposer started")
 	return nil
 }
 
-func (l *L2OutputSubmitter) Stop() {
+// StopL2OutputSubmitting stops the proposer loop, cancelling any proposal that is in progress.
+func (l *L2OutputSubmitter) StopL2OutputSubmitting() error {
+	l.log.Info("Stopping Proposer")
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.running {
+		return ErrProposerNotRunning
+	}
+	l.running = false
+
 	l.cancel()
 	close(l.done)
 	l.wg.Wait()
+
+	l.log.Info("Proposer stopped")
+	return nil
+}
+
+// ProposeAtBlock proposes the output at the given L2 block number immediately, regardless of the proposal schedule.
+// The block must be finalized, or safe if non-finalized proposals are allowed.
+// When proposing to the L2OutputOracle, the oracle only accepts the output at its next block number.
+// The proposal is independent of the proposer loop, and can be made while the proposer is stopped.
+func (l *L2OutputSubmitter) ProposeAtBlock(ctx context.Context, blockNumber uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	var output *eth.OutputResponse
+	var shouldPropose bool
+	var err error
+	if l.dgfContract != nil {
+		output, shouldPropose, err = l.fetchDGFOutput(ctx, blockNumber)
+	} else {
+		output, shouldPropose, err = l.fetchOutput(ctx, new(big.Int).SetUint64(blockNumber))
+	}
+	if err != nil {
+		return err
+	}
+	if !shouldPropose {
+		return fmt.Errorf("%w: %d", ErrCannotPropose, blockNumber)
+	}
+	return l.proposeOutput(ctx, ctx.Done(), output)
+}
+
+// Status returns the state of the proposer, including the last and pending proposals.
+func (l *L2OutputSubmitter) Status(ctx context.Context) (*rpc.ProposerStatus, error) {
+	next, err := l.nextBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	return &rpc.ProposerStatus{
+		Running:         running,
+		LastProposal:    l.lastProposal,
+		PendingProposal: l.pendingProposal,
+		NextBlockNumber: hexutil.Uint64(next),
+	}, nil
+}
+
+// nextBlockNumber returns the L2 block number the next output will be proposed for.
+func (l *L2OutputSubmitter) nextBlockNumber(ctx context.Context) (uint64, error) {
+	if l.dgfContract != nil {
+		current, err := l.currentBlockNumber(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return max(current, l.lastProposedBlock()+1), nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	next, err := l.l2ooContract.NextBlockNumber(&bind.CallOpts{From: l.txMgr.From(), Context: cCtx})
+	if err != nil {
+		return 0, err
+	}
+	return next.Uint64(), nil
+}
+
+// proposeOutput sends the proposal for the output, until done is closed. Proposals are made one at a time.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, done <-chan struct{}, output *eth.OutputResponse) error {
+	l.proposalLock.Lock()
+	defer l.proposalLock.Unlock()
+
+	l.statusLock.Lock()
+	l.pendingProposal = newProposal(output)
+	l.statusLock.Unlock()
+	defer func() {
+		l.statusLock.Lock()
+		l.pendingProposal = nil
+		l.statusLock.Unlock()
+	}()
+
+	var err error
+	if l.dgfContract != nil {
+		err = l.sendCreateGameTransaction(ctx, output)
+	} else {
+		err = l.sendTransaction(ctx, done, output)
+	}
+	if err != nil {
+		return err
+	}
+	l.metr.RecordL2BlocksProposed(output.BlockRef)
+	return nil
+}
+
+// recordProposal records the output as the last proposal, after the proposal transaction succeeded.
+func (l *L2OutputSubmitter) recordProposal(output *eth.OutputResponse, txHash common.Hash, game *common.Address) {
+	proposal := newProposal(output)
+	proposal.TxHash = &txHash
+	proposal.Game = game
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	l.lastProposal = proposal
+}
+
+// setPendingTxHash records the hash of the transaction in flight for the pending proposal.
+func (l *L2OutputSubmitter) setPendingTxHash(txHash common.Hash) {
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	if l.pendingProposal == nil {
+		return
+	}
+	// Copy the proposal, as status calls may still hold the previous one.
+	proposal := *l.pendingProposal
+	proposal.TxHash = &txHash
+	l.pendingProposal = &proposal
+}
+
+func newProposal(output *eth.OutputResponse) *rpc.Proposal {
+	return &rpc.Proposal{
+		L2Block:    output.BlockRef.ID(),
+		OutputRoot: output.OutputRoot,
+		L1Block:    output.Status.CurrentL1.ID(),
+		Time:       time.Now(),
+	}
 }
 
 // FetchNextOutputInfo gets the block number of the next proposal.
@@ -340,7 +620,7 @@ func proposeL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, er
 // "pending" instead of committed. In the case l1blocknum == l1head then, blockhash(l1blocknum)
 // will produce a value of 0 within EstimateGas, and the call will fail when the contract checks
 // that l1blockhash matches blockhash(l1blocknum).
-func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64) error {
+func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, done <-chan struct{}, blockNum uint64) error {
 	ticker := time.NewTicker(l.pollInterval)
 	defer ticker.Stop()
 	l1head, err := l.txMgr.BlockNumber(ctx)
@@ -356,7 +636,9 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 				return err
 			}
 			break
-		case <-l.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
 			return fmt.Errorf("L2OutputSubmitter is done()")
 		}
 	}
@@ -364,8 +646,8 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 }
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
-func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
-	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
+func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, done <-chan struct{}, output *eth.OutputResponse) error {
+	err := l.waitForL1Head(ctx, done, output.Status.HeadL1.Number+1)
 	if err != nil {
 		return err
 	}
@@ -374,9 +656,10 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 		return err
 	}
 	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
-		TxData:   data,
-		To:       &l.l2ooContractAddr,
-		GasLimit: 0,
+		TxData:      data,
+		To:          &l.l2ooContractAddr,
+		GasLimit:    0,
+		OnPublished: l.setPendingTxHash,
 	})
 	if err != nil {
 		return err
@@ -388,16 +671,15 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			"tx_hash", receipt.TxHash,
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash)
+		l.recordProposal(output, receipt.TxHash, nil)
 	}
 	return nil
 }
 
-// loop is responsible for creating & submitting the next outputs
-func (l *L2OutputSubmitter) loop() {
+// loop is responsible for creating & submitting the next outputs, until done is closed
+func (l *L2OutputSubmitter) loop(ctx context.Context, done <-chan struct{}) {
 	defer l.wg.Done()
 
-	ctx := l.ctx
-
 	ticker := time.NewTicker(l.pollInterval)
 	defer ticker.Stop()
 	for {
@@ -411,7 +693,7 @@ func (l *L2OutputSubmitter) loop() {
 				break
 			}
 			cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-			if err := l.sendTransaction(cCtx, output); err != nil {
+			if err := l.proposeOutput(cCtx, done, output); err != nil {
 				l.log.Error("Failed to send proposal transaction",
 					"err", err,
 					"l1blocknum", output.Status.CurrentL1.Number,
@@ -420,10 +702,9 @@ func (l *L2OutputSubmitter) loop() {
 				cancel()
 				break
 			}
-			l.metr.RecordL2BlocksProposed(output.BlockRef)
 			cancel()
 
-		case <-l.done:
+		case <-done:
 			return
 		}
 	}
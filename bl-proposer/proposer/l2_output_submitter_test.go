@@ -0,0 +1,91 @@
+package proposer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/bl-proposer/metrics"
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/sources"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/bl-service/txmgr"
+	txmgrmocks "github.com/BLASTchain/blast/bl-service/txmgr/mocks"
+)
+
+// stubRollupRPC serves the outputs of a rollup node, with all blocks finalized
+type stubRollupRPC struct {
+	headL1 uint64
+}
+
+func (s *stubRollupRPC) Close() {}
+
+func (s *stubRollupRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	if method != "optimism_outputAtBlock" {
+		return ethereum.NotFound
+	}
+	blockNum := uint64(args[0].(hexutil.Uint64))
+	*result.(**eth.OutputResponse) = &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: blockNum},
+		Status: &eth.SyncStatus{
+			HeadL1:      eth.L1BlockRef{Number: s.headL1},
+			FinalizedL2: eth.L2BlockRef{Number: blockNum},
+		},
+	}
+	return nil
+}
+
+func (s *stubRollupRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	return ethereum.NotFound
+}
+
+func (s *stubRollupRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, ethereum.NotFound
+}
+
+func TestProposeAtBlockWhenStopped(t *testing.T) {
+	txMgr := txmgrmocks.NewTxManager(t)
+	l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	l := &L2OutputSubmitter{
+		txMgr:            txMgr,
+		log:              testlog.Logger(t, log.LvlInfo),
+		metr:             metrics.NoopMetrics,
+		rollupClient:     sources.NewRollupClient(&stubRollupRPC{headL1: 10}),
+		l2ooContractAddr: common.Address{0xaa},
+		l2ooABI:          l2ooABI,
+		// The loop must not tick before it is stopped
+		pollInterval:   time.Hour,
+		networkTimeout: time.Second,
+	}
+	require.NoError(t, l.StartL2OutputSubmitting())
+	require.NoError(t, l.StopL2OutputSubmitting())
+
+	txMgr.On("BlockNumber", mock.Anything).Return(uint64(12), nil)
+	txMgr.On("Send", mock.Anything, mock.MatchedBy(func(candidate txmgr.TxCandidate) bool {
+		return candidate.To != nil && *candidate.To == l.l2ooContractAddr
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(txmgr.TxCandidate).OnPublished(common.Hash{0xbb})
+		pending := l.pendingProposal
+		require.NotNil(t, pending)
+		require.Equal(t, common.Hash{0xbb}, *pending.TxHash)
+	}).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: common.Hash{0xbb}}, nil).Once()
+
+	require.NoError(t, l.ProposeAtBlock(context.Background(), 100))
+
+	require.NotNil(t, l.lastProposal)
+	require.Equal(t, uint64(100), l.lastProposal.L2Block.Number)
+	require.Equal(t, common.Hash{0xbb}, *l.lastProposal.TxHash)
+	require.Nil(t, l.pendingProposal)
+}
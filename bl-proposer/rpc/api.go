@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/metrics"
+	"github.com/BLASTchain/blast/bl-service/rpc"
+)
+
+// Proposal is an output proposed, or being proposed, by the proposer.
+type Proposal struct {
+	L2Block    eth.BlockID `json:"l2Block"`
+	OutputRoot eth.Bytes32 `json:"outputRoot"`
+	L1Block    eth.BlockID `json:"l1Block"`
+	// TxHash is the hash of the proposal transaction. While the proposal is pending, it is the hash of the
+	// transaction currently in flight, which changes when fees are bumped, and is unset until one is published.
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// Game is the dispute game created for the proposal, when proposing via the DisputeGameFactory.
+	Game *common.Address `json:"game,omitempty"`
+	Time time.Time       `json:"time"`
+}
+
+type ProposerStatus struct {
+	Running bool `json:"running"`
+	// LastProposal is the last output successfully proposed since the proposer was started.
+	LastProposal *Proposal `json:"lastProposal"`
+	// PendingProposal is the output currently being proposed.
+	PendingProposal *Proposal `json:"pendingProposal"`
+	// NextBlockNumber is the L2 block number the next output will be proposed for.
+	NextBlockNumber hexutil.Uint64 `json:"nextBlockNumber"`
+}
+
+type ProposerDriver interface {
+	StartL2OutputSubmitting() error
+	StopL2OutputSubmitting() error
+	ProposeAtBlock(ctx context.Context, blockNumber uint64) error
+	Status(ctx context.Context) (*ProposerStatus, error)
+}
+
+type adminAPI struct {
+	*rpc.CommonAdminAPI
+	p ProposerDriver
+}
+
+func NewAdminAPI(dr ProposerDriver, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+	return &adminAPI{
+		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		p:              dr,
+	}
+}
+
+func GetAdminAPI(api *adminAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "admin",
+		Service:   api,
+	}
+}
+
+func (a *adminAPI) StartProposer(_ context.Context) error {
+	return a.p.StartL2OutputSubmitting()
+}
+
+func (a *adminAPI) StopProposer(_ context.Context) error {
+	return a.p.StopL2OutputSubmitting()
+}
+
+// ProposeAtBlock immediately proposes the output at the given L2 block number,
+// regardless of the proposal schedule, and whether the proposer is running. The block must be finalized,
+// or safe if non-finalized proposals are allowed.
+func (a *adminAPI) ProposeAtBlock(ctx context.Context, blockNumber hexutil.Uint64) error {
+	return a.p.ProposeAtBlock(ctx, uint64(blockNumber))
+}
+
+func (a *adminAPI) ProposerStatus(ctx context.Context) (*ProposerStatus, error) {
+	return a.p.Status(ctx)
+}
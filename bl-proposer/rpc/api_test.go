@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/eth"
+	"github.com/BLASTchain/blast/bl-service/metrics"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+)
+
+type mockDriver struct {
+	running  bool
+	proposed []uint64
+	status   ProposerStatus
+}
+
+func (m *mockDriver) StartL2OutputSubmitting() error {
+	m.running = true
+	return nil
+}
+
+func (m *mockDriver) StopL2OutputSubmitting() error {
+	m.running = false
+	return nil
+}
+
+func (m *mockDriver) ProposeAtBlock(_ context.Context, blockNumber uint64) error {
+	m.proposed = append(m.proposed, blockNumber)
+	return nil
+}
+
+func (m *mockDriver) Status(_ context.Context) (*ProposerStatus, error) {
+	status := m.status
+	status.Running = m.running
+	return &status, nil
+}
+
+func TestAdminAPI(t *testing.T) {
+	txHash := common.Hash{0xaa}
+	driver := &mockDriver{
+		status: ProposerStatus{
+			LastProposal: &Proposal{
+				L2Block:    eth.BlockID{Hash: common.Hash{0x01}, Number: 10},
+				OutputRoot: eth.Bytes32{0x02},
+				L1Block:    eth.BlockID{Hash: common.Hash{0x03}, Number: 5},
+				TxHash:     &txHash,
+			},
+			NextBlockNumber: 20,
+		},
+	}
+	srv := gethrpc.NewServer()
+	api := GetAdminAPI(NewAdminAPI(driver, &metrics.NoopRPCMetrics{}, testlog.Logger(t, log.LvlError)))
+	require.NoError(t, srv.RegisterName(api.Namespace, api.Service))
+	client := gethrpc.DialInProc(srv)
+	defer client.Close()
+	ctx := context.Background()
+
+	require.NoError(t, client.CallContext(ctx, nil, "admin_startProposer"))
+	require.True(t, driver.running)
+
+	require.NoError(t, client.CallContext(ctx, nil, "admin_proposeAtBlock", hexutil.Uint64(15)))
+	require.Equal(t, []uint64{15}, driver.proposed)
+
+	var status ProposerStatus
+	require.NoError(t, client.CallContext(ctx, &status, "admin_proposerStatus"))
+	require.True(t, status.Running)
+	require.Equal(t, hexutil.Uint64(20), status.NextBlockNumber)
+	require.Nil(t, status.PendingProposal)
+	require.Equal(t, driver.status.LastProposal.L2Block, status.LastProposal.L2Block)
+	require.Equal(t, driver.status.LastProposal.OutputRoot, status.LastProposal.OutputRoot)
+	require.Equal(t, txHash, *status.LastProposal.TxHash)
+
+	require.NoError(t, client.CallContext(ctx, nil, "admin_stopProposer"))
+	require.False(t, driver.running)
+}
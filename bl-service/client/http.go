@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HTTP is a minimal interface for plain (non JSON-RPC) HTTP APIs, such as the Beacon-node REST API.
+type HTTP interface {
+	Get(ctx context.Context, path string, query url.Values, headers http.Header) (*http.Response, error)
+}
+
+// BasicHTTPClient performs GET requests against a fixed base endpoint.
+type BasicHTTPClient struct {
+	endpoint string
+	log      log.Logger
+	client   *http.Client
+}
+
+var _ HTTP = (*BasicHTTPClient)(nil)
+
+// NewBasicHTTPClient creates a client for the given endpoint. A trailing slash is added to the endpoint
+// if missing, so request paths are resolved relative to it.
+func NewBasicHTTPClient(endpoint string, log log.Logger) *BasicHTTPClient {
+	// Make sure the endpoint ends in trailing slash
+	trimmedEndpoint := strings.TrimSuffix(endpoint, "/") + "/"
+	return &BasicHTTPClient{
+		endpoint: trimmedEndpoint,
+		log:      log,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (cl *BasicHTTPClient) Get(ctx context.Context, p string, query url.Values, headers http.Header) (*http.Response, error) {
+	u, err := url.JoinPath(cl.endpoint, p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to join path", err)
+	}
+	target, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url", err)
+	}
+	target.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to construct request", err)
+	}
+	for k, vs := range headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	return cl.client.Do(req)
+}
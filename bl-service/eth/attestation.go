@@ -0,0 +1,31 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SafeHeadAttestation is a claim by a node operator of the safe and finalized L2 heads that
+// were derived from the L1 chain, up to and including the L1 block.
+type SafeHeadAttestation struct {
+	L1          BlockID `json:"l1"`
+	SafeL2      BlockID `json:"safe_l2"`
+	FinalizedL2 BlockID `json:"finalized_l2"`
+	// Timestamp is the unix time in seconds at which the attestation was made.
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// SafeHeadDivergence compares the latest attestation of a trusted attester with the local chain.
+type SafeHeadDivergence struct {
+	Attester    common.Address      `json:"attester"`
+	Attestation SafeHeadAttestation `json:"attestation"`
+	// LocalSafeL2 is the local safe L2 head.
+	LocalSafeL2 BlockID `json:"local_safe_l2"`
+	// LocalL2 is the local L2 block at the height of the attested safe L2 head.
+	// It is zeroed if the local chain does not reach that height yet.
+	LocalL2 BlockID `json:"local_l2"`
+	// SafeLag is the number of blocks the local safe L2 head is behind the attested safe L2 head.
+	// It is negative if the local safe L2 head is ahead.
+	SafeLag int64 `json:"safe_lag"`
+	// Diverged is true if the attested safe or finalized L2 head conflicts with the local chain.
+	Diverged bool `json:"diverged"`
+}
@@ -2,6 +2,8 @@ package eth
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -16,6 +18,12 @@ const (
 	MaxBlobDataSize = 4096*31 - 4
 )
 
+var (
+	ErrBlobInvalidFieldElement = errors.New("invalid field element")
+	ErrBlobInvalidLength       = errors.New("invalid blob data length")
+	ErrBlobInputTooLarge       = errors.New("too much data to encode in one blob")
+)
+
 type Blob [BlobSize]byte
 
 func (b *Blob) KZGBlob() *kzg4844.Blob {
@@ -66,3 +74,51 @@ func KZGToVersionedHash(commitment kzg4844.Commitment) (out common.Hash) {
 func VerifyBlobProof(blob *Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
 	return kzg4844.VerifyBlobProof(*blob.KZGBlob(), commitment, proof)
 }
+
+// FromData encodes the given input data into this blob. The encoding is as follows:
+// the most significant byte of each 32-byte field element is left 0, to keep it in the BLS12-381 scalar field.
+// The remaining 31 bytes of the first field element start with a 4-byte little-endian length prefix,
+// followed by the first 27 bytes of data. Each subsequent field element holds the next (up to) 31 bytes of data.
+// Unused trailing bytes are left zeroed.
+func (b *Blob) FromData(data Data) error {
+	if len(data) > MaxBlobDataSize {
+		return fmt.Errorf("%w: len=%v", ErrBlobInputTooLarge, len(data))
+	}
+	b.Clear()
+	// encode 4-byte little-endian length value into topmost 4 bytes (out of 31) of first field element
+	binary.LittleEndian.PutUint32(b[1:5], uint32(len(data)))
+	// encode first 27 bytes of input data into remaining bytes of first field element
+	offset := copy(b[5:32], data)
+	// encode (up to) 31 bytes of remaining input data at a time into the subsequent field elements
+	for i := 1; i < 4096 && offset < len(data); i++ {
+		offset += copy(b[i*32+1:i*32+32], data[offset:])
+	}
+	if offset < len(data) {
+		return fmt.Errorf("%w: bytes remaining: %v", ErrBlobInputTooLarge, len(data)-offset)
+	}
+	return nil
+}
+
+// ToData decodes the blob into raw byte data. See FromData above for details on the encoding format.
+func (b *Blob) ToData() (Data, error) {
+	data := make(Data, 4096*31)
+	for i := 0; i < 4096; i++ {
+		if b[i*32] != 0 {
+			return nil, fmt.Errorf("%w: non-zero high order byte %x of field element %d", ErrBlobInvalidFieldElement, b[i*32], i)
+		}
+		copy(data[i*31:i*31+31], b[i*32+1:i*32+32])
+	}
+	// extract the length prefix & trim the output accordingly
+	dataLen := binary.LittleEndian.Uint32(data[:4])
+	data = data[4:]
+	if dataLen > uint32(len(data)) {
+		return nil, fmt.Errorf("%w: length prefix out of range: %d", ErrBlobInvalidLength, dataLen)
+	}
+	return data[:dataLen], nil
+}
+
+func (b *Blob) Clear() {
+	for i := 0; i < BlobSize; i++ {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,46 @@
+package eth
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	for _, size := range []int{0, 1, 27, 28, 31, 32, 1000, MaxBlobDataSize} {
+		data := make(Data, size)
+		rng.Read(data)
+
+		var b Blob
+		require.NoError(t, b.FromData(data))
+		decoded, err := b.ToData()
+		require.NoError(t, err)
+		require.Equal(t, data, decoded, "size %d", size)
+	}
+}
+
+func TestBlobTooLarge(t *testing.T) {
+	var b Blob
+	err := b.FromData(make(Data, MaxBlobDataSize+1))
+	require.ErrorIs(t, err, ErrBlobInputTooLarge)
+}
+
+func TestBlobInvalidFieldElement(t *testing.T) {
+	var b Blob
+	require.NoError(t, b.FromData(Data("hello")))
+	b[32*10] = 1
+	_, err := b.ToData()
+	require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+}
+
+func TestBlobInvalidLength(t *testing.T) {
+	var b Blob
+	b[1] = 0xff
+	b[2] = 0xff
+	b[3] = 0xff
+	b[4] = 0xff
+	_, err := b.ToData()
+	require.ErrorIs(t, err, ErrBlobInvalidLength)
+}
@@ -1,5 +1,14 @@
 package eth
 
+import "github.com/ethereum/go-ethereum/common"
+
+// IndexedBlobHash represents a blob hash that commits to a single blob confirmed in a block.
+// The index helps us avoid unnecessary blob to blob hash conversions to find the right content in a sidecar.
+type IndexedBlobHash struct {
+	Index uint64      // absolute index in the block, a.k.a. position in sidecar blobs array
+	Hash  common.Hash // hash of the blob, used for consistency checks
+}
+
 type BlobSidecar struct {
 	BlockRoot     Bytes32      `json:"block_root"`
 	Slot          Uint64String `json:"slot"`
@@ -13,6 +22,24 @@ type APIGetBlobSidecarsResponse struct {
 	Data []*BlobSidecar `json:"data"`
 }
 
+type BeaconBlockHeaderMessage struct {
+	Slot Uint64String `json:"slot"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Message BeaconBlockHeaderMessage `json:"message"`
+}
+
+type BeaconBlockHeaderData struct {
+	Root      Bytes32                 `json:"root"`
+	Canonical bool                    `json:"canonical"`
+	Header    SignedBeaconBlockHeader `json:"header"`
+}
+
+type APIBeaconBlockHeaderResponse struct {
+	Data BeaconBlockHeaderData `json:"data"`
+}
+
 type ReducedGenesisData struct {
 	GenesisTime Uint64String `json:"genesis_time"`
 }
@@ -28,3 +55,11 @@ type ReducedConfigData struct {
 type APIConfigResponse struct {
 	Data ReducedConfigData `json:"data"`
 }
+
+type APIVersionResponse struct {
+	Data VersionInformation `json:"data"`
+}
+
+type VersionInformation struct {
+	ProductVersion string `json:"version"`
+}
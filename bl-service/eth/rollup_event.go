@@ -0,0 +1,36 @@
+package eth
+
+// RollupEventKind identifies the type of a RollupEvent.
+type RollupEventKind string
+
+const (
+	// UnsafeHeadEvent is emitted when the unsafe L2 head changes.
+	UnsafeHeadEvent RollupEventKind = "unsafe_head"
+	// SafeHeadEvent is emitted when the safe L2 head changes.
+	// The L1 block is the L1 block the safe head was derived from.
+	SafeHeadEvent RollupEventKind = "safe_head"
+	// FinalizedHeadEvent is emitted when the finalized L2 head changes.
+	FinalizedHeadEvent RollupEventKind = "finalized_head"
+	// L1ReorgEvent is emitted when a new L1 head is not a descendant of the previous L1 head.
+	L1ReorgEvent RollupEventKind = "l1_reorg"
+	// PipelineResetEvent is emitted when the derivation pipeline is reset.
+	PipelineResetEvent RollupEventKind = "pipeline_reset"
+	// SequencerStartedEvent is emitted when the sequencer is started, at the given L2 head.
+	SequencerStartedEvent RollupEventKind = "sequencer_started"
+	// SequencerStoppedEvent is emitted when the sequencer is stopped, at the given L2 head.
+	SequencerStoppedEvent RollupEventKind = "sequencer_stopped"
+)
+
+// RollupEvent is a change of the rollup-node state, as delivered to event stream subscribers.
+// Depending on the Kind, only some of the fields are set.
+type RollupEvent struct {
+	Kind RollupEventKind `json:"kind"`
+	// L2 is the new L2 head, for head and sequencer events.
+	L2 *L2BlockRef `json:"l2,omitempty"`
+	// L1 is the L1 origin of the derivation for safe head events, and the new L1 head for L1 reorg events.
+	L1 *L1BlockRef `json:"l1,omitempty"`
+	// OldL1 is the replaced L1 head, for L1 reorg events.
+	OldL1 *L1BlockRef `json:"old_l1,omitempty"`
+	// Reason describes why the derivation pipeline was reset, for pipeline reset events.
+	Reason string `json:"reason,omitempty"`
+}
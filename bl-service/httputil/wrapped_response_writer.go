@@ -36,3 +36,8 @@ func (w *WrappedResponseWriter) WriteHeader(statusCode int) {
 	w.StatusCode = statusCode
 	w.w.WriteHeader(statusCode)
 }
+
+// Unwrap returns the underlying writer, such that an http.ResponseController can flush the response
+func (w *WrappedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.w
+}
@@ -0,0 +1,314 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/BLASTchain/blast/bl-service/client"
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+const (
+	versionMethod        = "eth/v1/node/version"
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+	headersMethodPrefix  = "eth/v1/beacon/headers/"
+)
+
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// BeaconClient is a thin wrapper over the Beacon APIs.
+type BeaconClient interface {
+	NodeVersion(ctx context.Context) (string, error)
+	ConfigSpec(ctx context.Context) (eth.APIConfigResponse, error)
+	BeaconGenesis(ctx context.Context) (eth.APIGenesisResponse, error)
+	BlobSideCarsFetcher
+}
+
+// BlobSideCarsFetcher is a thin wrapper over the Beacon APIs needed to fetch blob sidecars.
+// Blob archivers speaking the same API implement it as well, and can be used as fallbacks.
+type BlobSideCarsFetcher interface {
+	// BeaconBlobSideCars fetches the blob sidecars of the given slot. If no hashes are provided,
+	// all sidecars of the slot are requested.
+	BeaconBlobSideCars(ctx context.Context, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error)
+}
+
+// BeaconHTTPClient implements BeaconClient. It provides golang types over the basic Beacon API.
+type BeaconHTTPClient struct {
+	cl client.HTTP
+}
+
+var _ BeaconClient = (*BeaconHTTPClient)(nil)
+
+// NewBeaconHTTPClient returns a client for making requests to a Beacon API endpoint.
+func NewBeaconHTTPClient(cl client.HTTP) *BeaconHTTPClient {
+	return &BeaconHTTPClient{cl}
+}
+
+func (cl *BeaconHTTPClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	resp, err := cl.cl.Get(ctx, method, query, headers)
+	if err != nil {
+		return fmt.Errorf("%w: http Get failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		errMsg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed request with status %d: %s: %w", resp.StatusCode, string(errMsg), ethereum.NotFound)
+	} else if resp.StatusCode != http.StatusOK {
+		errMsg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(errMsg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NodeVersion returns the version string reported by the beacon node.
+func (cl *BeaconHTTPClient) NodeVersion(ctx context.Context) (string, error) {
+	var resp eth.APIVersionResponse
+	if err := cl.apiReq(ctx, &resp, versionMethod, nil); err != nil {
+		return "", err
+	}
+	return resp.Data.ProductVersion, nil
+}
+
+func (cl *BeaconHTTPClient) ConfigSpec(ctx context.Context) (eth.APIConfigResponse, error) {
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return eth.APIConfigResponse{}, err
+	}
+	return configResp, nil
+}
+
+func (cl *BeaconHTTPClient) BeaconGenesis(ctx context.Context) (eth.APIGenesisResponse, error) {
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return eth.APIGenesisResponse{}, err
+	}
+	return genesisResp, nil
+}
+
+func (cl *BeaconHTTPClient) BeaconBlobSideCars(ctx context.Context, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	reqPath := sidecarsMethodPrefix + strconv.FormatUint(slot, 10)
+	var query url.Values
+	if len(hashes) > 0 {
+		query = url.Values{}
+		for i := range hashes {
+			query.Add("indices", strconv.FormatUint(hashes[i].Index, 10))
+		}
+	}
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, reqPath, query); err != nil {
+		return eth.APIGetBlobSidecarsResponse{}, err
+	}
+	return resp, nil
+}
+
+// BeaconBlockHeader fetches the header of the given block, identified by slot, block root, or one of
+// "head", "genesis" and "finalized". Returns an error wrapping ethereum.NotFound if there is no such block.
+func (cl *BeaconHTTPClient) BeaconBlockHeader(ctx context.Context, blockID string) (eth.APIBeaconBlockHeaderResponse, error) {
+	var resp eth.APIBeaconBlockHeaderResponse
+	if err := cl.apiReq(ctx, &resp, headersMethodPrefix+blockID, nil); err != nil {
+		return eth.APIBeaconBlockHeaderResponse{}, err
+	}
+	return resp, nil
+}
+
+// L1BeaconClient is a high level golang client for the Beacon API.
+// Blob sidecars are fetched from the primary beacon node first, and from the
+// fallback sources (e.g. blob archivers) in order if the primary fails to serve them,
+// for example because the blobs were already pruned.
+type L1BeaconClient struct {
+	cl        BeaconClient
+	fallbacks []BlobSideCarsFetcher
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// NewL1BeaconClient returns a client for making requests to an L1 consensus layer node.
+// Fallbacks are optional sources of blob sidecars, tried in order if the primary client fails.
+func NewL1BeaconClient(cl BeaconClient, fallbacks ...BlobSideCarsFetcher) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl, fallbacks: fallbacks}
+}
+
+// NodeVersion returns the version string reported by the primary beacon node.
+func (cl *L1BeaconClient) NodeVersion(ctx context.Context) (string, error) {
+	return cl.cl.NodeVersion(ctx)
+}
+
+// GetTimeToSlotFn returns a function that converts a timestamp to a slot number.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	genesisResp, err := cl.cl.BeaconGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	configResp, err := cl.cl.ConfigSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("got bad value for seconds per slot: %v", configResp.Data.SecondsPerSlot)
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches blob sidecars that were confirmed in the specified L1 block with the
+// given indexed hashes. Order of the returned sidecars is guaranteed to be that of the hashes.
+// Blob data is not checked for validity.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	sidecars, _, err := cl.fetchFromSources(ctx, ref, hashes, nil)
+	return sidecars, err
+}
+
+// fetchFromSources fetches the blob sidecars from the primary beacon node, and from the fallbacks
+// in order if it fails to serve them. If decode is set, it is applied to the sidecars of each source,
+// and the next source is tried if it fails, so a source serving invalid blobs does not stall derivation.
+func (cl *L1BeaconClient) fetchFromSources(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash,
+	decode func([]*eth.BlobSidecar) ([]*eth.Blob, error)) ([]*eth.BlobSidecar, []*eth.Blob, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, []*eth.Blob{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	fetchers := append([]BlobSideCarsFetcher{cl.cl}, cl.fallbacks...)
+	var errs []error
+	notFound := 0
+	for i, fetcher := range fetchers {
+		name := "primary"
+		if i > 0 {
+			name = fmt.Sprintf("fallback %d", i-1)
+		}
+		sidecars, err := fetchSidecars(ctx, fetcher, slot, hashes)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				notFound++
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if decode == nil {
+			return sidecars, nil, nil
+		}
+		blobs, err := decode(sidecars)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		return sidecars, blobs, nil
+	}
+	if notFound < len(errs) {
+		// The sidecars are only missing if no source has them. A source failing for another reason may still
+		// serve them when retried, so the error must not be mistaken for missing sidecars.
+		for i, err := range errs {
+			if errors.Is(err, ethereum.NotFound) {
+				errs[i] = errors.New(err.Error())
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, errors.Join(errs...))
+}
+
+// fetchSidecars fetches the sidecars of the given slot from a single source, and returns
+// them in the order of the given hashes. The source may return the sidecars in any order,
+// and may include more than requested.
+func fetchSidecars(ctx context.Context, fetcher BlobSideCarsFetcher, slot uint64, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	resp, err := fetcher.BeaconBlobSideCars(ctx, slot, hashes)
+	if err != nil {
+		return nil, err
+	}
+	byIndex := make(map[uint64]*eth.BlobSidecar, len(resp.Data))
+	for _, sidecar := range resp.Data {
+		byIndex[uint64(sidecar.Index)] = sidecar
+	}
+	sidecars := make([]*eth.BlobSidecar, len(hashes))
+	for i, h := range hashes {
+		sidecar, ok := byIndex[h.Index]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing blob sidecar with index %d", ethereum.NotFound, h.Index)
+		}
+		sidecars[i] = sidecar
+	}
+	return sidecars, nil
+}
+
+// GetBlobs fetches blobs that were confirmed in the specified L1 block with the given indexed
+// hashes. The order of the returned blobs will match the order of `hashes`. Confirms each
+// blob's validity by checking its proof against the commitment, and confirming the commitment
+// hashes to the expected value. Blobs failing verification are fetched from the fallbacks.
+// Returns error if no source serves valid blobs.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	_, blobs, err := cl.fetchFromSources(ctx, ref, hashes, func(sidecars []*eth.BlobSidecar) ([]*eth.Blob, error) {
+		return blobsFromSidecars(sidecars, hashes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
+	}
+	return blobs, nil
+}
+
+var errBlobSidecarMismatch = errors.New("blob sidecar does not match expected blob hash")
+
+func blobsFromSidecars(sidecars []*eth.BlobSidecar, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	if len(sidecars) != len(hashes) {
+		return nil, fmt.Errorf("number of hashes and sidecars mismatch, %d != %d", len(hashes), len(sidecars))
+	}
+
+	out := make([]*eth.Blob, len(hashes))
+	for i, ih := range hashes {
+		sidecar := sidecars[i]
+		if sidx := uint64(sidecar.Index); sidx != ih.Index {
+			return nil, fmt.Errorf("expected sidecars to be ordered by hashes, but got %d != %d", sidx, ih.Index)
+		}
+
+		// make sure the blob's kzg commitment hashes to the expected value
+		hash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment))
+		if hash != ih.Hash {
+			return nil, fmt.Errorf("%w: index %d, got %s, expected %s", errBlobSidecarMismatch, i, hash, ih.Hash)
+		}
+
+		// confirm blob data is valid by verifying its proof against the commitment
+		if err := eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return nil, fmt.Errorf("blob at index %d failed verification: %w", i, err)
+		}
+		out[i] = &sidecar.Blob
+	}
+	return out, nil
+}
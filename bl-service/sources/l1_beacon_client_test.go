@@ -0,0 +1,192 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BLASTchain/blast/bl-service/client"
+	"github.com/BLASTchain/blast/bl-service/eth"
+)
+
+func makeTestBlobSidecar(t *testing.T, index uint64, data string) (eth.IndexedBlobHash, *eth.BlobSidecar) {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data(data)))
+	commit, err := blob.ComputeKZGCommitment()
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commit)
+	require.NoError(t, err)
+	hash := eth.IndexedBlobHash{Index: index, Hash: eth.KZGToVersionedHash(commit)}
+	sidecar := &eth.BlobSidecar{
+		Index:         eth.Uint64String(index),
+		Blob:          blob,
+		KZGCommitment: eth.Bytes48(commit),
+		KZGProof:      eth.Bytes48(proof),
+	}
+	return hash, sidecar
+}
+
+func TestBlobsFromSidecars(t *testing.T) {
+	h0, s0 := makeTestBlobSidecar(t, 0, "hello")
+	h1, s1 := makeTestBlobSidecar(t, 2, "world")
+
+	blobs, err := blobsFromSidecars([]*eth.BlobSidecar{s0, s1}, []eth.IndexedBlobHash{h0, h1})
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	data, err := blobs[1].ToData()
+	require.NoError(t, err)
+	require.Equal(t, eth.Data("world"), data)
+
+	// mismatched order
+	_, err = blobsFromSidecars([]*eth.BlobSidecar{s1, s0}, []eth.IndexedBlobHash{h0, h1})
+	require.Error(t, err)
+
+	// mismatched hash
+	badHash := h1
+	badHash.Hash[1] ^= 1
+	_, err = blobsFromSidecars([]*eth.BlobSidecar{s0, s1}, []eth.IndexedBlobHash{h0, badHash})
+	require.ErrorIs(t, err, errBlobSidecarMismatch)
+
+	// corrupted blob data
+	corrupt := *s1
+	corrupt.Blob[40] ^= 1
+	_, err = blobsFromSidecars([]*eth.BlobSidecar{s0, &corrupt}, []eth.IndexedBlobHash{h0, h1})
+	require.Error(t, err)
+}
+
+func TestL1BeaconClientGetBlobs(t *testing.T) {
+	h0, s0 := makeTestBlobSidecar(t, 0, "first")
+	h1, s1 := makeTestBlobSidecar(t, 1, "second")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}})
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/5", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, []string{"0", "1"}, r.URL.Query()["indices"])
+		// respond out of order, the client should re-order
+		_ = json.NewEncoder(w).Encode(eth.APIGetBlobSidecarsResponse{Data: []*eth.BlobSidecar{s1, s0}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cl := NewL1BeaconClient(NewBeaconHTTPClient(client.NewBasicHTTPClient(srv.URL, log.New())))
+	ref := eth.L1BlockRef{Time: 20}
+	blobs, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0, h1})
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	data, err := blobs[0].ToData()
+	require.NoError(t, err)
+	require.Equal(t, eth.Data("first"), data)
+
+	_, err = cl.GetBlobs(context.Background(), eth.L1BlockRef{Time: 22}, []eth.IndexedBlobHash{h0})
+	require.Error(t, err, "unknown slot")
+}
+
+func TestL1BeaconClientFallback(t *testing.T) {
+	h0, s0 := makeTestBlobSidecar(t, 0, "pruned")
+
+	// the primary beacon node has pruned the blob sidecars of slot 5
+	primary := http.NewServeMux()
+	primary.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}})
+	})
+	primary.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}})
+	})
+	primary.HandleFunc("/eth/v1/beacon/blob_sidecars/5", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIGetBlobSidecarsResponse{Data: []*eth.BlobSidecar{}})
+	})
+	primarySrv := httptest.NewServer(primary)
+	defer primarySrv.Close()
+
+	// the first archiver is unavailable, the second one has the sidecars
+	brokenSrv := httptest.NewServer(http.NotFoundHandler())
+	defer brokenSrv.Close()
+	archiver := http.NewServeMux()
+	archiver.HandleFunc("/eth/v1/beacon/blob_sidecars/5", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(eth.APIGetBlobSidecarsResponse{Data: []*eth.BlobSidecar{s0}})
+	})
+	archiverSrv := httptest.NewServer(archiver)
+	defer archiverSrv.Close()
+
+	newClient := func(url string) *BeaconHTTPClient {
+		return NewBeaconHTTPClient(client.NewBasicHTTPClient(url, log.New()))
+	}
+	ref := eth.L1BlockRef{Time: 20}
+
+	cl := NewL1BeaconClient(newClient(primarySrv.URL), newClient(brokenSrv.URL))
+	_, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0})
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// a fallback that is temporarily unavailable may still serve the sidecars, so they are not reported missing
+	unavailableSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailableSrv.Close()
+	cl = NewL1BeaconClient(newClient(primarySrv.URL), newClient(unavailableSrv.URL))
+	_, err = cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ethereum.NotFound)
+
+	cl = NewL1BeaconClient(newClient(primarySrv.URL), newClient(brokenSrv.URL), newClient(archiverSrv.URL))
+	blobs, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0})
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	data, err := blobs[0].ToData()
+	require.NoError(t, err)
+	require.Equal(t, eth.Data("pruned"), data)
+}
+
+func TestL1BeaconClientFallbackOnInvalidBlob(t *testing.T) {
+	h0, s0 := makeTestBlobSidecar(t, 0, "valid")
+	corrupt := *s0
+	corrupt.Blob[40] ^= 1
+
+	newServer := func(sidecar *eth.BlobSidecar) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}})
+		})
+		mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}})
+		})
+		mux.HandleFunc("/eth/v1/beacon/blob_sidecars/5", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(eth.APIGetBlobSidecarsResponse{Data: []*eth.BlobSidecar{sidecar}})
+		})
+		return httptest.NewServer(mux)
+	}
+	primarySrv := newServer(&corrupt)
+	defer primarySrv.Close()
+	archiverSrv := newServer(s0)
+	defer archiverSrv.Close()
+
+	newClient := func(url string) *BeaconHTTPClient {
+		return NewBeaconHTTPClient(client.NewBasicHTTPClient(url, log.New()))
+	}
+	ref := eth.L1BlockRef{Time: 20}
+
+	// the primary serves a blob failing verification, without fallback it is rejected
+	cl := NewL1BeaconClient(newClient(primarySrv.URL))
+	_, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0})
+	require.ErrorContains(t, err, "failed verification")
+
+	// the valid blob is fetched from the fallback instead
+	cl = NewL1BeaconClient(newClient(primarySrv.URL), newClient(archiverSrv.URL))
+	blobs, err := cl.GetBlobs(context.Background(), ref, []eth.IndexedBlobHash{h0})
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	data, err := blobs[0].ToData()
+	require.NoError(t, err)
+	require.Equal(t, eth.Data("valid"), data)
+}
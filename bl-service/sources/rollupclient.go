@@ -44,6 +44,18 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	return output, err
 }
 
+func (r *RollupClient) SafeHeadDivergence(ctx context.Context) ([]eth.SafeHeadDivergence, error) {
+	var output []eth.SafeHeadDivergence
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadDivergence")
+	return output, err
+}
+
+func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL1Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) StartSequencer(ctx context.Context, unsafeHead common.Hash) error {
 	return r.rpc.CallContext(ctx, nil, "admin_startSequencer", unsafeHead)
 }
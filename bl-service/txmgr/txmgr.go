@@ -12,23 +12,37 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 
+	"github.com/BLASTchain/blast/bl-service/eth"
 	"github.com/BLASTchain/blast/bl-service/retry"
 	"github.com/BLASTchain/blast/bl-service/txmgr/metrics"
 )
 
 const (
-	// Geth requires a minimum fee bump of 10% for tx resubmission
+	// Geth requires a minimum fee bump of 10% for regular tx resubmission
 	priceBump int64 = 10
+	// Geth requires a minimum fee bump of 100% for blob tx resubmission
+	blobPriceBump int64 = 100
 )
 
-// new = old * (100 + priceBump) / 100
-var priceBumpPercent = big.NewInt(100 + priceBump)
-var oneHundred = big.NewInt(100)
+var (
+	// new = old * (100 + priceBump) / 100
+	priceBumpPercent     = big.NewInt(100 + priceBump)
+	blobPriceBumpPercent = big.NewInt(100 + blobPriceBump)
+	oneHundred           = big.NewInt(100)
+	two                  = big.NewInt(2)
+
+	// Geth's blob pool enforces a minimum tip and blob fee cap of 1 gwei for blob transactions
+	minBlobTxFee = big.NewInt(params.GWei)
+)
 
 // TxManager is an interface that allows callers to reliably publish txs,
 // bumping the gas price if needed, and obtain the receipt of the resulting tx.
@@ -142,6 +156,12 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Blobs to send along in the tx (optional). If len(Blobs) > 0 then a blob tx
+	// will be sent instead of a DynamicFeeTx.
+	Blobs []*eth.Blob
+	// OnPublished is called with the hash of each transaction published for the candidate (optional).
+	// Fee bumps publish replacement transactions, so it may be called with several hashes.
+	OnPublished func(txHash common.Hash)
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -182,7 +202,7 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	return m.sendTx(ctx, tx, candidate.OnPublished)
 }
 
 // craftTx creates the signed transaction
@@ -191,44 +211,96 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+	gasTipCap, basefee, blobBasefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
-	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
-
-	rawTx := &types.DynamicFeeTx{
-		ChainID:   m.chainID,
-		To:        candidate.To,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Data:      candidate.TxData,
-		Value:     candidate.Value,
+	isBlobTx := len(candidate.Blobs) > 0
+	if isBlobTx && gasTipCap.Cmp(minBlobTxFee) < 0 {
+		gasTipCap = new(big.Int).Set(minBlobTxFee)
 	}
+	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
 
-	m.l.Info("Creating tx", "to", rawTx.To, "from", m.cfg.From)
+	m.l.Info("Creating tx", "to", candidate.To, "from", m.cfg.From, "blobs", len(candidate.Blobs))
 
 	// If the gas limit is set, we can use that as the gas
-	if candidate.GasLimit != 0 {
-		rawTx.Gas = candidate.GasLimit
-	} else {
+	gasLimit := candidate.GasLimit
+	if gasLimit == 0 {
 		// Calculate the intrinsic gas for the transaction
 		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
 			From:      m.cfg.From,
 			To:        candidate.To,
 			GasFeeCap: gasFeeCap,
 			GasTipCap: gasTipCap,
-			Data:      rawTx.Data,
-			Value:     rawTx.Value,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
-		rawTx.Gas = gas
+		gasLimit = gas
+	}
+
+	var txMessage types.TxData
+	if isBlobTx {
+		if candidate.To == nil {
+			return nil, errors.New("blob txs cannot deploy contracts")
+		}
+		if blobBasefee == nil {
+			return nil, errors.New("blob txs require a post-Cancun L1 with a blob basefee")
+		}
+		sidecar, blobHashes, err := MakeSidecar(candidate.Blobs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make sidecar: %w", err)
+		}
+		message := &types.BlobTx{
+			To:         *candidate.To,
+			Data:       candidate.TxData,
+			Gas:        gasLimit,
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+		if err := finishBlobTx(message, m.chainID, gasTipCap, gasFeeCap, calcBlobFeeCap(blobBasefee), candidate.Value); err != nil {
+			return nil, fmt.Errorf("failed to create blob transaction: %w", err)
+		}
+		txMessage = message
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			To:        candidate.To,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gasLimit,
+			Data:      candidate.TxData,
+			Value:     candidate.Value,
+		}
 	}
 
-	return m.signWithNextNonce(ctx, rawTx)
+	return m.signWithNextNonce(ctx, txMessage)
+}
+
+// MakeSidecar builds & returns the BlobTxSidecar and corresponding blob hashes from the raw blob
+// data.
+func MakeSidecar(blobs []*eth.Blob) (*types.BlobTxSidecar, []common.Hash, error) {
+	sidecar := &types.BlobTxSidecar{}
+	blobHashes := make([]common.Hash, 0, len(blobs))
+	for i, blob := range blobs {
+		rawBlob := *blob.KZGBlob()
+		sidecar.Blobs = append(sidecar.Blobs, rawBlob)
+		commitment, err := kzg4844.BlobToCommitment(rawBlob)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot compute KZG commitment of blob %d in tx candidate: %w", i, err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		proof, err := kzg4844.ComputeBlobProof(rawBlob, commitment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot compute KZG proof for fast commitment verification of blob %d in tx candidate: %w", i, err)
+		}
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+		blobHashes = append(blobHashes, eth.KZGToVersionedHash(commitment))
+	}
+	return sidecar, blobHashes, nil
 }
 
 // signWithNextNonce returns a signed transaction with the next available nonce.
@@ -236,7 +308,7 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 // then subsequent calls simply increment this number. If the transaction manager
 // is reset, it will query the eth_getTransactionCount nonce again. If signing
 // fails, the nonce is not incremented.
-func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.DynamicFeeTx) (*types.Transaction, error) {
+func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types.TxData) (*types.Transaction, error) {
 	m.nonceLock.Lock()
 	defer m.nonceLock.Unlock()
 
@@ -254,10 +326,18 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 		*m.nonce++
 	}
 
-	rawTx.Nonce = *m.nonce
+	switch x := txMessage.(type) {
+	case *types.DynamicFeeTx:
+		x.Nonce = *m.nonce
+	case *types.BlobTx:
+		x.Nonce = *m.nonce
+	default:
+		*m.nonce--
+		return nil, fmt.Errorf("unrecognized tx type: %T", x)
+	}
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
 	if err != nil {
 		// decrement the nonce, so we can retry signing with the same nonce next time
 		// signWithNextNonce is called
@@ -277,8 +357,9 @@ func (m *SimpleTxManager) resetNonce() {
 }
 
 // send submits the same transaction several times with increasing gas prices as necessary.
-// It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// It waits for the transaction to be confirmed on chain. onPublished, if set, is called with
+// the hash of every transaction published.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, onPublished func(common.Hash)) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -290,6 +371,9 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 		wg.Add(1)
 		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
 		if published {
+			if onPublished != nil {
+				onPublished(tx.Hash())
+			}
 			go func() {
 				defer wg.Done()
 				m.waitForTx(ctx, tx, sendState, receiptChan)
@@ -495,12 +579,16 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // `feeLimitMultiplier` multiple of the suggested values.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	m.l.Info("bumping gas price for tx", "hash", tx.Hash(), "tip", tx.GasTipCap(), "fee", tx.GasFeeCap(), "gaslimit", tx.Gas())
-	tip, basefee, err := m.suggestGasPriceCaps(ctx)
+	tip, basefee, blobBasefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
 		return nil, err
 	}
-	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	isBlobTx := tx.Type() == types.BlobTxType
+	if isBlobTx && tip.Cmp(minBlobTxFee) < 0 {
+		tip = new(big.Int).Set(minBlobTxFee)
+	}
+	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, isBlobTx, m.l)
 
 	// Make sure increase is at most [FeeLimitMultiplier] the suggested values
 	maxTip := new(big.Int).Mul(tip, big.NewInt(int64(m.cfg.FeeLimitMultiplier)))
@@ -511,24 +599,14 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	if bumpedFee.Cmp(maxFee) > 0 {
 		return nil, fmt.Errorf("bumped fee 0x%s is over %dx multiple of the suggested value", bumpedFee.Text(16), m.cfg.FeeLimitMultiplier)
 	}
-	rawTx := &types.DynamicFeeTx{
-		ChainID:    tx.ChainId(),
-		Nonce:      tx.Nonce(),
-		GasTipCap:  bumpedTip,
-		GasFeeCap:  bumpedFee,
-		To:         tx.To(),
-		Value:      tx.Value(),
-		Data:       tx.Data(),
-		AccessList: tx.AccessList(),
-	}
 
 	// Re-estimate gaslimit in case things have changed or a previous gaslimit estimate was wrong
 	gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
 		From:      m.cfg.From,
-		To:        rawTx.To,
+		To:        tx.To(),
 		GasFeeCap: bumpedTip,
 		GasTipCap: bumpedFee,
-		Data:      rawTx.Data,
+		Data:      tx.Data(),
 	})
 	if err != nil {
 		// If this is a transaction resubmission, we sometimes see this outcome because the
@@ -541,11 +619,52 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	if tx.Gas() != gas {
 		m.l.Info("re-estimated gas differs", "oldgas", tx.Gas(), "newgas", gas)
 	}
-	rawTx.Gas = gas
+
+	var txMessage types.TxData
+	if isBlobTx {
+		if blobBasefee == nil {
+			return nil, errors.New("blob tx to bump, but no blob basefee available")
+		}
+		// Blob transactions have an additional blob gas price we must specify, so we must make sure it is
+		// getting bumped appropriately.
+		bumpedBlobFee := calcThresholdValue(tx.BlobGasFeeCap(), true)
+		if suggested := calcBlobFeeCap(blobBasefee); bumpedBlobFee.Cmp(suggested) < 0 {
+			bumpedBlobFee = suggested
+		}
+		maxBlobFee := new(big.Int).Mul(calcBlobFeeCap(blobBasefee), big.NewInt(int64(m.cfg.FeeLimitMultiplier)))
+		if bumpedBlobFee.Cmp(maxBlobFee) > 0 {
+			return nil, fmt.Errorf("bumped blob fee 0x%s is over %dx multiple of the suggested value", bumpedBlobFee.Text(16), m.cfg.FeeLimitMultiplier)
+		}
+		message := &types.BlobTx{
+			Nonce:      tx.Nonce(),
+			To:         *tx.To(),
+			Data:       tx.Data(),
+			Gas:        gas,
+			AccessList: tx.AccessList(),
+			BlobHashes: tx.BlobHashes(),
+			Sidecar:    tx.BlobTxSidecar(),
+		}
+		if err := finishBlobTx(message, tx.ChainId(), bumpedTip, bumpedFee, bumpedBlobFee, tx.Value()); err != nil {
+			return nil, err
+		}
+		txMessage = message
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  bumpedTip,
+			GasFeeCap:  bumpedFee,
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err)
 		return tx, nil
@@ -553,32 +672,43 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return newTx, nil
 }
 
-// suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions
-func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+// suggestGasPriceCaps suggests what the new tip, basefee & blob basefee should be based on the current L1 conditions.
+// The blob basefee is nil if the L1 head does not have an excess blob gas value, i.e. if it is pre-Cancun.
+func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	tip, err := m.backend.SuggestGasTipCap(cCtx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
 	} else if tip == nil {
-		return nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, nil, errors.New("the suggested tip was nil")
 	}
 	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	head, err := m.backend.HeaderByNumber(cCtx, nil)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
 	} else if head.BaseFee == nil {
-		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+		return nil, nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+	}
+	var blobBasefee *big.Int
+	if head.ExcessBlobGas != nil {
+		blobBasefee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
 	}
-	return tip, head.BaseFee, nil
+	return tip, head.BaseFee, blobBasefee, nil
 }
 
-// calcThresholdValue returns x * priceBumpPercent / 100
-func calcThresholdValue(x *big.Int) *big.Int {
-	threshold := new(big.Int).Mul(priceBumpPercent, x)
+// calcThresholdValue returns x * priceBumpPercent / 100, or x * blobPriceBumpPercent / 100 for blob txs.
+func calcThresholdValue(x *big.Int, isBlobTx bool) *big.Int {
+	var percent *big.Int
+	if isBlobTx {
+		percent = blobPriceBumpPercent
+	} else {
+		percent = priceBumpPercent
+	}
+	threshold := new(big.Int).Mul(percent, x)
 	threshold = threshold.Div(threshold, oneHundred)
 	return threshold
 }
@@ -589,11 +719,13 @@ func calcThresholdValue(x *big.Int) *big.Int {
 //	(a) each satisfies geth's required tx-replacement fee bumps (we use a 10% increase), and
 //	(b) gasTipCap is no less than new tip, and
 //	(c) gasFeeCap is no less than calcGasFee(newBaseFee, newTip)
-func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger) (*big.Int, *big.Int) {
+//
+// Blob transactions are subject to a larger replacement fee bump (100%) in geth's blob pool.
+func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, isBlobTx bool, lgr log.Logger) (*big.Int, *big.Int) {
 	newFeeCap := calcGasFeeCap(newBaseFee, newTip)
 	lgr = lgr.New("old_tip", oldTip, "old_feecap", oldFeeCap, "new_tip", newTip, "new_feecap", newFeeCap)
-	thresholdTip := calcThresholdValue(oldTip)
-	thresholdFeeCap := calcThresholdValue(oldFeeCap)
+	thresholdTip := calcThresholdValue(oldTip, isBlobTx)
+	thresholdFeeCap := calcThresholdValue(oldFeeCap, isBlobTx)
 	if newTip.Cmp(thresholdTip) >= 0 && newFeeCap.Cmp(thresholdFeeCap) >= 0 {
 		lgr.Debug("Using new tip and feecap")
 		return newTip, newFeeCap
@@ -626,6 +758,39 @@ func calcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
 	)
 }
 
+// calcBlobFeeCap computes a suggested blob fee cap that is twice the current header's blob basefee
+// value, with a minimum value of minBlobTxFee.
+func calcBlobFeeCap(blobBasefee *big.Int) *big.Int {
+	cap := new(big.Int).Mul(blobBasefee, two)
+	if cap.Cmp(minBlobTxFee) < 0 {
+		cap.Set(minBlobTxFee)
+	}
+	return cap
+}
+
+// finishBlobTx finishes creating a blob tx message by safely converting bigints to uint256
+func finishBlobTx(message *types.BlobTx, chainID, tip, fee, blobFee, value *big.Int) error {
+	var o bool
+	if message.ChainID, o = uint256.FromBig(chainID); o {
+		return fmt.Errorf("ChainID overflow")
+	}
+	if message.GasTipCap, o = uint256.FromBig(tip); o {
+		return fmt.Errorf("GasTipCap overflow")
+	}
+	if message.GasFeeCap, o = uint256.FromBig(fee); o {
+		return fmt.Errorf("GasFeeCap overflow")
+	}
+	if message.BlobFeeCap, o = uint256.FromBig(blobFee); o {
+		return fmt.Errorf("BlobFeeCap overflow")
+	}
+	if value == nil {
+		message.Value = new(uint256.Int)
+	} else if message.Value, o = uint256.FromBig(value); o {
+		return fmt.Errorf("Value overflow")
+	}
+	return nil
+}
+
 // errStringMatch returns true if err.Error() is a substring in target.Error() or if both are nil.
 // It can accept nil errors without issue.
 func errStringMatch(err, target error) bool {
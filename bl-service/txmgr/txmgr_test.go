@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/BLASTchain/blast/bl-service/eth"
 	"github.com/BLASTchain/blast/bl-service/testlog"
 	"github.com/BLASTchain/blast/bl-service/txmgr/metrics"
 
@@ -19,6 +20,8 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 type sendTransactionFunc func(ctx context.Context, tx *types.Transaction) error
@@ -202,8 +205,10 @@ func (b *mockBackend) CallContract(ctx context.Context, call ethereum.CallMsg, b
 }
 
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	excessBlobGas := uint64(0)
 	return &types.Header{
-		BaseFee: b.g.basefee(),
+		BaseFee:       b.g.basefee(),
+		ExcessBlobGas: &excessBlobGas,
 	}, nil
 }
 
@@ -287,7 +292,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -315,7 +320,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -344,12 +349,46 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
 }
 
+// TestTxMgrReportsPublishedTxs asserts that the hash of every published tx,
+// including fee bumps, is passed to the publish callback.
+func TestTxMgrReportsPublishedTxs(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	gasTipCap, gasFeeCap := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var published []common.Hash
+	receipt, err := h.mgr.sendTx(ctx, tx, func(txHash common.Hash) {
+		published = append(published, txHash)
+	})
+	require.Nil(t, err)
+	require.NotNil(t, receipt)
+	require.Greater(t, len(published), 1)
+	require.Contains(t, published, receipt.TxHash)
+}
+
 // errRpcFailure is a sentinel error used in testing to fail publications.
 var errRpcFailure = errors.New("rpc failure")
 
@@ -375,7 +414,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -403,6 +442,35 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftBlobTx ensures that the tx manager will create a blob tx
+// with a sidecar, blob hashes and a blob fee cap, if the candidate has blobs.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.TxData = nil
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("some frame data")))
+	candidate.Blobs = []*eth.Blob{&blob}
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+	require.Len(t, tx.BlobHashes(), 1)
+	require.NotNil(t, tx.BlobTxSidecar())
+	require.Equal(t, tx.BlobHashes(), tx.BlobTxSidecar().BlobHashes())
+
+	// geth requires a minimum tip and blob fee cap of 1 gwei for blob txs
+	require.Equal(t, minBlobTxFee, tx.GasTipCap())
+	require.Equal(t, minBlobTxFee, tx.BlobGasFeeCap())
+
+	// blob txs must have a recipient
+	candidate.To = nil
+	_, err = h.mgr.craftTx(context.Background(), candidate)
+	require.Error(t, err)
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -512,7 +580,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -547,7 +615,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -592,7 +660,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -692,6 +760,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessBlobGas            *uint64
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -723,7 +792,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessBlobGas: b.excessBlobGas,
 	}, nil
 }
 
@@ -885,6 +955,56 @@ func TestIncreaseGasPrice(t *testing.T) {
 	}
 }
 
+// TestIncreaseGasPriceBlobTx asserts that blob txs are bumped by at least 100%, including the blob fee cap,
+// and keep their blobs.
+func TestIncreaseGasPriceBlobTx(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+
+	excessBlobGas := uint64(0)
+	borkedBackend := failingBackend{
+		gasTip:        big.NewInt(params.GWei),
+		baseFee:       big.NewInt(params.GWei),
+		excessBlobGas: &excessBlobGas,
+	}
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			ResubmissionTimeout:       time.Second,
+			ReceiptQueryInterval:      50 * time.Millisecond,
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+			FeeLimitMultiplier:        5,
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			From: common.Address{},
+		},
+		name:    "TEST",
+		backend: &borkedBackend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	var blob eth.Blob
+	sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{&blob})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.BlobTx{
+		GasTipCap:  uint256.NewInt(params.GWei),
+		GasFeeCap:  uint256.NewInt(3 * params.GWei),
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	})
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, big.NewInt(2*params.GWei), newTx.GasTipCap())
+	require.Equal(t, big.NewInt(6*params.GWei), newTx.GasFeeCap())
+	require.Equal(t, big.NewInt(2*params.GWei), newTx.BlobGasFeeCap())
+	require.Equal(t, blobHashes, newTx.BlobHashes())
+	require.NotNil(t, newTx.BlobTxSidecar())
+}
+
 // TestIncreaseGasPriceNotExponential asserts that if the L1 basefee & tip remain the
 // same, repeated calls to IncreaseGasPrice do not continually increase the gas price.
 func TestIncreaseGasPriceNotExponential(t *testing.T) {
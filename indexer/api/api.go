@@ -28,6 +28,7 @@ const ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
 const (
 	MetricsNamespace = "op_indexer_api"
 	addressParam     = "{address:%s}"
+	tokenPairParams  = "/{l1Token:%s}/{l2Token:%s}"
 
 	// Endpoint paths
 	// NOTE - This can be further broken out over time as new version iterations
@@ -36,7 +37,14 @@ const (
 	DepositsPath    = "/api/v0/deposits/"
 	WithdrawalsPath = "/api/v0/withdrawals/"
 
-	SupplyPath = "/api/v0/supply"
+	SupplyPath      = "/api/v0/supply"
+	TokenSupplyPath = "/api/v0/supply/tokens"
+
+	TokensPath = "/api/v0/tokens"
+
+	WithdrawalStatusPath = "/api/v0/withdrawal/{hash}/status"
+
+	EventsPath = "/api/v0/events"
 )
 
 // Api ... Indexer API struct
@@ -46,8 +54,11 @@ type APIService struct {
 	router *chi.Mux
 
 	bv      database.BridgeTransfersView
+	blocks  database.BlocksView
 	dbClose func() error
 
+	finalizationPeriodSeconds uint64
+
 	metricsRegistry *prometheus.Registry
 
 	apiServer     *httputil.HTTPServer
@@ -73,6 +84,7 @@ func NewApi(ctx context.Context, log log.Logger, cfg *Config) (*APIService, erro
 }
 
 func (a *APIService) initFromConfig(ctx context.Context, cfg *Config) error {
+	a.finalizationPeriodSeconds = cfg.FinalizationPeriodSeconds
 	if err := a.initDB(ctx, cfg.DB); err != nil {
 		return fmt.Errorf("failed to init DB: %w", err)
 	}
@@ -133,23 +145,37 @@ func (a *APIService) initDB(ctx context.Context, connector DBConnector) error {
 	}
 	a.dbClose = db.Closer
 	a.bv = db.BridgeTransfers
+	a.blocks = db.Blocks
 	return nil
 }
 
 func (a *APIService) initRouter(apiConfig config.ServerConfig) {
 	apiRouter := chi.NewRouter()
-	h := routes.NewRoutes(a.log, a.bv, apiRouter)
+	h := routes.NewRoutes(a.log, a.bv, a.blocks, a.finalizationPeriodSeconds, apiRouter)
 
 	promRecorder := metrics.NewPromHTTPRecorder(a.metricsRegistry, MetricsNamespace)
 
 	apiRouter.Use(chiMetricsMiddleware(promRecorder))
-	apiRouter.Use(middleware.Timeout(time.Duration(apiConfig.WriteTimeout) * time.Second))
 	apiRouter.Use(middleware.Recoverer)
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
-	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
-	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
-	apiRouter.Get(SupplyPath, h.SupplyView)
+	// The events stream is long-lived, and not subject to the request timeout
+	apiRouter.Get(EventsPath, h.BridgeEventsHandler)
+
+	apiRouter.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(time.Duration(apiConfig.WriteTimeout) * time.Second))
+
+		r.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
+		r.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
+		r.Get(WithdrawalStatusPath, h.WithdrawalStatusHandler)
+		r.Get(SupplyPath, h.SupplyView)
+		r.Get(TokenSupplyPath, h.TokenSupplyView)
+		r.Get(TokensPath, h.BridgedTokensHandler)
+		r.Get(fmt.Sprintf(TokensPath+tokenPairParams+"/deposits", ethereumAddressRegex, ethereumAddressRegex), h.TokenDepositsHandler)
+		r.Get(fmt.Sprintf(TokensPath+tokenPairParams+"/withdrawals", ethereumAddressRegex, ethereumAddressRegex), h.TokenWithdrawalsHandler)
+		r.Get(fmt.Sprintf(TokensPath+tokenPairParams+"/erc721/deposits", ethereumAddressRegex, ethereumAddressRegex), h.TokenERC721DepositsHandler)
+		r.Get(fmt.Sprintf(TokensPath+tokenPairParams+"/erc721/withdrawals", ethereumAddressRegex, ethereumAddressRegex), h.TokenERC721WithdrawalsHandler)
+	})
 	a.router = apiRouter
 }
 
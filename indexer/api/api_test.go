@@ -1,12 +1,17 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/BLASTchain/blast/indexer/api/models"
 	"github.com/BLASTchain/blast/indexer/config"
@@ -23,6 +28,16 @@ type MockBridgeTransfersView struct{}
 
 var mockAddress = "0x4204204204204204204204204204204204204204"
 
+var mockTokenPair = database.TokenPair{
+	LocalTokenAddress:  common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	RemoteTokenAddress: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+}
+
+var mockERC721TokenPair = database.TokenPair{
+	LocalTokenAddress:  common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	RemoteTokenAddress: common.HexToAddress("0x4444444444444444444444444444444444444444"),
+}
+
 var apiConfig = config.ServerConfig{
 	Host: "localhost",
 	Port: 0, // random port, to allow parallel tests
@@ -43,6 +58,25 @@ var (
 		},
 	}
 
+	erc721Deposit = database.L1ERC721BridgeDeposit{
+		TransactionSourceHash: common.HexToHash("0x721"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			TokenPair: mockERC721TokenPair,
+			TokenID:   big.NewInt(7),
+			Timestamp: 1000,
+		},
+	}
+
+	erc721Withdrawal = database.L2ERC721BridgeWithdrawal{
+		TransactionWithdrawalHash: common.HexToHash("0x1721"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			// withdrawals are from the perspective of L2
+			TokenPair: database.TokenPair{LocalTokenAddress: mockERC721TokenPair.RemoteTokenAddress, RemoteTokenAddress: mockERC721TokenPair.LocalTokenAddress},
+			TokenID:   big.NewInt(7),
+			Timestamp: 1500,
+		},
+	}
+
 	withdrawal = database.L2BridgeWithdrawal{
 		TransactionWithdrawalHash: common.HexToHash("0x420"),
 		BridgeTransfer: database.BridgeTransfer{
@@ -83,6 +117,7 @@ func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Add
 }
 
 func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	provenTimestamp, finalizedTimestamp, succeeded := uint64(1000), uint64(1500), true
 	return &database.L2BridgeWithdrawalsResponse{
 		Withdrawals: []database.L2BridgeWithdrawalWithTransactionHashes{
 			{
@@ -91,11 +126,97 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 				L2BlockHash:                common.HexToHash("0x456"),
 				ProvenL1TransactionHash:    common.HexToHash("0x123"),
 				FinalizedL1TransactionHash: common.HexToHash("0x123"),
+				L2BlockNumber:              big.NewInt(10),
+				ProvenL1Timestamp:          &provenTimestamp,
+				FinalizedL1Timestamp:       &finalizedTimestamp,
+				Succeeded:                  &succeeded,
 			},
 		},
 	}, nil
 }
 
+func (mbv *MockBridgeTransfersView) L1BridgeDepositsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	if tokenPair != mockTokenPair {
+		return &database.L1BridgeDepositsResponse{}, nil
+	}
+	return mbv.L1BridgeDepositsByAddress(common.Address{}, cursor, limit)
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	// withdrawals are from the perspective of L2
+	if tokenPair.LocalTokenAddress != mockTokenPair.RemoteTokenAddress || tokenPair.RemoteTokenAddress != mockTokenPair.LocalTokenAddress {
+		return &database.L2BridgeWithdrawalsResponse{}, nil
+	}
+	return mbv.L2BridgeWithdrawalsByAddress(common.Address{}, cursor, limit)
+}
+
+func (mbv *MockBridgeTransfersView) L2WithdrawalByHash(hash common.Hash) (*database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	if hash != withdrawal.TransactionWithdrawalHash {
+		return nil, nil
+	}
+	provenTimestamp := uint64(1000)
+	return &database.L2BridgeWithdrawalWithTransactionHashes{
+		L2BridgeWithdrawal:      withdrawal,
+		L2TransactionHash:       common.HexToHash("0x789"),
+		L2BlockHash:             common.HexToHash("0x456"),
+		ProvenL1TransactionHash: common.HexToHash("0x123"),
+		L2BlockNumber:           big.NewInt(10),
+		ProvenL1Timestamp:       &provenTimestamp,
+	}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L1ERC721BridgeDeposit(hash common.Hash) (*database.L1ERC721BridgeDeposit, error) {
+	return nil, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2ERC721BridgeWithdrawal(hash common.Hash) (*database.L2ERC721BridgeWithdrawal, error) {
+	return nil, nil
+}
+
+func (mbv *MockBridgeTransfersView) L1ERC721BridgeDepositsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L1ERC721BridgeDepositsResponse, error) {
+	if tokenPair != mockERC721TokenPair {
+		return &database.L1ERC721BridgeDepositsResponse{}, nil
+	}
+	return &database.L1ERC721BridgeDepositsResponse{
+		Deposits: []database.L1ERC721BridgeDepositWithTransactionHashes{
+			{
+				L1ERC721BridgeDeposit: erc721Deposit,
+				L1TransactionHash:     common.HexToHash("0x123"),
+				L2TransactionHash:     common.HexToHash("0x555"),
+				L1BlockHash:           common.HexToHash("0x456"),
+			},
+		},
+	}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2ERC721BridgeWithdrawalsByTokenPair(tokenPair database.TokenPair, cursor string, limit int) (*database.L2ERC721BridgeWithdrawalsResponse, error) {
+	if tokenPair != erc721Withdrawal.TokenPair {
+		return &database.L2ERC721BridgeWithdrawalsResponse{}, nil
+	}
+	return &database.L2ERC721BridgeWithdrawalsResponse{
+		Withdrawals: []database.L2ERC721BridgeWithdrawalWithTransactionHashes{
+			{
+				L2ERC721BridgeWithdrawal: erc721Withdrawal,
+				L2TransactionHash:        common.HexToHash("0x789"),
+				L2BlockHash:              common.HexToHash("0x456"),
+				L2BlockNumber:            big.NewInt(10),
+			},
+		},
+	}, nil
+}
+
+func (mbv *MockBridgeTransfersView) BridgedTokens() ([]database.BridgedToken, error) {
+	return []database.BridgedToken{
+		{L1TokenAddress: mockTokenPair.LocalTokenAddress, L2TokenAddress: mockTokenPair.RemoteTokenAddress, Standard: "ERC20"},
+	}, nil
+}
+
+func (mbv *MockBridgeTransfersView) TokenValueLocked() ([]database.TokenValueLocked, error) {
+	return []database.TokenValueLocked{
+		{TokenPair: mockTokenPair, DepositSum: 100, WithdrawalSum: 40},
+	}, nil
+}
+
 func (mbv *MockBridgeTransfersView) L1BridgeDepositSum() (float64, error) {
 	return 69, nil
 }
@@ -103,10 +224,18 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalSum() (float64, error) {
 	return 420, nil
 }
 
+// newMockBlocksView mocks the indexed L1 chain & output proposals the lifecycle of withdrawals is computed against
+func newMockBlocksView() *database.MockBlocksView {
+	blocks := new(database.MockBlocksView)
+	blocks.On("L1LatestBlockHeader").Return(&database.L1BlockHeader{BlockHeader: database.BlockHeader{Number: big.NewInt(100), Timestamp: 2000}}, nil)
+	blocks.On("LatestOutputProposal").Return(&database.OutputProposal{L2BlockNumber: big.NewInt(20)}, nil)
+	return blocks
+}
+
 func TestHealthz(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cfg := &Config{
-		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
 		HTTPServer:    apiConfig,
 		MetricsServer: metricsConfig,
 	}
@@ -124,7 +253,7 @@ func TestHealthz(t *testing.T) {
 func TestL1BridgeDepositsHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cfg := &Config{
-		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
 		HTTPServer:    apiConfig,
 		MetricsServer: metricsConfig,
 	}
@@ -153,7 +282,7 @@ func TestL1BridgeDepositsHandler(t *testing.T) {
 func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cfg := &Config{
-		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
 		HTTPServer:    apiConfig,
 		MetricsServer: metricsConfig,
 	}
@@ -182,5 +311,245 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].L1TokenAddress, withdrawal.TokenPair.RemoteTokenAddress.String())
 	assert.Equal(t, resp.Items[0].L2TokenAddress, withdrawal.TokenPair.LocalTokenAddress.String())
 	assert.Equal(t, resp.Items[0].Timestamp, withdrawal.Tx.Timestamp)
+	assert.Equal(t, resp.Items[0].Status.State, models.WithdrawalFinalized)
+	assert.True(t, *resp.Items[0].Status.Succeeded)
+
+}
+
+func TestWithdrawalStatusHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:                        &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
+		HTTPServer:                apiConfig,
+		MetricsServer:             metricsConfig,
+		FinalizationPeriodSeconds: 12,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	get := func(hash string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://%s/api/v0/withdrawal/%s/status", api.Addr(), hash), nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		return responseRecorder
+	}
+
+	t.Run("proven", func(t *testing.T) {
+		responseRecorder := get(withdrawal.TransactionWithdrawalHash.String())
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.WithdrawalStatusResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Equal(t, withdrawal.TransactionWithdrawalHash.String(), resp.Guid)
+		require.Equal(t, common.HexToHash("0x789").String(), resp.TransactionHash)
+		require.Equal(t, models.WithdrawalReadyToFinalize, resp.Status.State)
+		require.Equal(t, uint64(1012), resp.Status.FinalizableAt)
+	})
+
+	t.Run("unknown withdrawal", func(t *testing.T) {
+		responseRecorder := get(common.HexToHash("0x1").String())
+		require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		responseRecorder := get("0x1234")
+		require.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+}
+
+func TestTokenHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		return responseRecorder
+	}
+
+	l1Token, l2Token := mockTokenPair.LocalTokenAddress.String(), mockTokenPair.RemoteTokenAddress.String()
+
+	t.Run("tokens", func(t *testing.T) {
+		responseRecorder := get(TokensPath)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.BridgedTokensResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, l1Token, resp.Items[0].L1TokenAddress)
+		require.Equal(t, l2Token, resp.Items[0].L2TokenAddress)
+		require.Equal(t, "ERC20", resp.Items[0].Standard)
+	})
+
+	t.Run("token supply", func(t *testing.T) {
+		responseRecorder := get(TokenSupplyPath)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.TokenSupplyView
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, l1Token, resp.Items[0].L1TokenAddress)
+		require.Equal(t, l2Token, resp.Items[0].L2TokenAddress)
+		require.Equal(t, float64(60), resp.Items[0].ValueLocked)
+	})
+
+	t.Run("deposits by token pair", func(t *testing.T) {
+		responseRecorder := get(fmt.Sprintf("%s/%s/%s/deposits", TokensPath, l1Token, l2Token))
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, deposit.TransactionSourceHash.String(), resp.Items[0].Guid)
+	})
+
+	t.Run("withdrawals by token pair", func(t *testing.T) {
+		responseRecorder := get(fmt.Sprintf("%s/%s/%s/withdrawals", TokensPath, l1Token, l2Token))
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.WithdrawalResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, withdrawal.TransactionWithdrawalHash.String(), resp.Items[0].Guid)
+	})
+
+	t.Run("erc721 deposits by token pair", func(t *testing.T) {
+		l1ERC721Token, l2ERC721Token := mockERC721TokenPair.LocalTokenAddress.String(), mockERC721TokenPair.RemoteTokenAddress.String()
+		responseRecorder := get(fmt.Sprintf("%s/%s/%s/erc721/deposits", TokensPath, l1ERC721Token, l2ERC721Token))
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.ERC721DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, erc721Deposit.TransactionSourceHash.String(), resp.Items[0].Guid)
+		require.Equal(t, "7", resp.Items[0].TokenId)
+		require.Equal(t, l1ERC721Token, resp.Items[0].L1TokenAddress)
+		require.Equal(t, l2ERC721Token, resp.Items[0].L2TokenAddress)
+
+		// the ERC20 deposits of the pair are listed separately
+		responseRecorder = get(fmt.Sprintf("%s/%s/%s/deposits", TokensPath, l1ERC721Token, l2ERC721Token))
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var erc20Resp models.DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &erc20Resp))
+		require.Empty(t, erc20Resp.Items)
+	})
+
+	t.Run("erc721 withdrawals by token pair", func(t *testing.T) {
+		l1ERC721Token, l2ERC721Token := mockERC721TokenPair.LocalTokenAddress.String(), mockERC721TokenPair.RemoteTokenAddress.String()
+		responseRecorder := get(fmt.Sprintf("%s/%s/%s/erc721/withdrawals", TokensPath, l1ERC721Token, l2ERC721Token))
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.ERC721WithdrawalResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, erc721Withdrawal.TransactionWithdrawalHash.String(), resp.Items[0].Guid)
+		require.Equal(t, "7", resp.Items[0].TokenId)
+		require.Equal(t, l1ERC721Token, resp.Items[0].L1TokenAddress)
+		require.Equal(t, models.WithdrawalReadyToProve, resp.Items[0].Status.State)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		responseRecorder := get(fmt.Sprintf("%s/%s/%s/deposits", TokensPath, l1Token, common.Address{}))
+		require.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+}
+
+// newDepositBridgeTransfersView lists an additional deposit once the deposits were read
+type newDepositBridgeTransfersView struct {
+	MockBridgeTransfersView
+	reads atomic.Int32
+}
+
+func (v *newDepositBridgeTransfersView) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	resp, err := v.MockBridgeTransfersView.L1BridgeDepositsByAddress(address, cursor, limit)
+	if err != nil || v.reads.Add(1) == 1 {
+		return resp, err
+	}
+	newDeposit := deposit
+	newDeposit.TransactionSourceHash = common.HexToHash("0xdef")
+	resp.Deposits = append([]database.L1BridgeDepositWithTransactionHashes{{L1BridgeDeposit: newDeposit}}, resp.Deposits...)
+	return resp, nil
+}
+
+func TestBridgeEventsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: newMockBlocksView()},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	t.Run("invalid subscriptions", func(t *testing.T) {
+		for _, query := range []string{"", "?address=0x1234", "?address=" + mockAddress + "&address=0xzz"} {
+			request, err := http.NewRequest("GET", fmt.Sprintf("http://%s%s%s", api.Addr(), EventsPath, query), nil)
+			require.NoError(t, err)
+			responseRecorder := httptest.NewRecorder()
+			api.router.ServeHTTP(responseRecorder, request)
+			require.Equal(t, http.StatusBadRequest, responseRecorder.Code, query)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%s%s?address=%s", api.Addr(), EventsPath, mockAddress), nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	})
+}
+
+func TestBridgeEventsHandlerStream(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &newDepositBridgeTransfersView{}, Blocks: newMockBlocksView()},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, api.Stop(context.Background())) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("http://%s%s?address=%s", api.Addr(), EventsPath, mockAddress), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The deposit indexed after subscribing is streamed by the next poll
+	scanner := bufio.NewScanner(resp.Body)
+	var eventType, data string
+	for scanner.Scan() && (eventType == "" || data == "") {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "event: "); ok {
+			eventType = value
+		} else if value, ok := strings.CutPrefix(line, "data: "); ok {
+			data = value
+		}
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, string(models.DepositCreatedEvent), eventType)
 
+	var item models.DepositItem
+	require.NoError(t, json.Unmarshal([]byte(data), &item))
+	require.Equal(t, common.HexToHash("0xdef").String(), item.Guid)
 }
@@ -13,6 +13,7 @@ import (
 // DB represents the abstract DB access the API has.
 type DB struct {
 	BridgeTransfers database.BridgeTransfersView
+	Blocks          database.BlocksView
 	Closer          func() error
 }
 
@@ -28,17 +29,20 @@ func (cfg *DBConfigConnector) OpenDB(ctx context.Context, log log.Logger) (*DB,
 	}
 	return &DB{
 		BridgeTransfers: db.BridgeTransfers,
+		Blocks:          db.Blocks,
 		Closer:          db.Close,
 	}, nil
 }
 
 type TestDBConnector struct {
 	BridgeTransfers database.BridgeTransfersView
+	Blocks          database.BlocksView
 }
 
 func (tdb *TestDBConnector) OpenDB(ctx context.Context, log log.Logger) (*DB, error) {
 	return &DB{
 		BridgeTransfers: tdb.BridgeTransfers,
+		Blocks:          tdb.Blocks,
 		Closer: func() error {
 			log.Info("API service closed test DB view")
 			return nil
@@ -57,4 +61,7 @@ type Config struct {
 	DB            DBConnector
 	HTTPServer    config.ServerConfig
 	MetricsServer config.ServerConfig
+
+	// FinalizationPeriodSeconds is the challenge window of proven withdrawals
+	FinalizationPeriodSeconds uint64
 }
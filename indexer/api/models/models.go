@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math/big"
+
 	"github.com/BLASTchain/blast/indexer/database"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -26,20 +28,147 @@ type DepositResponse struct {
 	Items       []DepositItem `json:"items"`
 }
 
+// ERC721DepositItem ... ERC721 deposit item model for API responses
+type ERC721DepositItem struct {
+	Guid           string `json:"guid"`
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Timestamp      uint64 `json:"timestamp"`
+	L1BlockHash    string `json:"l1BlockHash"`
+	L1TxHash       string `json:"l1TxHash"`
+	L2TxHash       string `json:"l2TxHash"`
+	TokenId        string `json:"tokenId"`
+	L1TokenAddress string `json:"l1TokenAddress"`
+	L2TokenAddress string `json:"l2TokenAddress"`
+}
+
+// ERC721DepositResponse ... Data model for API JSON response
+type ERC721DepositResponse struct {
+	Cursor      string              `json:"cursor"`
+	HasNextPage bool                `json:"hasNextPage"`
+	Items       []ERC721DepositItem `json:"items"`
+}
+
 // WithdrawalItem ... Data model for API JSON response
 type WithdrawalItem struct {
-	Guid                   string `json:"guid"`
-	From                   string `json:"from"`
-	To                     string `json:"to"`
-	TransactionHash        string `json:"transactionHash"`
-	CrossDomainMessageHash string `json:"crossDomainMessageHash"`
-	Timestamp              uint64 `json:"timestamp"`
-	L2BlockHash            string `json:"l2BlockHash"`
-	Amount                 string `json:"amount"`
-	L1ProvenTxHash         string `json:"l1ProvenTxHash"`
-	L1FinalizedTxHash      string `json:"l1FinalizedTxHash"`
-	L1TokenAddress         string `json:"l1TokenAddress"`
-	L2TokenAddress         string `json:"l2TokenAddress"`
+	Guid                   string           `json:"guid"`
+	From                   string           `json:"from"`
+	To                     string           `json:"to"`
+	TransactionHash        string           `json:"transactionHash"`
+	CrossDomainMessageHash string           `json:"crossDomainMessageHash"`
+	Timestamp              uint64           `json:"timestamp"`
+	L2BlockHash            string           `json:"l2BlockHash"`
+	Amount                 string           `json:"amount"`
+	L1ProvenTxHash         string           `json:"l1ProvenTxHash"`
+	L1FinalizedTxHash      string           `json:"l1FinalizedTxHash"`
+	L1TokenAddress         string           `json:"l1TokenAddress"`
+	L2TokenAddress         string           `json:"l2TokenAddress"`
+	Status                 WithdrawalStatus `json:"status"`
+}
+
+// ERC721WithdrawalItem ... Data model for API JSON response
+type ERC721WithdrawalItem struct {
+	Guid                   string           `json:"guid"`
+	From                   string           `json:"from"`
+	To                     string           `json:"to"`
+	TransactionHash        string           `json:"transactionHash"`
+	CrossDomainMessageHash string           `json:"crossDomainMessageHash"`
+	Timestamp              uint64           `json:"timestamp"`
+	L2BlockHash            string           `json:"l2BlockHash"`
+	TokenId                string           `json:"tokenId"`
+	L1ProvenTxHash         string           `json:"l1ProvenTxHash"`
+	L1FinalizedTxHash      string           `json:"l1FinalizedTxHash"`
+	L1TokenAddress         string           `json:"l1TokenAddress"`
+	L2TokenAddress         string           `json:"l2TokenAddress"`
+	Status                 WithdrawalStatus `json:"status"`
+}
+
+// ERC721WithdrawalResponse ... Data model for API JSON response
+type ERC721WithdrawalResponse struct {
+	Cursor      string                 `json:"cursor"`
+	HasNextPage bool                   `json:"hasNextPage"`
+	Items       []ERC721WithdrawalItem `json:"items"`
+}
+
+// WithdrawalState ... Lifecycle state of a withdrawal
+type WithdrawalState string
+
+const (
+	// WithdrawalInitiated ... The withdrawal was initiated on L2, but the L1 chain is not indexed up to it yet
+	WithdrawalInitiated WithdrawalState = "initiated"
+	// WithdrawalWaitingForOutputRoot ... No output root including the withdrawal has been proposed yet
+	WithdrawalWaitingForOutputRoot WithdrawalState = "waiting_for_output_root"
+	// WithdrawalReadyToProve ... An output root including the withdrawal has been proposed
+	WithdrawalReadyToProve WithdrawalState = "ready_to_prove"
+	// WithdrawalInChallengeWindow ... The withdrawal was proven, and the finalization period has not passed yet
+	WithdrawalInChallengeWindow WithdrawalState = "in_challenge_window"
+	// WithdrawalReadyToFinalize ... The withdrawal was proven, and the finalization period has passed
+	WithdrawalReadyToFinalize WithdrawalState = "ready_to_finalize"
+	// WithdrawalFinalized ... The withdrawal was finalized on L1
+	WithdrawalFinalized WithdrawalState = "finalized"
+)
+
+// WithdrawalStatus ... Data model for API JSON response
+type WithdrawalStatus struct {
+	State WithdrawalState `json:"state"`
+	// FinalizableAt is the timestamp at which a proven withdrawal can be finalized
+	FinalizableAt uint64 `json:"finalizableAt,omitempty"`
+	// Succeeded is whether the relay of a finalized withdrawal was successful
+	Succeeded *bool `json:"succeeded,omitempty"`
+}
+
+// WithdrawalStatusResponse ... Data model for API JSON response
+type WithdrawalStatusResponse struct {
+	Guid            string           `json:"guid"`
+	TransactionHash string           `json:"transactionHash"`
+	Status          WithdrawalStatus `json:"status"`
+}
+
+// WithdrawalStatusContext ... The indexed state of the chains that the lifecycle of withdrawals is computed against
+type WithdrawalStatusContext struct {
+	// LatestL1Timestamp is the timestamp of the latest indexed L1 block
+	LatestL1Timestamp uint64
+	// LatestProposedL2BlockNumber is the L2 block of the latest proposed output root, nil if there is none
+	LatestProposedL2BlockNumber *big.Int
+	// FinalizationPeriodSeconds is the challenge window of proven withdrawals
+	FinalizationPeriodSeconds uint64
+	// Now is the current unix time in seconds
+	Now uint64
+}
+
+// Status ... Computes the lifecycle state of the withdrawal
+func (c WithdrawalStatusContext) Status(withdrawal database.L2BridgeWithdrawalWithTransactionHashes) WithdrawalStatus {
+	return c.status(withdrawal.L2BridgeWithdrawal.Tx.Timestamp, withdrawal.L2BlockNumber, withdrawal.ProvenL1Timestamp, withdrawal.FinalizedL1Timestamp, withdrawal.Succeeded)
+}
+
+// ERC721Status ... Computes the lifecycle state of the ERC721 withdrawal
+func (c WithdrawalStatusContext) ERC721Status(withdrawal database.L2ERC721BridgeWithdrawalWithTransactionHashes) WithdrawalStatus {
+	return c.status(withdrawal.L2ERC721BridgeWithdrawal.Timestamp, withdrawal.L2BlockNumber, withdrawal.ProvenL1Timestamp, withdrawal.FinalizedL1Timestamp, withdrawal.Succeeded)
+}
+
+func (c WithdrawalStatusContext) status(timestamp uint64, l2BlockNumber *big.Int, provenL1Timestamp, finalizedL1Timestamp *uint64, succeeded *bool) WithdrawalStatus {
+	if finalizedL1Timestamp != nil {
+		return WithdrawalStatus{State: WithdrawalFinalized, Succeeded: succeeded}
+	}
+
+	if provenL1Timestamp != nil {
+		finalizableAt := *provenL1Timestamp + c.FinalizationPeriodSeconds
+		if c.Now > finalizableAt {
+			return WithdrawalStatus{State: WithdrawalReadyToFinalize, FinalizableAt: finalizableAt}
+		}
+		return WithdrawalStatus{State: WithdrawalInChallengeWindow, FinalizableAt: finalizableAt}
+	}
+
+	if l2BlockNumber != nil && c.LatestProposedL2BlockNumber != nil && c.LatestProposedL2BlockNumber.Cmp(l2BlockNumber) >= 0 {
+		return WithdrawalStatus{State: WithdrawalReadyToProve}
+	}
+
+	// Output roots proposed after the withdrawal cannot be known of until L1 is indexed past it
+	if l2BlockNumber == nil || c.LatestL1Timestamp < timestamp {
+		return WithdrawalStatus{State: WithdrawalInitiated}
+	}
+
+	return WithdrawalStatus{State: WithdrawalWaitingForOutputRoot}
 }
 
 // WithdrawalResponse ... Data model for API JSON response
@@ -49,42 +178,176 @@ type WithdrawalResponse struct {
 	Items       []WithdrawalItem `json:"items"`
 }
 
+// BridgeEventType ... Type of a bridge event pushed to subscribers
+type BridgeEventType string
+
+const (
+	// DepositCreatedEvent ... A deposit of a subscribed address was indexed. The data is a DepositItem
+	DepositCreatedEvent BridgeEventType = "deposit"
+	// WithdrawalCreatedEvent ... A withdrawal of a subscribed address was indexed. The data is a WithdrawalItem
+	WithdrawalCreatedEvent BridgeEventType = "withdrawal"
+	// WithdrawalStatusEvent ... A withdrawal of a subscribed address changed state. The data is a WithdrawalItem
+	WithdrawalStatusEvent BridgeEventType = "withdrawal_status"
+)
+
 type BridgeSupplyView struct {
 	L1DepositSum    float64 `json:"l1DepositSum"`
 	L2WithdrawalSum float64 `json:"l2WithdrawalSum"`
 }
 
+// TokenSupplyItem ... Data model for API JSON response
+type TokenSupplyItem struct {
+	L1TokenAddress string  `json:"l1TokenAddress"`
+	L2TokenAddress string  `json:"l2TokenAddress"`
+	DepositSum     float64 `json:"depositSum"`
+	WithdrawalSum  float64 `json:"withdrawalSum"`
+	ValueLocked    float64 `json:"valueLocked"`
+}
+
+// TokenSupplyView ... Data model for API JSON response
+type TokenSupplyView struct {
+	Items []TokenSupplyItem `json:"items"`
+}
+
+// BridgedTokenItem ... Data model for API JSON response
+type BridgedTokenItem struct {
+	L1TokenAddress string `json:"l1TokenAddress"`
+	L2TokenAddress string `json:"l2TokenAddress"`
+	Standard       string `json:"standard"`
+}
+
+// BridgedTokensResponse ... Data model for API JSON response
+type BridgedTokensResponse struct {
+	Items []BridgedTokenItem `json:"items"`
+}
+
+// CreateWithdrawalItem ... Converts a withdrawal to an api.WithdrawalItem
+func CreateWithdrawalItem(withdrawal database.L2BridgeWithdrawalWithTransactionHashes, statusCtx WithdrawalStatusContext) WithdrawalItem {
+	cdh := withdrawal.L2BridgeWithdrawal.CrossDomainMessageHash
+	if cdh == nil { // Zero value indicates that the withdrawal didn't have a cross domain message
+		cdh = &common.Hash{0}
+	}
+
+	return WithdrawalItem{
+		Guid:                   withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+		L2BlockHash:            withdrawal.L2BlockHash.String(),
+		Timestamp:              withdrawal.L2BridgeWithdrawal.Tx.Timestamp,
+		From:                   withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
+		To:                     withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
+		TransactionHash:        withdrawal.L2TransactionHash.String(),
+		Amount:                 withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
+		CrossDomainMessageHash: cdh.String(),
+		L1ProvenTxHash:         withdrawal.ProvenL1TransactionHash.String(),
+		L1FinalizedTxHash:      withdrawal.FinalizedL1TransactionHash.String(),
+		L1TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+		L2TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+		Status:                 statusCtx.Status(withdrawal),
+	}
+}
+
 // FIXME make a pure function that returns a struct instead of newWithdrawalResponse
 // newWithdrawalResponse ... Converts a database.L2BridgeWithdrawalsResponse to an api.WithdrawalResponse
-func CreateWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse) WithdrawalResponse {
+func CreateWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse, statusCtx WithdrawalStatusContext) WithdrawalResponse {
 	items := make([]WithdrawalItem, len(withdrawals.Withdrawals))
 	for i, withdrawal := range withdrawals.Withdrawals {
+		items[i] = CreateWithdrawalItem(withdrawal, statusCtx)
+	}
+
+	return WithdrawalResponse{
+		Cursor:      withdrawals.Cursor,
+		HasNextPage: withdrawals.HasNextPage,
+		Items:       items,
+	}
+}
 
-		cdh := withdrawal.L2BridgeWithdrawal.CrossDomainMessageHash
-		if cdh == nil { // Zero value indicates that the withdrawal didn't have a cross domain message
-			cdh = &common.Hash{0}
+// CreateERC721DepositResponse ... Converts a database.L1ERC721BridgeDepositsResponse to an api.ERC721DepositResponse
+func CreateERC721DepositResponse(deposits *database.L1ERC721BridgeDepositsResponse) ERC721DepositResponse {
+	items := make([]ERC721DepositItem, len(deposits.Deposits))
+	for i, deposit := range deposits.Deposits {
+		items[i] = ERC721DepositItem{
+			Guid:           deposit.L1ERC721BridgeDeposit.TransactionSourceHash.String(),
+			L1BlockHash:    deposit.L1BlockHash.String(),
+			Timestamp:      deposit.L1ERC721BridgeDeposit.Timestamp,
+			L1TxHash:       deposit.L1TransactionHash.String(),
+			L2TxHash:       deposit.L2TransactionHash.String(),
+			From:           deposit.L1ERC721BridgeDeposit.FromAddress.String(),
+			To:             deposit.L1ERC721BridgeDeposit.ToAddress.String(),
+			TokenId:        deposit.L1ERC721BridgeDeposit.TokenID.String(),
+			L1TokenAddress: deposit.L1ERC721BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+			L2TokenAddress: deposit.L1ERC721BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
 		}
+	}
 
-		item := WithdrawalItem{
-			Guid:                   withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+	return ERC721DepositResponse{
+		Cursor:      deposits.Cursor,
+		HasNextPage: deposits.HasNextPage,
+		Items:       items,
+	}
+}
+
+// CreateERC721WithdrawalResponse ... Converts a database.L2ERC721BridgeWithdrawalsResponse to an api.ERC721WithdrawalResponse
+func CreateERC721WithdrawalResponse(withdrawals *database.L2ERC721BridgeWithdrawalsResponse, statusCtx WithdrawalStatusContext) ERC721WithdrawalResponse {
+	items := make([]ERC721WithdrawalItem, len(withdrawals.Withdrawals))
+	for i, withdrawal := range withdrawals.Withdrawals {
+		items[i] = ERC721WithdrawalItem{
+			Guid:                   withdrawal.L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String(),
 			L2BlockHash:            withdrawal.L2BlockHash.String(),
-			Timestamp:              withdrawal.L2BridgeWithdrawal.Tx.Timestamp,
-			From:                   withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
-			To:                     withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
+			Timestamp:              withdrawal.L2ERC721BridgeWithdrawal.Timestamp,
+			From:                   withdrawal.L2ERC721BridgeWithdrawal.FromAddress.String(),
+			To:                     withdrawal.L2ERC721BridgeWithdrawal.ToAddress.String(),
 			TransactionHash:        withdrawal.L2TransactionHash.String(),
-			Amount:                 withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
-			CrossDomainMessageHash: cdh.String(),
+			TokenId:                withdrawal.L2ERC721BridgeWithdrawal.TokenID.String(),
+			CrossDomainMessageHash: withdrawal.L2ERC721BridgeWithdrawal.CrossDomainMessageHash.String(),
 			L1ProvenTxHash:         withdrawal.ProvenL1TransactionHash.String(),
 			L1FinalizedTxHash:      withdrawal.FinalizedL1TransactionHash.String(),
-			L1TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
-			L2TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+			L1TokenAddress:         withdrawal.L2ERC721BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+			L2TokenAddress:         withdrawal.L2ERC721BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+			Status:                 statusCtx.ERC721Status(withdrawal),
 		}
-		items[i] = item
 	}
 
-	return WithdrawalResponse{
+	return ERC721WithdrawalResponse{
 		Cursor:      withdrawals.Cursor,
 		HasNextPage: withdrawals.HasNextPage,
 		Items:       items,
 	}
 }
+
+// CreateWithdrawalStatusResponse ... Converts a withdrawal to an api.WithdrawalStatusResponse
+func CreateWithdrawalStatusResponse(withdrawal *database.L2BridgeWithdrawalWithTransactionHashes, statusCtx WithdrawalStatusContext) WithdrawalStatusResponse {
+	return WithdrawalStatusResponse{
+		Guid:            withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+		TransactionHash: withdrawal.L2TransactionHash.String(),
+		Status:          statusCtx.Status(*withdrawal),
+	}
+}
+
+// CreateTokenSupplyView ... Converts the value locked of each token pair to an api.TokenSupplyView
+func CreateTokenSupplyView(tokens []database.TokenValueLocked) TokenSupplyView {
+	items := make([]TokenSupplyItem, len(tokens))
+	for i, token := range tokens {
+		items[i] = TokenSupplyItem{
+			L1TokenAddress: token.TokenPair.LocalTokenAddress.String(),
+			L2TokenAddress: token.TokenPair.RemoteTokenAddress.String(),
+			DepositSum:     token.DepositSum,
+			WithdrawalSum:  token.WithdrawalSum,
+			ValueLocked:    token.DepositSum - token.WithdrawalSum,
+		}
+	}
+
+	return TokenSupplyView{Items: items}
+}
+
+// CreateBridgedTokensResponse ... Converts the bridged tokens to an api.BridgedTokensResponse
+func CreateBridgedTokensResponse(tokens []database.BridgedToken) BridgedTokensResponse {
+	items := make([]BridgedTokenItem, len(tokens))
+	for i, token := range tokens {
+		items[i] = BridgedTokenItem{
+			L1TokenAddress: token.L1TokenAddress.String(),
+			L2TokenAddress: token.L2TokenAddress.String(),
+			Standard:       token.Standard,
+		}
+	}
+
+	return BridgedTokensResponse{Items: items}
+}
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -39,7 +40,7 @@ func TestCreateWithdrawal(t *testing.T) {
 
 	// (2) Create and validate response object
 
-	response := models.CreateWithdrawalResponse(dbWithdrawals)
+	response := models.CreateWithdrawalResponse(dbWithdrawals, models.WithdrawalStatusContext{})
 	require.NotEmpty(t, response.Items)
 	require.Len(t, response.Items, 1)
 
@@ -62,3 +63,73 @@ func TestCreateWithdrawal(t *testing.T) {
 	}
 
 }
+
+func TestWithdrawalStatus(t *testing.T) {
+	proven, finalized, succeeded := uint64(100), uint64(200), false
+	statusCtx := models.WithdrawalStatusContext{
+		LatestL1Timestamp:           50,
+		LatestProposedL2BlockNumber: big.NewInt(10),
+		FinalizationPeriodSeconds:   60,
+		Now:                         150,
+	}
+
+	withdrawalAt := func(timestamp uint64, l2BlockNumber *big.Int) database.L2BridgeWithdrawalWithTransactionHashes {
+		return database.L2BridgeWithdrawalWithTransactionHashes{
+			L2BridgeWithdrawal: database.L2BridgeWithdrawal{
+				BridgeTransfer: database.BridgeTransfer{Tx: database.Transaction{Timestamp: timestamp}},
+			},
+			L2BlockNumber: l2BlockNumber,
+		}
+	}
+
+	t.Run("initiated", func(t *testing.T) {
+		status := statusCtx.Status(withdrawalAt(60, big.NewInt(11)))
+		require.Equal(t, models.WithdrawalInitiated, status.State)
+
+		status = statusCtx.Status(withdrawalAt(40, nil))
+		require.Equal(t, models.WithdrawalInitiated, status.State)
+	})
+
+	t.Run("waiting for output root", func(t *testing.T) {
+		status := statusCtx.Status(withdrawalAt(40, big.NewInt(11)))
+		require.Equal(t, models.WithdrawalWaitingForOutputRoot, status.State)
+
+		noProposals := statusCtx
+		noProposals.LatestProposedL2BlockNumber = nil
+		status = noProposals.Status(withdrawalAt(40, big.NewInt(5)))
+		require.Equal(t, models.WithdrawalWaitingForOutputRoot, status.State)
+	})
+
+	t.Run("ready to prove", func(t *testing.T) {
+		status := statusCtx.Status(withdrawalAt(40, big.NewInt(10)))
+		require.Equal(t, models.WithdrawalReadyToProve, status.State)
+	})
+
+	t.Run("in challenge window", func(t *testing.T) {
+		withdrawal := withdrawalAt(40, big.NewInt(10))
+		withdrawal.ProvenL1Timestamp = &proven
+		status := statusCtx.Status(withdrawal)
+		require.Equal(t, models.WithdrawalInChallengeWindow, status.State)
+		require.Equal(t, uint64(160), status.FinalizableAt)
+	})
+
+	t.Run("ready to finalize", func(t *testing.T) {
+		withdrawal := withdrawalAt(40, big.NewInt(10))
+		withdrawal.ProvenL1Timestamp = &proven
+		later := statusCtx
+		later.Now = 161
+		status := later.Status(withdrawal)
+		require.Equal(t, models.WithdrawalReadyToFinalize, status.State)
+		require.Equal(t, uint64(160), status.FinalizableAt)
+	})
+
+	t.Run("finalized", func(t *testing.T) {
+		withdrawal := withdrawalAt(40, big.NewInt(10))
+		withdrawal.ProvenL1Timestamp = &proven
+		withdrawal.FinalizedL1Timestamp = &finalized
+		withdrawal.Succeeded = &succeeded
+		status := statusCtx.Status(withdrawal)
+		require.Equal(t, models.WithdrawalFinalized, status.State)
+		require.False(t, *status.Succeeded)
+	})
+}
@@ -9,23 +9,27 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// newDepositItem ... Converts a database.L1BridgeDepositWithTransactionHashes to an api.DepositItem
+func newDepositItem(deposit database.L1BridgeDepositWithTransactionHashes) models.DepositItem {
+	return models.DepositItem{
+		Guid:           deposit.L1BridgeDeposit.TransactionSourceHash.String(),
+		L1BlockHash:    deposit.L1BlockHash.String(),
+		Timestamp:      deposit.L1BridgeDeposit.Tx.Timestamp,
+		L1TxHash:       deposit.L1TransactionHash.String(),
+		L2TxHash:       deposit.L2TransactionHash.String(),
+		From:           deposit.L1BridgeDeposit.Tx.FromAddress.String(),
+		To:             deposit.L1BridgeDeposit.Tx.ToAddress.String(),
+		Amount:         deposit.L1BridgeDeposit.Tx.Amount.String(),
+		L1TokenAddress: deposit.L1BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+		L2TokenAddress: deposit.L1BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
+	}
+}
+
 // newDepositResponse ... Converts a database.L1BridgeDepositsResponse to an api.DepositResponse
 func newDepositResponse(deposits *database.L1BridgeDepositsResponse) models.DepositResponse {
 	items := make([]models.DepositItem, len(deposits.Deposits))
 	for i, deposit := range deposits.Deposits {
-		item := models.DepositItem{
-			Guid:           deposit.L1BridgeDeposit.TransactionSourceHash.String(),
-			L1BlockHash:    deposit.L1BlockHash.String(),
-			Timestamp:      deposit.L1BridgeDeposit.Tx.Timestamp,
-			L1TxHash:       deposit.L1TransactionHash.String(),
-			L2TxHash:       deposit.L2TransactionHash.String(),
-			From:           deposit.L1BridgeDeposit.Tx.FromAddress.String(),
-			To:             deposit.L1BridgeDeposit.Tx.ToAddress.String(),
-			Amount:         deposit.L1BridgeDeposit.Tx.Amount.String(),
-			L1TokenAddress: deposit.L1BridgeDeposit.TokenPair.LocalTokenAddress.String(),
-			L2TokenAddress: deposit.L1BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
-		}
-		items[i] = item
+		items[i] = newDepositItem(deposit)
 	}
 
 	return models.DepositResponse{
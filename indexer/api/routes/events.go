@@ -0,0 +1,367 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/BLASTchain/blast/indexer/api/models"
+	"github.com/BLASTchain/blast/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// maxEventAddresses ... Maximum number of addresses a single events stream can subscribe to
+	maxEventAddresses = 10
+
+	// defaultEventsPollInterval ... Interval at which the bridge state of the subscribed addresses is polled
+	defaultEventsPollInterval = 2 * time.Second
+
+	// eventsKeepAliveInterval ... Interval at which a comment is sent on an idle stream, to keep it open
+	eventsKeepAliveInterval = 15 * time.Second
+
+	// defaultMaxEventStreams ... Maximum number of concurrent events streams
+	defaultMaxEventStreams = 100
+
+	// eventsSubscriberBuffer ... Number of polls with events buffered per stream. A stream that falls further behind is closed
+	eventsSubscriberBuffer = 16
+)
+
+var errTooManyEventStreams = errors.New("too many events streams")
+
+// bridgeEvent ... A bridge event pushed to subscribers as a server-sent event
+type bridgeEvent struct {
+	Type models.BridgeEventType
+	Data interface{}
+}
+
+// bridgeEventTracker ... Tracks the latest bridge state of the subscribed addresses, to detect the
+// deposits & withdrawals indexed since, as well as the withdrawals that changed state
+type bridgeEventTracker struct {
+	initialized bool
+	deposits    map[common.Hash]struct{}
+	withdrawals map[common.Hash]models.WithdrawalState
+}
+
+// update ... Diffs the latest deposits & withdrawals of the subscribed addresses with the previous update. The
+// first update only records the state, as it is available through the paginated endpoints.
+func (t *bridgeEventTracker) update(deposits []database.L1BridgeDepositWithTransactionHashes, withdrawals []database.L2BridgeWithdrawalWithTransactionHashes, statusCtx models.WithdrawalStatusContext) []bridgeEvent {
+	var events []bridgeEvent
+
+	// deposits & withdrawals between subscribed addresses are listed for both
+	latestDeposits := make(map[common.Hash]struct{}, len(deposits))
+	for i := len(deposits) - 1; i >= 0; i-- { // oldest first
+		hash := deposits[i].L1BridgeDeposit.TransactionSourceHash
+		if _, ok := latestDeposits[hash]; ok {
+			continue
+		}
+		latestDeposits[hash] = struct{}{}
+		if _, ok := t.deposits[hash]; !ok && t.initialized {
+			events = append(events, bridgeEvent{Type: models.DepositCreatedEvent, Data: newDepositItem(deposits[i])})
+		}
+	}
+
+	latestWithdrawals := make(map[common.Hash]models.WithdrawalState, len(withdrawals))
+	for i := len(withdrawals) - 1; i >= 0; i-- { // oldest first
+		hash := withdrawals[i].L2BridgeWithdrawal.TransactionWithdrawalHash
+		if _, ok := latestWithdrawals[hash]; ok {
+			continue
+		}
+		item := models.CreateWithdrawalItem(withdrawals[i], statusCtx)
+		latestWithdrawals[hash] = item.Status.State
+		if state, ok := t.withdrawals[hash]; !ok && t.initialized {
+			events = append(events, bridgeEvent{Type: models.WithdrawalCreatedEvent, Data: item})
+		} else if ok && state != item.Status.State {
+			events = append(events, bridgeEvent{Type: models.WithdrawalStatusEvent, Data: item})
+		}
+	}
+
+	// Only the latest page of each address is tracked. Older entries are no longer indexed, nor change state
+	t.initialized = true
+	t.deposits = latestDeposits
+	t.withdrawals = latestWithdrawals
+	return events
+}
+
+// bridgeEventsSubscriber ... An events stream, with the tracked bridge state of its addresses
+type bridgeEventsSubscriber struct {
+	addresses []common.Address
+	tracker   *bridgeEventTracker
+	// events receives the events of each poll, and is closed if the stream falls behind
+	events chan []bridgeEvent
+}
+
+// bridgeEventsHub ... Polls the bridge state of the subscribed addresses for all events streams at once, such that
+// the load on the database does not grow with the number of streams subscribed to the same addresses
+type bridgeEventsHub struct {
+	pollInterval time.Duration
+	maxStreams   int
+
+	mu          sync.Mutex
+	subscribers map[*bridgeEventsSubscriber]struct{}
+	polling     bool
+}
+
+func newBridgeEventsHub() *bridgeEventsHub {
+	return &bridgeEventsHub{
+		pollInterval: defaultEventsPollInterval,
+		maxStreams:   defaultMaxEventStreams,
+		subscribers:  make(map[*bridgeEventsSubscriber]struct{}),
+	}
+}
+
+// remove ... Removes the subscriber, closing its events channel. Returns false if it was already removed
+func (hub *bridgeEventsHub) remove(sub *bridgeEventsSubscriber) bool {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if _, ok := hub.subscribers[sub]; !ok {
+		return false
+	}
+	delete(hub.subscribers, sub)
+	close(sub.events)
+	return true
+}
+
+// subscribeBridgeEvents ... Records the bridge state of the addresses, and subscribes to the events of later polls
+func (h Routes) subscribeBridgeEvents(addresses []common.Address) (*bridgeEventsSubscriber, error) {
+	hub := h.events
+	hub.mu.Lock()
+	full := len(hub.subscribers) >= hub.maxStreams
+	hub.mu.Unlock()
+	if full {
+		return nil, errTooManyEventStreams
+	}
+
+	state, err := h.readBridgeState(addresses)
+	if err != nil {
+		return nil, err
+	}
+	statusCtx, err := h.withdrawalStatusContext()
+	if err != nil {
+		return nil, err
+	}
+	sub := &bridgeEventsSubscriber{
+		addresses: addresses,
+		tracker:   &bridgeEventTracker{},
+		events:    make(chan []bridgeEvent, eventsSubscriberBuffer),
+	}
+	sub.tracker.update(state.deposits(addresses), state.withdrawals(addresses), statusCtx)
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	if len(hub.subscribers) >= hub.maxStreams {
+		return nil, errTooManyEventStreams
+	}
+	hub.subscribers[sub] = struct{}{}
+	if !hub.polling {
+		hub.polling = true
+		go h.pollBridgeEvents()
+	}
+	return sub, nil
+}
+
+// pollBridgeEvents ... Polls the bridge state of the subscribed addresses, and fans out the events to the
+// subscribers, until there are no subscribers left
+func (h Routes) pollBridgeEvents() {
+	hub := h.events
+	ticker := time.NewTicker(hub.pollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		hub.mu.Lock()
+		if len(hub.subscribers) == 0 {
+			hub.polling = false
+			hub.mu.Unlock()
+			return
+		}
+		subscribers := make([]*bridgeEventsSubscriber, 0, len(hub.subscribers))
+		addresses := make([]common.Address, 0, len(hub.subscribers))
+		subscribed := make(map[common.Address]bool)
+		for sub := range hub.subscribers {
+			subscribers = append(subscribers, sub)
+			for _, address := range sub.addresses {
+				if !subscribed[address] {
+					subscribed[address] = true
+					addresses = append(addresses, address)
+				}
+			}
+		}
+		hub.mu.Unlock()
+
+		state, err := h.readBridgeState(addresses)
+		if err != nil {
+			h.logger.Error("Unable to read bridge state from DB", "err", err.Error())
+			continue
+		}
+		statusCtx, err := h.withdrawalStatusContext()
+		if err != nil {
+			h.logger.Error("Unable to read withdrawal status context from DB", "err", err.Error())
+			continue
+		}
+
+		// The trackers are only updated by the poller, once subscribed
+		for _, sub := range subscribers {
+			events := sub.tracker.update(state.deposits(sub.addresses), state.withdrawals(sub.addresses), statusCtx)
+			if len(events) == 0 {
+				continue
+			}
+			hub.mu.Lock()
+			if _, ok := hub.subscribers[sub]; ok {
+				select {
+				case sub.events <- events:
+				default:
+					delete(hub.subscribers, sub)
+					close(sub.events)
+				}
+			}
+			hub.mu.Unlock()
+		}
+	}
+}
+
+// bridgeState ... The latest deposits & withdrawals of a set of addresses
+type bridgeState struct {
+	depositsByAddress    map[common.Address][]database.L1BridgeDepositWithTransactionHashes
+	withdrawalsByAddress map[common.Address][]database.L2BridgeWithdrawalWithTransactionHashes
+}
+
+func (s bridgeState) deposits(addresses []common.Address) []database.L1BridgeDepositWithTransactionHashes {
+	var deposits []database.L1BridgeDepositWithTransactionHashes
+	for _, address := range addresses {
+		deposits = append(deposits, s.depositsByAddress[address]...)
+	}
+	return deposits
+}
+
+func (s bridgeState) withdrawals(addresses []common.Address) []database.L2BridgeWithdrawalWithTransactionHashes {
+	var withdrawals []database.L2BridgeWithdrawalWithTransactionHashes
+	for _, address := range addresses {
+		withdrawals = append(withdrawals, s.withdrawalsByAddress[address]...)
+	}
+	return withdrawals
+}
+
+// readBridgeState ... Reads the latest page of deposits & withdrawals of each address
+func (h Routes) readBridgeState(addresses []common.Address) (bridgeState, error) {
+	state := bridgeState{
+		depositsByAddress:    make(map[common.Address][]database.L1BridgeDepositWithTransactionHashes, len(addresses)),
+		withdrawalsByAddress: make(map[common.Address][]database.L2BridgeWithdrawalWithTransactionHashes, len(addresses)),
+	}
+	for _, address := range addresses {
+		depositsResponse, err := h.view.L1BridgeDepositsByAddress(address, "", defaultPageLimit)
+		if err != nil {
+			return bridgeState{}, fmt.Errorf("failed to read deposits: %w", err)
+		}
+		state.depositsByAddress[address] = depositsResponse.Deposits
+
+		withdrawalsResponse, err := h.view.L2BridgeWithdrawalsByAddress(address, "", defaultPageLimit)
+		if err != nil {
+			return bridgeState{}, fmt.Errorf("failed to read withdrawals: %w", err)
+		}
+		state.withdrawalsByAddress[address] = withdrawalsResponse.Withdrawals
+	}
+	return state, nil
+}
+
+// writeBridgeEvent ... Writes a server-sent event
+func writeBridgeEvent(w http.ResponseWriter, event bridgeEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// BridgeEventsHandler ... Handles /api/v0/events?address={address} GET requests, streaming the deposits & withdrawals
+// of up to 10 addresses, and the state transitions of the withdrawals, as server-sent events
+func (h Routes) BridgeEventsHandler(w http.ResponseWriter, r *http.Request) {
+	addressValues := r.URL.Query()["address"]
+	if len(addressValues) == 0 || len(addressValues) > maxEventAddresses {
+		http.Error(w, fmt.Sprintf("between 1 and %d addresses must be subscribed to", maxEventAddresses), http.StatusBadRequest)
+		h.logger.Error("Invalid address params", "count", len(addressValues))
+		return
+	}
+
+	addresses := make([]common.Address, 0, len(addressValues))
+	subscribed := make(map[common.Address]bool, len(addressValues))
+	for _, addressValue := range addressValues {
+		address, err := h.v.ParseValidateAddress(addressValue)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+			return
+		}
+		if !subscribed[address] {
+			subscribed[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+
+	sub, err := h.subscribeBridgeEvents(addresses)
+	if errors.Is(err, errTooManyEventStreams) {
+		http.Error(w, "Too many events streams", http.StatusServiceUnavailable)
+		h.logger.Warn("Rejected events stream", "err", err)
+		return
+	} else if err != nil {
+		http.Error(w, "Internal server error reading bridge state", http.StatusInternalServerError)
+		h.logger.Error("Unable to read bridge state from DB", "err", err.Error())
+		return
+	}
+	defer h.events.remove(sub)
+
+	// The stream is long-lived, and not subject to the write timeout of the server
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Error("Unable to clear write deadline", "err", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("Unable to flush events stream", "err", err)
+		return
+	}
+
+	keepAliveTicker := time.NewTicker(eventsKeepAliveInterval)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-keepAliveTicker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+		case events, ok := <-sub.events:
+			if !ok {
+				h.logger.Warn("Closed events stream that fell behind", "addresses", len(addresses))
+				return
+			}
+
+			for _, event := range events {
+				if err := writeBridgeEvent(w, event); err != nil {
+					h.logger.Error("Error writing bridge event", "err", err.Error())
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			keepAliveTicker.Reset(eventsKeepAliveInterval)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package routes
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/indexer/api/models"
+	"github.com/BLASTchain/blast/indexer/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeEventTracker(t *testing.T) {
+	tracker := &bridgeEventTracker{}
+	statusCtx := models.WithdrawalStatusContext{LatestL1Timestamp: 100, FinalizationPeriodSeconds: 12, Now: 100}
+
+	newDeposit := func(hash string) database.L1BridgeDepositWithTransactionHashes {
+		deposit := database.L1BridgeDepositWithTransactionHashes{}
+		deposit.L1BridgeDeposit.TransactionSourceHash = common.HexToHash(hash)
+		deposit.L1BridgeDeposit.Tx.Amount = big.NewInt(1)
+		return deposit
+	}
+	newWithdrawal := func(hash string) database.L2BridgeWithdrawalWithTransactionHashes {
+		withdrawal := database.L2BridgeWithdrawalWithTransactionHashes{L2BlockNumber: big.NewInt(10)}
+		withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash = common.HexToHash(hash)
+		withdrawal.L2BridgeWithdrawal.Tx.Amount = big.NewInt(1)
+		withdrawal.L2BridgeWithdrawal.Tx.Timestamp = 50
+		return withdrawal
+	}
+
+	// (1) The initial state is only recorded
+	deposits := []database.L1BridgeDepositWithTransactionHashes{newDeposit("0x1")}
+	withdrawals := []database.L2BridgeWithdrawalWithTransactionHashes{newWithdrawal("0x2")}
+	require.Empty(t, tracker.update(deposits, withdrawals, statusCtx))
+	require.Empty(t, tracker.update(deposits, withdrawals, statusCtx))
+
+	// (2) Newly indexed deposits & withdrawals, listed for both subscribed addresses
+	deposits = []database.L1BridgeDepositWithTransactionHashes{newDeposit("0x3"), newDeposit("0x1"), newDeposit("0x3")}
+	withdrawals = []database.L2BridgeWithdrawalWithTransactionHashes{newWithdrawal("0x4"), newWithdrawal("0x2")}
+	events := tracker.update(deposits, withdrawals, statusCtx)
+	require.Len(t, events, 2)
+	require.Equal(t, models.DepositCreatedEvent, events[0].Type)
+	require.Equal(t, common.HexToHash("0x3").String(), events[0].Data.(models.DepositItem).Guid)
+	require.Equal(t, models.WithdrawalCreatedEvent, events[1].Type)
+	require.Equal(t, common.HexToHash("0x4").String(), events[1].Data.(models.WithdrawalItem).Guid)
+	require.Equal(t, models.WithdrawalWaitingForOutputRoot, events[1].Data.(models.WithdrawalItem).Status.State)
+
+	// (3) A proposed output root changes the state of the withdrawals
+	statusCtx.LatestProposedL2BlockNumber = big.NewInt(10)
+	events = tracker.update(deposits, withdrawals, statusCtx)
+	require.Len(t, events, 2)
+	for _, event := range events {
+		require.Equal(t, models.WithdrawalStatusEvent, event.Type)
+		require.Equal(t, models.WithdrawalReadyToProve, event.Data.(models.WithdrawalItem).Status.State)
+	}
+
+	// (4) A proven withdrawal
+	provenTimestamp := uint64(100)
+	withdrawals[1].ProvenL1Timestamp = &provenTimestamp
+	events = tracker.update(deposits, withdrawals, statusCtx)
+	require.Len(t, events, 1)
+	require.Equal(t, models.WithdrawalStatusEvent, events[0].Type)
+	require.Equal(t, common.HexToHash("0x2").String(), events[0].Data.(models.WithdrawalItem).Guid)
+	require.Equal(t, models.WithdrawalInChallengeWindow, events[0].Data.(models.WithdrawalItem).Status.State)
+	require.Empty(t, tracker.update(deposits, withdrawals, statusCtx))
+}
+
+// countingBridgeTransfersView counts the deposit reads, and lists the deposits added by the test
+type countingBridgeTransfersView struct {
+	database.BridgeTransfersView
+
+	mu       sync.Mutex
+	reads    int
+	deposits []database.L1BridgeDepositWithTransactionHashes
+}
+
+func (v *countingBridgeTransfersView) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.reads++
+	return &database.L1BridgeDepositsResponse{Deposits: v.deposits}, nil
+}
+
+func (v *countingBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	return &database.L2BridgeWithdrawalsResponse{}, nil
+}
+
+func (v *countingBridgeTransfersView) addDeposit(hash string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	deposit := database.L1BridgeDepositWithTransactionHashes{}
+	deposit.L1BridgeDeposit.TransactionSourceHash = common.HexToHash(hash)
+	deposit.L1BridgeDeposit.Tx.Amount = big.NewInt(1)
+	v.deposits = append([]database.L1BridgeDepositWithTransactionHashes{deposit}, v.deposits...)
+}
+
+func (v *countingBridgeTransfersView) readCount() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.reads
+}
+
+func TestBridgeEventsHub(t *testing.T) {
+	blocks := new(database.MockBlocksView)
+	blocks.On("L1LatestBlockHeader").Return(&database.L1BlockHeader{BlockHeader: database.BlockHeader{Number: big.NewInt(100), Timestamp: 2000}}, nil)
+	blocks.On("LatestOutputProposal").Return(&database.OutputProposal{L2BlockNumber: big.NewInt(20)}, nil)
+
+	view := &countingBridgeTransfersView{}
+	h := NewRoutes(testlog.Logger(t, log.LvlInfo), view, blocks, 12, nil)
+	h.events.pollInterval = 10 * time.Millisecond
+	h.events.maxStreams = 2
+
+	// (1) Streams are capped
+	addresses := []common.Address{common.HexToAddress("0x42")}
+	sub1, err := h.subscribeBridgeEvents(addresses)
+	require.NoError(t, err)
+	sub2, err := h.subscribeBridgeEvents(addresses)
+	require.NoError(t, err)
+	_, err = h.subscribeBridgeEvents(addresses)
+	require.ErrorIs(t, err, errTooManyEventStreams)
+	require.Equal(t, 2, view.readCount())
+
+	// (2) A single poll reads the shared address once, and fans out the events to both streams
+	view.addDeposit("0x1")
+	for _, sub := range []*bridgeEventsSubscriber{sub1, sub2} {
+		events := <-sub.events
+		require.Len(t, events, 1)
+		require.Equal(t, models.DepositCreatedEvent, events[0].Type)
+	}
+	require.True(t, h.events.remove(sub2))
+	require.False(t, h.events.remove(sub2))
+	_, ok := <-sub2.events
+	require.False(t, ok, "events channel is closed")
+
+	// (3) A stream that falls behind is closed
+	for i := 0; i < eventsSubscriberBuffer+1; i++ {
+		view.addDeposit(common.BigToHash(big.NewInt(int64(i + 2))).String())
+		reads := view.readCount()
+		require.Eventually(t, func() bool { return view.readCount() > reads }, time.Second, time.Millisecond)
+	}
+	require.Eventually(t, func() bool {
+		h.events.mu.Lock()
+		defer h.events.mu.Unlock()
+		_, ok := h.events.subscribers[sub1]
+		return !ok
+	}, time.Second, time.Millisecond)
+	require.False(t, h.events.remove(sub1))
+	require.Len(t, sub1.events, eventsSubscriberBuffer)
+
+	// (4) The poller stops without subscribers
+	require.Eventually(t, func() bool {
+		h.events.mu.Lock()
+		defer h.events.mu.Unlock()
+		return !h.events.polling
+	}, time.Second, time.Millisecond)
+
+	// The shared address was read once per poll, regardless of the number of streams
+	var statusReads int
+	for _, call := range blocks.Calls {
+		if call.Method == "LatestOutputProposal" {
+			statusReads++
+		}
+	}
+	require.Equal(t, statusReads, view.readCount())
+}
@@ -10,15 +10,25 @@ import (
 type Routes struct {
 	logger log.Logger
 	view   database.BridgeTransfersView
+	blocks database.BlocksView
 	router *chi.Mux
 	v      *Validator
+
+	// finalizationPeriodSeconds ... Challenge window of proven withdrawals
+	finalizationPeriodSeconds uint64
+
+	// events ... Polls the bridge state for the events streams
+	events *bridgeEventsHub
 }
 
 // NewRoutes ... Construct a new route handler instance
-func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, r *chi.Mux) Routes {
+func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, blocks database.BlocksView, finalizationPeriodSeconds uint64, r *chi.Mux) Routes {
 	return Routes{
-		logger: logger,
-		view:   bv,
-		router: r,
+		logger:                    logger,
+		view:                      bv,
+		blocks:                    blocks,
+		router:                    r,
+		finalizationPeriodSeconds: finalizationPeriodSeconds,
+		events:                    newBridgeEventsHub(),
 	}
 }
@@ -33,3 +33,20 @@ func (h Routes) SupplyView(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("error writing response", "err", err)
 	}
 }
+
+// TokenSupplyView ... Handles /api/v0/supply/tokens GET requests
+func (h Routes) TokenSupplyView(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.view.TokenValueLocked()
+	if err != nil {
+		http.Error(w, "internal server error reading token supply", http.StatusInternalServerError)
+		h.logger.Error("unable to read token supply from DB", "err", err.Error())
+		return
+	}
+
+	view := models.CreateTokenSupplyView(tokens)
+
+	err = jsonResponse(w, view, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
@@ -0,0 +1,181 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/BLASTchain/blast/indexer/api/models"
+	"github.com/BLASTchain/blast/indexer/database"
+	"github.com/go-chi/chi/v5"
+)
+
+// parseTokenPair ... Parses the L1 and L2 token address params of a token pair route
+func (h Routes) parseTokenPair(w http.ResponseWriter, r *http.Request) (database.TokenPair, bool) {
+	l1TokenValue := chi.URLParam(r, "l1Token")
+	l1Token, err := h.v.ParseValidateAddress(l1TokenValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid l1 token param", "param", l1TokenValue, "err", err)
+		return database.TokenPair{}, false
+	}
+
+	l2TokenValue := chi.URLParam(r, "l2Token")
+	l2Token, err := h.v.ParseValidateAddress(l2TokenValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid l2 token param", "param", l2TokenValue, "err", err)
+		return database.TokenPair{}, false
+	}
+
+	// The token pair is from the perspective of L1
+	return database.TokenPair{LocalTokenAddress: l1Token, RemoteTokenAddress: l2Token}, true
+}
+
+// parseTokenPairPage ... Parses the token pair, cursor and limit params of a paginated token pair route
+func (h Routes) parseTokenPairPage(w http.ResponseWriter, r *http.Request) (database.TokenPair, string, int, bool) {
+	cursor := r.URL.Query().Get("cursor")
+	limitQuery := r.URL.Query().Get("limit")
+
+	tokenPair, ok := h.parseTokenPair(w, r)
+	if !ok {
+		return database.TokenPair{}, "", 0, false
+	}
+
+	err := h.v.ValidateCursor(cursor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid cursor param", "param", cursor, "err", err)
+		return database.TokenPair{}, "", 0, false
+	}
+
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid limit param", "param", limitQuery, "err", err)
+		return database.TokenPair{}, "", 0, false
+	}
+
+	return tokenPair, cursor, limit, true
+}
+
+// BridgedTokensHandler ... Handles /api/v0/tokens GET requests
+func (h Routes) BridgedTokensHandler(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.view.BridgedTokens()
+	if err != nil {
+		http.Error(w, "Internal server error reading bridged tokens", http.StatusInternalServerError)
+		h.logger.Error("Unable to read bridged tokens from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateBridgedTokensResponse(tokens)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
+
+// TokenDepositsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/deposits GET requests
+func (h Routes) TokenDepositsHandler(w http.ResponseWriter, r *http.Request) {
+	tokenPair, cursor, limit, ok := h.parseTokenPairPage(w, r)
+	if !ok {
+		return
+	}
+
+	deposits, err := h.view.L1BridgeDepositsByTokenPair(tokenPair, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read deposits from DB", "err", err.Error())
+		return
+	}
+
+	response := newDepositResponse(deposits)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
+
+// TokenWithdrawalsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/withdrawals GET requests
+func (h Routes) TokenWithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	tokenPair, cursor, limit, ok := h.parseTokenPairPage(w, r)
+	if !ok {
+		return
+	}
+
+	// Withdrawals are indexed from the perspective of L2
+	l2TokenPair := database.TokenPair{LocalTokenAddress: tokenPair.RemoteTokenAddress, RemoteTokenAddress: tokenPair.LocalTokenAddress}
+	withdrawals, err := h.view.L2BridgeWithdrawalsByTokenPair(l2TokenPair, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawals from DB", "err", err.Error())
+		return
+	}
+
+	statusCtx, err := h.withdrawalStatusContext()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal status", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal status context from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateWithdrawalResponse(withdrawals, statusCtx)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
+
+// TokenERC721DepositsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/erc721/deposits GET requests
+func (h Routes) TokenERC721DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	tokenPair, cursor, limit, ok := h.parseTokenPairPage(w, r)
+	if !ok {
+		return
+	}
+
+	deposits, err := h.view.L1ERC721BridgeDepositsByTokenPair(tokenPair, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read erc721 deposits from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateERC721DepositResponse(deposits)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
+
+// TokenERC721WithdrawalsHandler ... Handles /api/v0/tokens/{l1Token}/{l2Token}/erc721/withdrawals GET requests
+func (h Routes) TokenERC721WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	tokenPair, cursor, limit, ok := h.parseTokenPairPage(w, r)
+	if !ok {
+		return
+	}
+
+	// Withdrawals are indexed from the perspective of L2
+	l2TokenPair := database.TokenPair{LocalTokenAddress: tokenPair.RemoteTokenAddress, RemoteTokenAddress: tokenPair.LocalTokenAddress}
+	withdrawals, err := h.view.L2ERC721BridgeWithdrawalsByTokenPair(l2TokenPair, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read erc721 withdrawals from DB", "err", err.Error())
+		return
+	}
+
+	statusCtx, err := h.withdrawalStatusContext()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal status", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal status context from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateERC721WithdrawalResponse(withdrawals, statusCtx)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err)
+	}
+}
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/BLASTchain/blast/indexer/api/models"
 	"github.com/go-chi/chi/v5"
@@ -40,7 +42,77 @@ func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("Unable to read withdrawals from DB", "err", err.Error())
 		return
 	}
-	response := models.CreateWithdrawalResponse(withdrawals)
+	statusCtx, err := h.withdrawalStatusContext()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal status", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal status context from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateWithdrawalResponse(withdrawals, statusCtx)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// withdrawalStatusContext ... Reads the indexed state that the lifecycle of withdrawals is computed against
+func (h Routes) withdrawalStatusContext() (models.WithdrawalStatusContext, error) {
+	statusCtx := models.WithdrawalStatusContext{
+		FinalizationPeriodSeconds: h.finalizationPeriodSeconds,
+		Now:                       uint64(time.Now().Unix()),
+	}
+
+	l1Header, err := h.blocks.L1LatestBlockHeader()
+	if err != nil {
+		return models.WithdrawalStatusContext{}, fmt.Errorf("failed to read latest L1 header: %w", err)
+	}
+	if l1Header != nil {
+		statusCtx.LatestL1Timestamp = l1Header.Timestamp
+	}
+
+	proposal, err := h.blocks.LatestOutputProposal()
+	if err != nil {
+		return models.WithdrawalStatusContext{}, fmt.Errorf("failed to read latest output proposal: %w", err)
+	}
+	if proposal != nil {
+		statusCtx.LatestProposedL2BlockNumber = proposal.L2BlockNumber
+	}
+
+	return statusCtx, nil
+}
+
+// WithdrawalStatusHandler ... Handles /api/v0/withdrawal/{hash}/status GET requests
+func (h Routes) WithdrawalStatusHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+
+	withdrawalHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err)
+		return
+	}
+
+	withdrawal, err := h.view.L2WithdrawalByHash(withdrawalHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal from DB", "err", err.Error())
+		return
+	}
+	if withdrawal == nil {
+		http.Error(w, "Withdrawal not found", http.StatusNotFound)
+		return
+	}
+
+	statusCtx, err := h.withdrawalStatusContext()
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal status", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal status context from DB", "err", err.Error())
+		return
+	}
+
+	response := models.CreateWithdrawalStatusResponse(withdrawal, statusCtx)
 
 	err = jsonResponse(w, response, http.StatusOK)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"encoding/json"
@@ -24,6 +25,10 @@ const (
 	deposits    = "get_deposits"
 	withdrawals = "get_withdrawals"
 	sum         = "get_sum"
+	tokenSum    = "get_token_sum"
+	tokens      = "get_tokens"
+
+	withdrawalStatus = "get_withdrawal_status"
 )
 
 // Option ... Provides configuration through callback injection
@@ -224,3 +229,127 @@ func (c *Client) GetWithdrawalsByAddress(l2Address common.Address, cursor string
 
 	return wResponse, nil
 }
+
+// GetTokenSupplyAssessment ... Returns an assessment of the current supply
+// of each token pair bridged through the StandardBridge
+func (c *Client) GetTokenSupplyAssessment() (*models.TokenSupplyView, error) {
+	url := c.cfg.BaseURL + api.TokenSupplyPath
+
+	resp, err := c.doRecordRequest(tokenSum, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var tsv *models.TokenSupplyView
+	if err := json.Unmarshal(resp, &tsv); err != nil {
+		return nil, err
+	}
+
+	return tsv, nil
+}
+
+// GetBridgedTokens ... Gets all token pairs that have ever been bridged
+func (c *Client) GetBridgedTokens() ([]models.BridgedTokenItem, error) {
+	url := c.cfg.BaseURL + api.TokensPath
+
+	resp, err := c.doRecordRequest(tokens, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var tResponse *models.BridgedTokensResponse
+	if err := json.Unmarshal(resp, &tResponse); err != nil {
+		return nil, err
+	}
+
+	return tResponse.Items, nil
+}
+
+// GetDepositsByTokenPair ... Gets a deposit response object provided an L1/L2 token pair and cursor
+func (c *Client) GetDepositsByTokenPair(l1Token, l2Token common.Address, cursor string) (*models.DepositResponse, error) {
+	var dResponse *models.DepositResponse
+	url := c.cfg.BaseURL + api.TokensPath + "/" + l1Token.String() + "/" + l2Token.String() + "/deposits" + urlParams
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+
+	resp, err := c.doRecordRequest(deposits, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(resp, &dResponse); err != nil {
+		return nil, err
+	}
+
+	return dResponse, nil
+}
+
+// GetWithdrawalsByTokenPair ... Gets a withdrawal response object provided an L1/L2 token pair and cursor
+func (c *Client) GetWithdrawalsByTokenPair(l1Token, l2Token common.Address, cursor string) (*models.WithdrawalResponse, error) {
+	var wResponse *models.WithdrawalResponse
+	url := c.cfg.BaseURL + api.TokensPath + "/" + l1Token.String() + "/" + l2Token.String() + "/withdrawals" + urlParams
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+
+	resp, err := c.doRecordRequest(withdrawals, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(resp, &wResponse); err != nil {
+		return nil, err
+	}
+
+	return wResponse, nil
+}
+
+// GetERC721DepositsByTokenPair ... Gets an ERC721 deposit response object provided an L1/L2 token pair and cursor
+func (c *Client) GetERC721DepositsByTokenPair(l1Token, l2Token common.Address, cursor string) (*models.ERC721DepositResponse, error) {
+	var dResponse *models.ERC721DepositResponse
+	url := c.cfg.BaseURL + api.TokensPath + "/" + l1Token.String() + "/" + l2Token.String() + "/erc721/deposits" + urlParams
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+
+	resp, err := c.doRecordRequest(deposits, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(resp, &dResponse); err != nil {
+		return nil, err
+	}
+
+	return dResponse, nil
+}
+
+// GetERC721WithdrawalsByTokenPair ... Gets an ERC721 withdrawal response object provided an L1/L2 token pair and cursor
+func (c *Client) GetERC721WithdrawalsByTokenPair(l1Token, l2Token common.Address, cursor string) (*models.ERC721WithdrawalResponse, error) {
+	var wResponse *models.ERC721WithdrawalResponse
+	url := c.cfg.BaseURL + api.TokensPath + "/" + l1Token.String() + "/" + l2Token.String() + "/erc721/withdrawals" + urlParams
+	endpoint := fmt.Sprintf(url, cursor, c.cfg.PaginationLimit)
+
+	resp, err := c.doRecordRequest(withdrawals, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(resp, &wResponse); err != nil {
+		return nil, err
+	}
+
+	return wResponse, nil
+}
+
+// GetWithdrawalStatus ... Gets the lifecycle status of a withdrawal provided its withdrawal hash
+func (c *Client) GetWithdrawalStatus(withdrawalHash common.Hash) (*models.WithdrawalStatusResponse, error) {
+	var sResponse *models.WithdrawalStatusResponse
+	endpoint := c.cfg.BaseURL + strings.Replace(api.WithdrawalStatusPath, "{hash}", withdrawalHash.String(), 1)
+
+	resp, err := c.doRecordRequest(withdrawalStatus, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(resp, &sResponse); err != nil {
+		return nil, err
+	}
+
+	return sResponse, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/urfave/cli/v2"
 
@@ -30,6 +31,37 @@ var (
 		Usage:   "path to migrations folder",
 		EnvVars: []string{"INDEXER_MIGRATIONS_DIR"},
 	}
+	ReindexL1FromFlag = &cli.Uint64Flag{
+		Name:  "l1-from",
+		Usage: "first L1 block number of the range to re-index",
+	}
+	ReindexL1ToFlag = &cli.Uint64Flag{
+		Name:  "l1-to",
+		Usage: "last L1 block number of the range to re-index",
+	}
+	ReindexL2FromFlag = &cli.Uint64Flag{
+		Name:  "l2-from",
+		Usage: "first L2 block number of the range to re-index",
+	}
+	ReindexL2ToFlag = &cli.Uint64Flag{
+		Name:  "l2-to",
+		Usage: "last L2 block number of the range to re-index",
+	}
+	ReindexProcessorFlag = &cli.StringFlag{
+		Name:  "processor",
+		Value: "bridge",
+		Usage: "processor to re-run over the block range",
+	}
+	ReindexChunkSizeFlag = &cli.Uint64Flag{
+		Name:  "chunk-size",
+		Value: 10_000,
+		Usage: "number of blocks re-indexed per chunk",
+	}
+	ReindexParallelismFlag = &cli.IntFlag{
+		Name:  "parallelism",
+		Value: 4,
+		Usage: "number of chunks re-indexed in parallel",
+	}
 )
 
 func runIndexer(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -61,6 +93,8 @@ func runApi(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, erro
 		DB:            &api.DBConfigConnector{DBConfig: cfg.DB},
 		HTTPServer:    cfg.HTTPServer,
 		MetricsServer: cfg.MetricsServer,
+
+		FinalizationPeriodSeconds: cfg.Chain.FinalizationPeriodSeconds,
 	}
 
 	return api.NewApi(ctx.Context, log, apiCfg)
@@ -91,11 +125,48 @@ func runMigrations(ctx *cli.Context) error {
 	return db.ExecuteSQLMigration(migrationsDir)
 }
 
+func runReindex(ctx *cli.Context) error {
+	// We don't maintain a complicated lifecycle here, just interrupt to shut down.
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+
+	log := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx)).New("role", "reindex")
+	oplog.SetGlobalLogHandler(log.GetHandler())
+	log.Info("running reindex...")
+
+	cfg, err := config.LoadConfig(log, ctx.String(ConfigFlag.Name))
+	if err != nil {
+		log.Error("failed to load config", "err", err)
+		return err
+	}
+
+	reindexCfg := indexer.ReindexConfig{
+		Processor:    ctx.String(ReindexProcessorFlag.Name),
+		L1FromHeight: heightFlag(ctx, ReindexL1FromFlag),
+		L1ToHeight:   heightFlag(ctx, ReindexL1ToFlag),
+		L2FromHeight: heightFlag(ctx, ReindexL2FromFlag),
+		L2ToHeight:   heightFlag(ctx, ReindexL2ToFlag),
+		ChunkSize:    ctx.Uint64(ReindexChunkSizeFlag.Name),
+		Parallelism:  ctx.Int(ReindexParallelismFlag.Name),
+	}
+
+	return indexer.Reindex(ctx.Context, log, &cfg, reindexCfg)
+}
+
+// heightFlag returns the block number of the flag, or nil if it is not set
+func heightFlag(ctx *cli.Context, flag *cli.Uint64Flag) *big.Int {
+	if !ctx.IsSet(flag.Name) {
+		return nil
+	}
+	return new(big.Int).SetUint64(ctx.Uint64(flag.Name))
+}
+
 func newCli(GitCommit string, GitDate string) *cli.App {
 	flags := []cli.Flag{ConfigFlag}
 	flags = append(flags, oplog.CLIFlags("INDEXER")...)
 	migrationFlags := []cli.Flag{MigrationsFlag, ConfigFlag}
 	migrationFlags = append(migrationFlags, oplog.CLIFlags("INDEXER")...)
+	reindexFlags := []cli.Flag{ConfigFlag, ReindexL1FromFlag, ReindexL1ToFlag, ReindexL2FromFlag, ReindexL2ToFlag, ReindexProcessorFlag, ReindexChunkSizeFlag, ReindexParallelismFlag}
+	reindexFlags = append(reindexFlags, oplog.CLIFlags("INDEXER")...)
 	return &cli.App{
 		Version:              params.VersionWithCommit(GitCommit, GitDate),
 		Description:          "An indexer of all optimism events with a serving api layer",
@@ -119,6 +190,12 @@ func newCli(GitCommit string, GitDate string) *cli.App {
 				Description: "Runs the database migrations",
 				Action:      runMigrations,
 			},
+			{
+				Name:        "reindex",
+				Flags:       reindexFlags,
+				Description: "Re-runs the ETL and a processor over historical L1 and/or L2 block ranges, alongside the indexing service",
+				Action:      runReindex,
+			},
 			{
 				Name:        "version",
 				Description: "print version",
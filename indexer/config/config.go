@@ -15,6 +15,9 @@ const (
 	// default to 5 seconds
 	defaultLoopInterval     = 5000
 	defaultHeaderBufferSize = 500
+
+	// default to the 7 day challenge window of mainnet chains
+	defaultFinalizationPeriodSeconds = 604800
 )
 
 // In the future, presets can just be onchain config and fetched on initialization
@@ -117,6 +120,10 @@ type ChainConfig struct {
 
 	L1HeaderBufferSize uint `toml:"l1-header-buffer-size"`
 	L2HeaderBufferSize uint `toml:"l2-header-buffer-size"`
+
+	// FinalizationPeriodSeconds is the challenge window of proven withdrawals, as configured
+	// in the L2OutputOracle. It is only used to report the lifecycle of withdrawals.
+	FinalizationPeriodSeconds uint64 `toml:"finalization-period-seconds"`
 }
 
 // RPCsConfig configures the RPC urls
@@ -214,6 +221,10 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 		cfg.Chain.L2HeaderBufferSize = defaultHeaderBufferSize
 	}
 
+	if cfg.Chain.FinalizationPeriodSeconds == 0 {
+		cfg.Chain.FinalizationPeriodSeconds = defaultFinalizationPeriodSeconds
+	}
+
 	log.Info("loaded chain config", "config", cfg.Chain)
 	return cfg, nil
 }
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -49,16 +51,31 @@ type L2BlockHeader struct {
 	BlockHeader `gorm:"embedded"`
 }
 
+type OutputProposal struct {
+	OutputRoot         common.Hash `gorm:"primaryKey;serializer:bytes"`
+	L2OutputIndex      *big.Int    `gorm:"serializer:u256"`
+	L2BlockNumber      *big.Int    `gorm:"serializer:u256"`
+	OutputProposedGUID uuid.UUID
+	Timestamp          uint64
+
+	// OutputsDeletedGUID ... The OutputsDeleted event that deleted the output, if any
+	OutputsDeletedGUID *uuid.UUID
+}
+
 type BlocksView interface {
 	L1BlockHeader(common.Hash) (*L1BlockHeader, error)
 	L1BlockHeaderWithFilter(BlockHeader) (*L1BlockHeader, error)
 	L1BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L1BlockHeader, error)
 	L1LatestBlockHeader() (*L1BlockHeader, error)
+	L1LatestBlockHeaders(limit int) ([]L1BlockHeader, error)
 
 	L2BlockHeader(common.Hash) (*L2BlockHeader, error)
 	L2BlockHeaderWithFilter(BlockHeader) (*L2BlockHeader, error)
 	L2BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L2BlockHeader, error)
 	L2LatestBlockHeader() (*L2BlockHeader, error)
+	L2LatestBlockHeaders(limit int) ([]L2BlockHeader, error)
+
+	LatestOutputProposal() (*OutputProposal, error)
 }
 
 type BlocksDB interface {
@@ -66,6 +83,12 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]L1BlockHeader) error
 	StoreL2BlockHeaders([]L2BlockHeader) error
+
+	DeleteL1BlockHeadersAfter(*big.Int) error
+	DeleteL2BlockHeadersAfter(*big.Int) error
+
+	StoreOutputProposals([]OutputProposal) error
+	MarkOutputProposalsDeleted(fromL2OutputIndex *big.Int, outputsDeletedGUID uuid.UUID) error
 }
 
 /**
@@ -128,6 +151,29 @@ func (db *blocksDB) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1LatestBlockHeaders returns up to `limit` of the latest indexed L1 block headers, in ascending order
+func (db *blocksDB) L1LatestBlockHeaders(limit int) ([]L1BlockHeader, error) {
+	var l1Headers []L1BlockHeader
+	result := db.gorm.Order("number DESC").Limit(limit).Find(&l1Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	slices.Reverse(l1Headers)
+	return l1Headers, nil
+}
+
+// DeleteL1BlockHeadersAfter deletes the L1 block headers after the supplied height. The contract events
+// of these headers, and the bridge state initiated by them, are deleted by cascade.
+func (db *blocksDB) DeleteL1BlockHeadersAfter(height *big.Int) error {
+	result := db.gorm.Where("number > ?", height).Delete(&L1BlockHeader{})
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Info("deleted L1 block headers", "after_block_number", height, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
@@ -173,3 +219,75 @@ func (db *blocksDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 
 	return &l2Header, nil
 }
+
+// L2LatestBlockHeaders returns up to `limit` of the latest indexed L2 block headers, in ascending order
+func (db *blocksDB) L2LatestBlockHeaders(limit int) ([]L2BlockHeader, error) {
+	var l2Headers []L2BlockHeader
+	result := db.gorm.Order("number DESC").Limit(limit).Find(&l2Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	slices.Reverse(l2Headers)
+	return l2Headers, nil
+}
+
+// DeleteL2BlockHeadersAfter deletes the L2 block headers after the supplied height. The contract events
+// of these headers, and the bridge state initiated by them, are deleted by cascade.
+func (db *blocksDB) DeleteL2BlockHeadersAfter(height *big.Int) error {
+	result := db.gorm.Where("number > ?", height).Delete(&L2BlockHeader{})
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Info("deleted L2 block headers", "after_block_number", height, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
+// Rollup
+
+func (db *blocksDB) StoreOutputProposals(outputs []OutputProposal) error {
+	// Re-indexed proposals keep their deletion, which may have been emitted outside of the re-indexed range
+	deduped := deduplicated(db.gorm, "output_root", "l2_output_index", "l2_block_number", "output_proposed_guid", "timestamp")
+	result := deduped.Create(&outputs)
+	if result.Error == nil && int(result.RowsAffected) < len(outputs) {
+		db.log.Warn("ignored output proposal duplicates", "duplicates", len(outputs)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+// MarkOutputProposalsDeleted marks the output proposals from the given output index onwards as deleted by the
+// OutputsDeleted event, as done by the L2OutputOracle. Only outputs proposed before the event are deleted by it.
+// The deleted outputs are restored when the event is rolled back.
+func (db *blocksDB) MarkOutputProposalsDeleted(fromL2OutputIndex *big.Int, outputsDeletedGUID uuid.UUID) error {
+	proposedBefore := db.gorm.Table("l1_contract_events AS proposed").Select("proposed.guid").
+		Joins("INNER JOIN l1_block_headers AS proposed_header ON proposed_header.hash = proposed.block_hash").
+		Joins("INNER JOIN l1_contract_events AS deleted ON deleted.guid = ?", outputsDeletedGUID).
+		Joins("INNER JOIN l1_block_headers AS deleted_header ON deleted_header.hash = deleted.block_hash").
+		Where("proposed_header.number < deleted_header.number OR (proposed_header.number = deleted_header.number AND proposed.log_index < deleted.log_index)")
+
+	result := db.gorm.Model(&OutputProposal{}).
+		Where("l2_output_index >= ? AND outputs_deleted_guid IS NULL", fromL2OutputIndex).
+		Where("output_proposed_guid IN (?)", proposedBefore).
+		Update("outputs_deleted_guid", outputsDeletedGUID)
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Info("deleted output proposals", "from_index", fromL2OutputIndex, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
+// LatestOutputProposal returns the output proposal of the highest L2 block that is not deleted, or nil if no outputs
+// have been proposed yet.
+func (db *blocksDB) LatestOutputProposal() (*OutputProposal, error) {
+	var outputProposal OutputProposal
+	result := db.gorm.Where("outputs_deleted_guid IS NULL").Order("l2_block_number DESC").Take(&outputProposal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &outputProposal, nil
+}
@@ -6,7 +6,6 @@ import (
 	"math/big"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -52,9 +51,11 @@ type BridgeMessagesDB interface {
 
 	StoreL1BridgeMessages([]L1BridgeMessage) error
 	MarkRelayedL1BridgeMessage(common.Hash, uuid.UUID) error
+	UnmarkRelayedL1BridgeMessagesAfter(*big.Int) error
 
 	StoreL2BridgeMessages([]L2BridgeMessage) error
 	MarkRelayedL2BridgeMessage(common.Hash, uuid.UUID) error
+	UnmarkRelayedL2BridgeMessagesAfter(*big.Int) error
 }
 
 /**
@@ -74,8 +75,11 @@ func newBridgeMessagesDB(log log.Logger, db *gorm.DB) BridgeMessagesDB {
  * Arbitrary Messages Sent from L1
  */
 
+// l1BridgeMessageColumns are upserted when re-processing, leaving the relayed event untouched
+var l1BridgeMessageColumns = []string{"nonce", "transaction_source_hash", "sent_message_event_guid", "from_address", "to_address", "amount", "gas_limit", "data", "timestamp"}
+
 func (db bridgeMessagesDB) StoreL1BridgeMessages(messages []L1BridgeMessage) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "message_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "message_hash", l1BridgeMessageColumns...)
 	result := deduped.Create(&messages)
 	if result.Error == nil && int(result.RowsAffected) < len(messages) {
 		db.log.Warn("ignored L1 bridge message duplicates", "duplicates", len(messages)-int(result.RowsAffected))
@@ -120,12 +124,31 @@ func (db bridgeMessagesDB) MarkRelayedL1BridgeMessage(messageHash common.Hash, r
 	return result.Error
 }
 
+// UnmarkRelayedL1BridgeMessagesAfter unlinks the relayed events of messages that were relayed in L2 blocks
+// after the supplied height. Deleting these events would otherwise cascade to the messages.
+func (db bridgeMessagesDB) UnmarkRelayedL1BridgeMessagesAfter(l2Height *big.Int) error {
+	l2Events := db.gorm.Table("l2_contract_events").Select("l2_contract_events.guid")
+	l2Events = l2Events.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	l2Events = l2Events.Where("l2_block_headers.number > ?", l2Height)
+
+	relayed := db.gorm.Table("l1_bridge_messages").Where("relayed_message_event_guid IN (?)", l2Events)
+	result := relayed.Update("relayed_message_event_guid", nil)
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Info("unmarked relayed L1 bridge messages", "after_l2_block_number", l2Height, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
 /**
  * Arbitrary Messages Sent from L2
  */
 
+// l2BridgeMessageColumns are upserted when re-processing, leaving the relayed event untouched
+var l2BridgeMessageColumns = []string{"nonce", "transaction_withdrawal_hash", "sent_message_event_guid", "from_address", "to_address", "amount", "gas_limit", "data", "timestamp"}
+
 func (db bridgeMessagesDB) StoreL2BridgeMessages(messages []L2BridgeMessage) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "message_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "message_hash", l2BridgeMessageColumns...)
 	result := deduped.Create(&messages)
 	if result.Error == nil && int(result.RowsAffected) < len(messages) {
 		db.log.Warn("ignored L2 bridge message duplicates", "duplicates", len(messages)-int(result.RowsAffected))
@@ -169,3 +192,19 @@ func (db bridgeMessagesDB) MarkRelayedL2BridgeMessage(messageHash common.Hash, r
 	result := db.gorm.Save(message)
 	return result.Error
 }
+
+// UnmarkRelayedL2BridgeMessagesAfter unlinks the relayed events of messages that were relayed in L1 blocks
+// after the supplied height. Deleting these events would otherwise cascade to the messages.
+func (db bridgeMessagesDB) UnmarkRelayedL2BridgeMessagesAfter(l1Height *big.Int) error {
+	l1Events := db.gorm.Table("l1_contract_events").Select("l1_contract_events.guid")
+	l1Events = l1Events.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	l1Events = l1Events.Where("l1_block_headers.number > ?", l1Height)
+
+	relayed := db.gorm.Table("l2_bridge_messages").Where("relayed_message_event_guid IN (?)", l1Events)
+	result := relayed.Update("relayed_message_event_guid", nil)
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Info("unmarked relayed L2 bridge messages", "after_l1_block_number", l1Height, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
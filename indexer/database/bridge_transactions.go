@@ -7,7 +7,6 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -65,6 +64,7 @@ type BridgeTransactionsDB interface {
 	StoreL2TransactionWithdrawals([]L2TransactionWithdrawal) error
 	MarkL2TransactionWithdrawalProvenEvent(common.Hash, uuid.UUID) error
 	MarkL2TransactionWithdrawalFinalizedEvent(common.Hash, uuid.UUID, bool) error
+	UnmarkL2TransactionWithdrawalEventsAfter(*big.Int) error
 }
 
 /**
@@ -85,7 +85,7 @@ func newBridgeTransactionsDB(log log.Logger, db *gorm.DB) BridgeTransactionsDB {
  */
 
 func (db *bridgeTransactionsDB) StoreL1TransactionDeposits(deposits []L1TransactionDeposit) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "source_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "source_hash")
 	result := deduped.Create(&deposits)
 	if result.Error == nil && int(result.RowsAffected) < len(deposits) {
 		db.log.Warn("ignored L1 tx deposit duplicates", "duplicates", len(deposits)-int(result.RowsAffected))
@@ -160,8 +160,11 @@ func (db *bridgeTransactionsDB) L1LatestFinalizedBlockHeader() (*L1BlockHeader,
  * Transactions withdrawn from L2
  */
 
+// withdrawalColumns are upserted when re-processing, leaving the proven & finalized events untouched
+var withdrawalColumns = []string{"nonce", "initiated_l2_event_guid", "from_address", "to_address", "amount", "gas_limit", "data", "timestamp"}
+
 func (db *bridgeTransactionsDB) StoreL2TransactionWithdrawals(withdrawals []L2TransactionWithdrawal) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "withdrawal_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "withdrawal_hash", withdrawalColumns...)
 	result := deduped.Create(&withdrawals)
 	if result.Error == nil && int(result.RowsAffected) < len(withdrawals) {
 		db.log.Warn("ignored L2 tx withdrawal duplicates", "duplicates", len(withdrawals)-int(result.RowsAffected))
@@ -226,6 +229,33 @@ func (db *bridgeTransactionsDB) MarkL2TransactionWithdrawalFinalizedEvent(withdr
 	return result.Error
 }
 
+// UnmarkL2TransactionWithdrawalEventsAfter unlinks the proven and finalized events of withdrawals that were
+// included in L1 blocks after the supplied height. Deleting these events would otherwise cascade to the withdrawals.
+func (db *bridgeTransactionsDB) UnmarkL2TransactionWithdrawalEventsAfter(l1Height *big.Int) error {
+	l1Events := db.gorm.Table("l1_contract_events").Select("l1_contract_events.guid")
+	l1Events = l1Events.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	l1Events = l1Events.Where("l1_block_headers.number > ?", l1Height)
+
+	finalized := db.gorm.Table("l2_transaction_withdrawals").Where("finalized_l1_event_guid IN (?)", l1Events)
+	result := finalized.Updates(map[string]interface{}{"finalized_l1_event_guid": nil, "succeeded": nil})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected > 0 {
+		db.log.Info("unmarked finalized withdrawals", "after_l1_block_number", l1Height, "size", result.RowsAffected)
+	}
+
+	// A withdrawal can only be finalized after it was proven
+	proven := db.gorm.Table("l2_transaction_withdrawals").Where("proven_l1_event_guid IN (?)", l1Events)
+	result = proven.Updates(map[string]interface{}{"proven_l1_event_guid": nil, "finalized_l1_event_guid": nil, "succeeded": nil})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected > 0 {
+		db.log.Info("unmarked proven withdrawals", "after_l1_block_number", l1Height, "size", result.RowsAffected)
+	}
+
+	return nil
+}
+
 func (db *bridgeTransactionsDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	// L2: Latest Withdrawal
 	l2Query := db.gorm.Table("l2_transaction_withdrawals").Order("timestamp DESC")
@@ -3,9 +3,9 @@ package database
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/BLASTchain/blast/bl-bindings/predeploys"
 	"github.com/ethereum/go-ethereum/common"
@@ -57,6 +57,72 @@ type L2BridgeWithdrawalWithTransactionHashes struct {
 
 	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
 	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// Lifecycle of the withdrawal. The L1 timestamps & success are nil until the withdrawal is proven/finalized
+	L2BlockNumber        *big.Int `gorm:"serializer:u256"`
+	ProvenL1Timestamp    *uint64
+	FinalizedL1Timestamp *uint64
+	Succeeded            *bool
+}
+
+// ERC721BridgeTransfer is a transfer of a single non-fungible token through the ERC721Bridge
+type ERC721BridgeTransfer struct {
+	CrossDomainMessageHash common.Hash `gorm:"serializer:bytes"`
+
+	TokenPair   TokenPair      `gorm:"embedded"`
+	TokenID     *big.Int       `gorm:"serializer:u256"`
+	FromAddress common.Address `gorm:"serializer:bytes"`
+	ToAddress   common.Address `gorm:"serializer:bytes"`
+	Data        Bytes          `gorm:"serializer:bytes"`
+	Timestamp   uint64
+}
+
+type L1ERC721BridgeDeposit struct {
+	ERC721BridgeTransfer  `gorm:"embedded"`
+	TransactionSourceHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawal struct {
+	ERC721BridgeTransfer      `gorm:"embedded"`
+	TransactionWithdrawalHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L1ERC721BridgeDepositWithTransactionHashes struct {
+	L1ERC721BridgeDeposit L1ERC721BridgeDeposit `gorm:"embedded"`
+
+	L1BlockHash       common.Hash `gorm:"serializer:bytes"`
+	L1TransactionHash common.Hash `gorm:"serializer:bytes"`
+	L2TransactionHash common.Hash `gorm:"serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawalWithTransactionHashes struct {
+	L2ERC721BridgeWithdrawal L2ERC721BridgeWithdrawal `gorm:"embedded"`
+	L2TransactionHash        common.Hash              `gorm:"serializer:bytes"`
+	L2BlockHash              common.Hash              `gorm:"serializer:bytes"`
+
+	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
+	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// Lifecycle of the withdrawal. The L1 timestamps & success are nil until the withdrawal is proven/finalized
+	L2BlockNumber        *big.Int `gorm:"serializer:u256"`
+	ProvenL1Timestamp    *uint64
+	FinalizedL1Timestamp *uint64
+	Succeeded            *bool
+}
+
+// BridgedToken is a token pair that has been bridged at least once, from the perspective of L1
+type BridgedToken struct {
+	L1TokenAddress common.Address `gorm:"serializer:bytes"`
+	L2TokenAddress common.Address `gorm:"serializer:bytes"`
+	Standard       string
+}
+
+// TokenValueLocked is the value of a token pair bridged from L1 into L2 that has not been withdrawn back
+// to L1, from the perspective of L1. The value locked is the difference of the two sums.
+type TokenValueLocked struct {
+	TokenPair     TokenPair `gorm:"embedded"`
+	DepositSum    float64
+	WithdrawalSum float64
 }
 
 type BridgeTransfersView interface {
@@ -64,18 +130,30 @@ type BridgeTransfersView interface {
 	L1BridgeDepositSum() (float64, error)
 	L1BridgeDepositWithFilter(BridgeTransfer) (*L1BridgeDeposit, error)
 	L1BridgeDepositsByAddress(common.Address, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDepositsByTokenPair(TokenPair, string, int) (*L1BridgeDepositsResponse, error)
+	L1ERC721BridgeDeposit(common.Hash) (*L1ERC721BridgeDeposit, error)
+	L1ERC721BridgeDepositsByTokenPair(TokenPair, string, int) (*L1ERC721BridgeDepositsResponse, error)
 
 	L2BridgeWithdrawal(common.Hash) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalSum() (float64, error)
 	L2BridgeWithdrawalWithFilter(BridgeTransfer) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalsByAddress(common.Address, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawalsByTokenPair(TokenPair, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2WithdrawalByHash(common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error)
+	L2ERC721BridgeWithdrawal(common.Hash) (*L2ERC721BridgeWithdrawal, error)
+	L2ERC721BridgeWithdrawalsByTokenPair(TokenPair, string, int) (*L2ERC721BridgeWithdrawalsResponse, error)
+
+	BridgedTokens() ([]BridgedToken, error)
+	TokenValueLocked() ([]TokenValueLocked, error)
 }
 
 type BridgeTransfersDB interface {
 	BridgeTransfersView
 
 	StoreL1BridgeDeposits([]L1BridgeDeposit) error
+	StoreL1ERC721BridgeDeposits([]L1ERC721BridgeDeposit) error
 	StoreL2BridgeWithdrawals([]L2BridgeWithdrawal) error
+	StoreL2ERC721BridgeWithdrawals([]L2ERC721BridgeWithdrawal) error
 }
 
 /**
@@ -96,7 +174,7 @@ func newBridgeTransfersDB(log log.Logger, db *gorm.DB) BridgeTransfersDB {
  */
 
 func (db *bridgeTransfersDB) StoreL1BridgeDeposits(deposits []L1BridgeDeposit) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "transaction_source_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "transaction_source_hash")
 	result := deduped.Create(&deposits)
 	if result.Error == nil && int(result.RowsAffected) < len(deposits) {
 		db.log.Warn("ignored L1 bridge transfer duplicates", "duplicates", len(deposits)-int(result.RowsAffected))
@@ -219,12 +297,138 @@ l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, re
 	return response, nil
 }
 
+// L1BridgeDepositsByTokenPair retrieves a list of deposits of the specified token pair, coupled with the L1/L2
+// transaction hashes that complete the bridge transaction. Unlike `L1BridgeDepositsByAddress`, ETH sent directly
+// through the OptimismPortal is not included as these deposits are not associated with a token pair.
+func (db *bridgeTransfersDB) L1BridgeDepositsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L1BridgeDepositsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	cursorClause := ""
+	if cursor != "" {
+		sourceHash := common.HexToHash(cursor)
+		var cursorDeposit L1BridgeDeposit
+		result := db.gorm.Model(&L1BridgeDeposit{}).Where(&L1BridgeDeposit{TransactionSourceHash: sourceHash}).Take(&cursorDeposit)
+		if result.Error != nil {
+			return nil, fmt.Errorf("unable to find deposit with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		}
+		cursorClause = fmt.Sprintf("l1_bridge_deposits.timestamp <= %d", cursorDeposit.Tx.Timestamp)
+	}
+
+	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
+	depositsQuery = depositsQuery.Where(&tokenPair)
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Select(`
+l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	if cursorClause != "" {
+		depositsQuery = depositsQuery.Where(cursorClause)
+	}
+
+	deposits := []L1BridgeDepositWithTransactionHashes{}
+	result := depositsQuery.Order("l1_bridge_deposits.timestamp DESC").Limit(limit + 1).Find(&deposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(deposits) > limit {
+		hasNextPage = true
+		nextCursor = deposits[limit].L1BridgeDeposit.TransactionSourceHash.String()
+		deposits = deposits[:limit]
+	}
+
+	response := &L1BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+func (db *bridgeTransfersDB) StoreL1ERC721BridgeDeposits(deposits []L1ERC721BridgeDeposit) error {
+	deduped := deduplicated(db.gorm, "transaction_source_hash")
+	result := deduped.Create(&deposits)
+	if result.Error == nil && int(result.RowsAffected) < len(deposits) {
+		db.log.Warn("ignored L1 erc721 bridge transfer duplicates", "duplicates", len(deposits)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) L1ERC721BridgeDeposit(txSourceHash common.Hash) (*L1ERC721BridgeDeposit, error) {
+	var deposit L1ERC721BridgeDeposit
+	result := db.gorm.Where(&L1ERC721BridgeDeposit{TransactionSourceHash: txSourceHash}).Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+type L1ERC721BridgeDepositsResponse struct {
+	Deposits    []L1ERC721BridgeDepositWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// L1ERC721BridgeDepositsByTokenPair retrieves a list of ERC721 deposits of the specified token pair, coupled with the
+// L1/L2 transaction hashes that complete the bridge transaction.
+func (db *bridgeTransfersDB) L1ERC721BridgeDepositsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L1ERC721BridgeDepositsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	cursorClause := ""
+	if cursor != "" {
+		sourceHash := common.HexToHash(cursor)
+		var cursorDeposit L1ERC721BridgeDeposit
+		result := db.gorm.Model(&L1ERC721BridgeDeposit{}).Where(&L1ERC721BridgeDeposit{TransactionSourceHash: sourceHash}).Take(&cursorDeposit)
+		if result.Error != nil {
+			return nil, fmt.Errorf("unable to find deposit with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		}
+		cursorClause = fmt.Sprintf("l1_erc721_bridge_deposits.timestamp <= %d", cursorDeposit.Timestamp)
+	}
+
+	depositsQuery := db.gorm.Model(&L1ERC721BridgeDeposit{})
+	depositsQuery = depositsQuery.Where(&tokenPair)
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Select(`
+l1_erc721_bridge_deposits.from_address, l1_erc721_bridge_deposits.to_address, l1_erc721_bridge_deposits.token_id, l1_erc721_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_erc721_bridge_deposits.timestamp, l1_erc721_bridge_deposits.cross_domain_message_hash, local_token_address, remote_token_address`)
+	if cursorClause != "" {
+		depositsQuery = depositsQuery.Where(cursorClause)
+	}
+
+	deposits := []L1ERC721BridgeDepositWithTransactionHashes{}
+	result := depositsQuery.Order("l1_erc721_bridge_deposits.timestamp DESC").Limit(limit + 1).Find(&deposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(deposits) > limit {
+		hasNextPage = true
+		nextCursor = deposits[limit].L1ERC721BridgeDeposit.TransactionSourceHash.String()
+		deposits = deposits[:limit]
+	}
+
+	response := &L1ERC721BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
 /**
  * Tokens Bridged (Withdrawn) from L2
  */
 
 func (db *bridgeTransfersDB) StoreL2BridgeWithdrawals(withdrawals []L2BridgeWithdrawal) error {
-	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "transaction_withdrawal_hash"}}, DoNothing: true})
+	deduped := deduplicated(db.gorm, "transaction_withdrawal_hash")
 	result := deduped.Create(&withdrawals)
 	if result.Error == nil && int(result.RowsAffected) < len(withdrawals) {
 		db.log.Warn("ignored L2 bridge transfer duplicates", "duplicates", len(withdrawals)-int(result.RowsAffected))
@@ -256,6 +460,40 @@ func (db *bridgeTransfersDB) L2BridgeWithdrawalSum() (float64, error) {
 	return sum, nil
 }
 
+// L2WithdrawalByHash retrieves the withdrawal with the specified withdrawal hash, coupled with the L1/L2 transaction hashes
+// that complete the bridge transaction. The withdrawal does not need to be a bridge transfer, in which case it is an ETH withdrawal.
+func (db *bridgeTransfersDB) L2WithdrawalByHash(withdrawalHash common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error) {
+	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
+
+	query := db.gorm.Model(&L2TransactionWithdrawal{})
+	query = query.Where(&L2TransactionWithdrawal{WithdrawalHash: withdrawalHash})
+	query = query.Joins("LEFT JOIN l2_bridge_withdrawals ON l2_bridge_withdrawals.transaction_withdrawal_hash = withdrawal_hash")
+	query = query.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	query = query.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	query = query.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	query = query.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	query = query.Select(`
+COALESCE(l2_bridge_withdrawals.from_address, l2_transaction_withdrawals.from_address) AS from_address,
+COALESCE(l2_bridge_withdrawals.to_address, l2_transaction_withdrawals.to_address) AS to_address,
+COALESCE(l2_bridge_withdrawals.amount, l2_transaction_withdrawals.amount) AS amount,
+COALESCE(l2_bridge_withdrawals.data, l2_transaction_withdrawals.data) AS data, withdrawal_hash AS transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+l2_transaction_withdrawals.timestamp, l2_bridge_withdrawals.cross_domain_message_hash,
+COALESCE(l2_bridge_withdrawals.local_token_address, ?) AS local_token_address, COALESCE(l2_bridge_withdrawals.remote_token_address, ?) AS remote_token_address,
+l2_block_headers.number AS l2_block_number, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.timestamp AS finalized_l1_timestamp, succeeded`, ethAddressString, ethAddressString)
+
+	var withdrawal L2BridgeWithdrawalWithTransactionHashes
+	result := query.Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
 // L2BridgeWithdrawalWithFilter queries for a bridge withdrawal with set fields in the `BridgeTransfer` filter
 func (db *bridgeTransfersDB) L2BridgeWithdrawalWithFilter(filter BridgeTransfer) (*L2BridgeWithdrawal, error) {
 	var withdrawal L2BridgeWithdrawal
@@ -307,12 +545,14 @@ func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address
 	ethTransactionWithdrawals := db.gorm.Model(&L2TransactionWithdrawal{})
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(&Transaction{FromAddress: address}).Where("amount > 0")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Select(`
 from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
 l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address,
+l2_block_headers.number AS l2_block_number, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.timestamp AS finalized_l1_timestamp, succeeded`, ethAddressString, ethAddressString)
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(cursorClause)
@@ -322,12 +562,14 @@ l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS lo
 	withdrawalsQuery = withdrawalsQuery.Where(&Transaction{FromAddress: address})
 	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
 	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
 	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
 	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
 	withdrawalsQuery = withdrawalsQuery.Select(`
 l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
 l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address,
+l2_block_headers.number AS l2_block_number, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.timestamp AS finalized_l1_timestamp, succeeded`)
 	withdrawalsQuery = withdrawalsQuery.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
@@ -359,3 +601,193 @@ l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address,
 	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
+
+// L2BridgeWithdrawalsByTokenPair retrieves a list of withdrawals of the specified token pair, from the perspective
+// of L2, coupled with the L1/L2 transaction hashes that complete the bridge transaction. Unlike `L2BridgeWithdrawalsByAddress`,
+// ETH sent directly through the L2ToL1MessagePasser is not included as these withdrawals are not associated with a token pair.
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L2BridgeWithdrawalsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	cursorClause := ""
+	if cursor != "" {
+		withdrawalHash := common.HexToHash(cursor)
+		var cursorWithdrawal L2BridgeWithdrawal
+		result := db.gorm.Model(&L2BridgeWithdrawal{}).Where(&L2BridgeWithdrawal{TransactionWithdrawalHash: withdrawalHash}).Take(&cursorWithdrawal)
+		if result.Error != nil {
+			return nil, fmt.Errorf("unable to find withdrawal with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		}
+		cursorClause = fmt.Sprintf("l2_bridge_withdrawals.timestamp <= %d", cursorWithdrawal.Tx.Timestamp)
+	}
+
+	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
+	withdrawalsQuery = withdrawalsQuery.Where(&tokenPair)
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address,
+l2_block_headers.number AS l2_block_number, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.timestamp AS finalized_l1_timestamp, succeeded`)
+	if cursorClause != "" {
+		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
+	}
+
+	withdrawals := []L2BridgeWithdrawalWithTransactionHashes{}
+	result := withdrawalsQuery.Order("l2_bridge_withdrawals.timestamp DESC").Limit(limit + 1).Find(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(withdrawals) > limit {
+		hasNextPage = true
+		nextCursor = withdrawals[limit].L2BridgeWithdrawal.TransactionWithdrawalHash.String()
+		withdrawals = withdrawals[:limit]
+	}
+
+	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+func (db *bridgeTransfersDB) StoreL2ERC721BridgeWithdrawals(withdrawals []L2ERC721BridgeWithdrawal) error {
+	deduped := deduplicated(db.gorm, "transaction_withdrawal_hash")
+	result := deduped.Create(&withdrawals)
+	if result.Error == nil && int(result.RowsAffected) < len(withdrawals) {
+		db.log.Warn("ignored L2 erc721 bridge transfer duplicates", "duplicates", len(withdrawals)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) L2ERC721BridgeWithdrawal(txWithdrawalHash common.Hash) (*L2ERC721BridgeWithdrawal, error) {
+	var withdrawal L2ERC721BridgeWithdrawal
+	result := db.gorm.Where(&L2ERC721BridgeWithdrawal{TransactionWithdrawalHash: txWithdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+type L2ERC721BridgeWithdrawalsResponse struct {
+	Withdrawals []L2ERC721BridgeWithdrawalWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+// L2ERC721BridgeWithdrawalsByTokenPair retrieves a list of ERC721 withdrawals of the specified token pair, from the
+// perspective of L2, coupled with the L1/L2 transaction hashes that complete the bridge transaction.
+func (db *bridgeTransfersDB) L2ERC721BridgeWithdrawalsByTokenPair(tokenPair TokenPair, cursor string, limit int) (*L2ERC721BridgeWithdrawalsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	cursorClause := ""
+	if cursor != "" {
+		withdrawalHash := common.HexToHash(cursor)
+		var cursorWithdrawal L2ERC721BridgeWithdrawal
+		result := db.gorm.Model(&L2ERC721BridgeWithdrawal{}).Where(&L2ERC721BridgeWithdrawal{TransactionWithdrawalHash: withdrawalHash}).Take(&cursorWithdrawal)
+		if result.Error != nil {
+			return nil, fmt.Errorf("unable to find withdrawal with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		}
+		cursorClause = fmt.Sprintf("l2_erc721_bridge_withdrawals.timestamp <= %d", cursorWithdrawal.Timestamp)
+	}
+
+	withdrawalsQuery := db.gorm.Model(&L2ERC721BridgeWithdrawal{})
+	withdrawalsQuery = withdrawalsQuery.Where(&tokenPair)
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_erc721_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_erc721_bridge_withdrawals.from_address, l2_erc721_bridge_withdrawals.to_address, l2_erc721_bridge_withdrawals.token_id, l2_erc721_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+l2_erc721_bridge_withdrawals.timestamp, l2_erc721_bridge_withdrawals.cross_domain_message_hash, local_token_address, remote_token_address,
+l2_block_headers.number AS l2_block_number, proven_l1_events.timestamp AS proven_l1_timestamp, finalized_l1_events.timestamp AS finalized_l1_timestamp, succeeded`)
+	if cursorClause != "" {
+		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
+	}
+
+	withdrawals := []L2ERC721BridgeWithdrawalWithTransactionHashes{}
+	result := withdrawalsQuery.Order("l2_erc721_bridge_withdrawals.timestamp DESC").Limit(limit + 1).Find(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(withdrawals) > limit {
+		hasNextPage = true
+		nextCursor = withdrawals[limit].L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String()
+		withdrawals = withdrawals[:limit]
+	}
+
+	response := &L2ERC721BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * Bridged Tokens
+ */
+
+// BridgedTokens retrieves every token pair that has been deposited or withdrawn at least once, from the perspective of L1.
+// The standard of the token is one of "ETH", "ERC20" or "ERC721".
+func (db *bridgeTransfersDB) BridgedTokens() ([]BridgedToken, error) {
+	// Withdrawals are indexed from the perspective of L2, hence the local and remote token addresses are swapped
+	l1Deposits := db.gorm.Model(&L1BridgeDeposit{}).Select("local_token_address AS l1_token_address, remote_token_address AS l2_token_address, 'ERC20' AS standard")
+	l2Withdrawals := db.gorm.Model(&L2BridgeWithdrawal{}).Select("remote_token_address AS l1_token_address, local_token_address AS l2_token_address, 'ERC20' AS standard")
+	l1ERC721Deposits := db.gorm.Model(&L1ERC721BridgeDeposit{}).Select("local_token_address AS l1_token_address, remote_token_address AS l2_token_address, 'ERC721' AS standard")
+	l2ERC721Withdrawals := db.gorm.Model(&L2ERC721BridgeWithdrawal{}).Select("remote_token_address AS l1_token_address, local_token_address AS l2_token_address, 'ERC721' AS standard")
+
+	// UNION removes the duplicate token pairs
+	query := db.gorm.Raw("? UNION ? UNION ? UNION ? ORDER BY l1_token_address, l2_token_address", l1Deposits, l2Withdrawals, l1ERC721Deposits, l2ERC721Withdrawals)
+
+	tokens := []BridgedToken{}
+	result := query.Scan(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range tokens {
+		if tokens[i].L1TokenAddress == ETHTokenPair.LocalTokenAddress && tokens[i].L2TokenAddress == ETHTokenPair.RemoteTokenAddress {
+			tokens[i].Standard = "ETH"
+		}
+	}
+
+	return tokens, nil
+}
+
+// TokenValueLocked retrieves, for every token pair bridged through the StandardBridge, the sum of all deposits and the
+// sum of all withdrawals that have been successfully finalized on L1. The token pair is from the perspective of L1.
+func (db *bridgeTransfersDB) TokenValueLocked() ([]TokenValueLocked, error) {
+	deposits := db.gorm.Model(&L1BridgeDeposit{})
+	deposits = deposits.Select("local_token_address, remote_token_address, amount AS deposit_amount, 0 AS withdrawal_amount")
+
+	// Withdrawals are indexed from the perspective of L2, hence the local and remote token addresses are swapped
+	withdrawals := db.gorm.Model(&L2BridgeWithdrawal{})
+	withdrawals = withdrawals.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawals = withdrawals.Where("l2_transaction_withdrawals.succeeded")
+	withdrawals = withdrawals.Select("l2_bridge_withdrawals.remote_token_address AS local_token_address, l2_bridge_withdrawals.local_token_address AS remote_token_address, 0 AS deposit_amount, l2_bridge_withdrawals.amount AS withdrawal_amount")
+
+	query := db.gorm.Table("(? UNION ALL ?) AS transfers", deposits, withdrawals)
+	query = query.Select("local_token_address, remote_token_address, sum(deposit_amount) AS deposit_sum, sum(withdrawal_amount) AS withdrawal_sum")
+	query = query.Group("local_token_address, remote_token_address").Order("local_token_address, remote_token_address")
+
+	tokens := []TokenValueLocked{}
+	result := query.Scan(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return tokens, nil
+}
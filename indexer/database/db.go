@@ -17,8 +17,12 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// upsertsSetting marks the gorm sessions in which stored rows replace the already indexed ones
+const upsertsSetting = "indexer:upserts"
+
 type DB struct {
 	gorm *gorm.DB
 	log  log.Logger
@@ -103,6 +107,23 @@ func (db *DB) Transaction(fn func(db *DB) error) error {
 	})
 }
 
+// WithUpserts returns a DB that upserts the stored bridge state, replacing already indexed rows rather than
+// ignoring them, such that re-processing a block range applies fixes made to the processors. Block headers,
+// contract events and the linked proven, finalized & relayed events are never overwritten.
+func (db *DB) WithUpserts() *DB {
+	// A session propagates the setting to the statements & transactions created from it
+	upserts := db.gorm.Set(upsertsSetting, true).Session(&gorm.Session{})
+	return &DB{
+		gorm:               upserts,
+		log:                db.log,
+		Blocks:             newBlocksDB(db.log, upserts),
+		ContractEvents:     newContractEventsDB(db.log, upserts),
+		BridgeTransfers:    newBridgeTransfersDB(db.log, upserts),
+		BridgeMessages:     newBridgeMessagesDB(db.log, upserts),
+		BridgeTransactions: newBridgeTransactionsDB(db.log, upserts),
+	}
+}
+
 func (db *DB) Close() error {
 	db.log.Info("closing database")
 	sql, err := db.gorm.DB()
@@ -145,3 +166,19 @@ func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
 	db.log.Info("finished migrations")
 	return err
 }
+
+// deduplicated resolves conflicts of the stored rows with already indexed rows on the supplied column by ignoring
+// them. When upserting, the indexed rows are instead updated with the supplied columns, or all columns if none.
+func deduplicated(db *gorm.DB, column string, updateColumns ...string) *gorm.DB {
+	onConflict := clause.OnConflict{Columns: []clause.Column{{Name: column}}, DoNothing: true}
+	if upserts, ok := db.Get(upsertsSetting); ok && upserts.(bool) {
+		onConflict.DoNothing = false
+		if len(updateColumns) > 0 {
+			onConflict.DoUpdates = clause.AssignmentColumns(updateColumns)
+		} else {
+			onConflict.UpdateAll = true
+		}
+	}
+
+	return db.Clauses(onConflict)
+}
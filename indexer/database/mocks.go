@@ -1,7 +1,10 @@
 package database
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"github.com/stretchr/testify/mock"
@@ -42,6 +45,17 @@ func (m *MockBlocksView) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return header, args.Error(1)
 }
 
+func (m *MockBlocksView) L1LatestBlockHeaders(limit int) ([]L1BlockHeader, error) {
+	args := m.Called(limit)
+
+	headers, ok := args.Get(0).([]L1BlockHeader)
+	if !ok {
+		headers = nil
+	}
+
+	return headers, args.Error(1)
+}
+
 func (m *MockBlocksView) L2BlockHeader(common.Hash) (*L2BlockHeader, error) {
 	args := m.Called()
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
@@ -62,6 +76,27 @@ func (m *MockBlocksView) L2LatestBlockHeader() (*L2BlockHeader, error) {
 	return args.Get(0).(*L2BlockHeader), args.Error(1)
 }
 
+func (m *MockBlocksView) L2LatestBlockHeaders(limit int) ([]L2BlockHeader, error) {
+	args := m.Called(limit)
+
+	headers, ok := args.Get(0).([]L2BlockHeader)
+	if !ok {
+		headers = nil
+	}
+
+	return headers, args.Error(1)
+}
+
+func (m *MockBlocksView) LatestOutputProposal() (*OutputProposal, error) {
+	args := m.Called()
+
+	proposal, ok := args.Get(0).(*OutputProposal)
+	if !ok {
+		proposal = nil
+	}
+	return proposal, args.Error(1)
+}
+
 type MockBlocksDB struct {
 	MockBlocksView
 }
@@ -76,6 +111,26 @@ func (m *MockBlocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
 	return args.Error(1)
 }
 
+func (m *MockBlocksDB) DeleteL1BlockHeadersAfter(height *big.Int) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
+func (m *MockBlocksDB) DeleteL2BlockHeadersAfter(height *big.Int) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
+func (m *MockBlocksDB) StoreOutputProposals(outputs []OutputProposal) error {
+	args := m.Called(outputs)
+	return args.Error(0)
+}
+
+func (m *MockBlocksDB) MarkOutputProposalsDeleted(fromL2OutputIndex *big.Int, outputsDeletedGUID uuid.UUID) error {
+	args := m.Called(fromL2OutputIndex, outputsDeletedGUID)
+	return args.Error(0)
+}
+
 // MockDB is a mock database that can be used for testing
 type MockDB struct {
 	MockBlocks *MockBlocksDB
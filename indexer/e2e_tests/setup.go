@@ -123,7 +123,7 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 	apiLog := testlog.Logger(t, log.LvlInfo).New("role", "indexer_api")
 
 	apiCfg := &api.Config{
-		DB: &api.TestDBConnector{BridgeTransfers: ix.DB.BridgeTransfers}, // reuse the same DB
+		DB: &api.TestDBConnector{BridgeTransfers: ix.DB.BridgeTransfers, Blocks: ix.DB.Blocks}, // reuse the same DB
 		HTTPServer: config.ServerConfig{
 			Host: "127.0.0.1",
 			Port: 0,
@@ -12,10 +12,13 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/BLASTchain/blast/indexer/bigint"
 	"github.com/BLASTchain/blast/indexer/node"
 	"github.com/BLASTchain/blast/bl-service/clock"
 )
 
+var errBatchReorged = errors.New("batch reorged by the provider")
+
 type Config struct {
 	LoopIntervalMsec uint
 	HeaderBufferSize uint
@@ -52,6 +55,10 @@ type ETLBatch struct {
 
 	Logs           []types.Log
 	HeadersWithLog map[common.Hash]bool
+
+	// CommonAncestor is set, instead of the headers, when the provider has reorged the
+	// traversed headers. The indexed state after the common ancestor must be rolled back.
+	CommonAncestor *types.Header
 }
 
 // Start starts the ETL polling routine. The ETL work should be stopped with Close().
@@ -79,8 +86,11 @@ func (etl *ETL) tick(_ context.Context) {
 	if len(etl.headers) > 0 {
 		etl.log.Info("retrying previous batch")
 	} else {
+		lastTraversedHeader := etl.headerTraversal.LastTraversedHeader()
 		newHeaders, err := etl.headerTraversal.NextHeaders(etl.headerBufferSize)
-		if err != nil {
+		if errors.Is(err, node.ErrHeaderTraversalReorg) {
+			etl.handleReorg(lastTraversedHeader)
+		} else if err != nil {
 			etl.log.Error("error querying for headers", "err", err)
 		} else if len(newHeaders) == 0 {
 			etl.log.Warn("no new headers. etl at head?")
@@ -98,16 +108,70 @@ func (etl *ETL) tick(_ context.Context) {
 	err := etl.processBatch(etl.headers)
 	if err == nil {
 		etl.headers = nil
+	} else if errors.Is(err, errBatchReorged) {
+		// drop the batch such that the canonical headers are traversed again
+		etl.headerTraversal.Rewind(len(etl.headers))
+		etl.headers = nil
 	}
 
 	done(err)
 }
 
+// handleReorg signals the consumer to roll back the indexed state after the common ancestor
+// that the header traversal has been rewound to
+func (etl *ETL) handleReorg(reorgedHeader *types.Header) {
+	commonAncestor := etl.headerTraversal.LastTraversedHeader()
+	depth := new(big.Int).Sub(reorgedHeader.Number, commonAncestor.Number)
+	etl.log.Warn("detected reorg", "common_ancestor_block_number", commonAncestor.Number, "common_ancestor_block_hash", commonAncestor.Hash(),
+		"reorged_block_number", reorgedHeader.Number, "reorged_block_hash", reorgedHeader.Hash(), "depth", depth)
+	etl.metrics.RecordReorg(depth.Uint64())
+
+	reorgLog := etl.log.New("common_ancestor_block_number", commonAncestor.Number)
+	etl.etlBatches <- &ETLBatch{Logger: reorgLog, CommonAncestor: commonAncestor}
+}
+
 func (etl *ETL) processBatch(headers []types.Header) error {
 	if len(headers) == 0 {
 		return nil
 	}
 
+	batch, err := etl.extractBatch(headers)
+	if err != nil {
+		return err
+	}
+
+	etl.etlBatches <- batch
+	return nil
+}
+
+// backfill extracts the headers & logs of the inclusive range [fromHeight, toHeight], independent of the header
+// traversal, and hands the batches of at most the header buffer size to the supplied handler in order.
+func (etl *ETL) backfill(fromHeight, toHeight *big.Int, handle func(*ETLBatch) error) error {
+	for height := fromHeight; height.Cmp(toHeight) <= 0; {
+		endHeight := bigint.Clamp(height, toHeight, etl.headerBufferSize)
+		headers, err := etl.EthClient.BlockHeadersByRange(height, endHeight)
+		if err != nil {
+			return fmt.Errorf("failed to query headers: %w", err)
+		} else if uint64(len(headers)) != new(big.Int).Sub(endHeight, height).Uint64()+1 {
+			return fmt.Errorf("provider is missing headers in range [%d, %d]", height, endHeight)
+		}
+
+		batch, err := etl.extractBatch(headers)
+		if err != nil {
+			return err
+		}
+		if err := handle(batch); err != nil {
+			return err
+		}
+
+		height = new(big.Int).Add(endHeight, bigint.One)
+	}
+
+	return nil
+}
+
+// extractBatch extracts the logs of the configured contracts emitted within the headers
+func (etl *ETL) extractBatch(headers []types.Header) (*ETLBatch, error) {
 	firstHeader, lastHeader := headers[0], headers[len(headers)-1]
 	batchLog := etl.log.New("batch_start_block_number", firstHeader.Number, "batch_end_block_number", lastHeader.Number)
 	batchLog.Info("extracting batch", "size", len(headers))
@@ -123,16 +187,16 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 	logs, err := etl.EthClient.FilterLogs(filterQuery)
 	if err != nil {
 		batchLog.Info("failed to extract logs", "err", err)
-		return err
+		return nil, err
 	}
 
 	if logs.ToBlockHeader.Number.Cmp(lastHeader.Number) != 0 {
 		// Warn and simply wait for the provider to synchronize state
 		batchLog.Warn("mismatch in FilterLog#ToBlock number", "queried_to_block_number", lastHeader.Number, "reported_to_block_number", logs.ToBlockHeader.Number)
-		return fmt.Errorf("mismatch in FilterLog#ToBlock number")
+		return nil, fmt.Errorf("mismatch in FilterLog#ToBlock number")
 	} else if logs.ToBlockHeader.Hash() != lastHeader.Hash() {
-		batchLog.Error("mismatch in FitlerLog#ToBlock block hash!!!", "queried_to_block_hash", lastHeader.Hash().String(), "reported_to_block_hash", logs.ToBlockHeader.Hash().String())
-		return fmt.Errorf("mismatch in FitlerLog#ToBlock block hash!!!")
+		batchLog.Warn("mismatch in FitlerLog#ToBlock block hash", "queried_to_block_hash", lastHeader.Hash().String(), "reported_to_block_hash", logs.ToBlockHeader.Hash().String())
+		return nil, fmt.Errorf("mismatch in FitlerLog#ToBlock block hash: %w", errBatchReorged)
 	}
 
 	if len(logs.Logs) > 0 {
@@ -143,16 +207,13 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 		log := logs.Logs[i]
 		headersWithLog[log.BlockHash] = true
 		if _, ok := headerMap[log.BlockHash]; !ok {
-			// NOTE. Definitely an error state if the none of the headers were re-orged out in between
-			// the blocks and logs retrieval operations. Unlikely as long as the confirmation depth has
-			// been appropriately set or when we get to natively handling reorgs.
-			batchLog.Error("log found with block hash not in the batch", "block_hash", logs.Logs[i].BlockHash, "log_index", logs.Logs[i].Index)
-			return errors.New("parsed log with a block hash not in the batch")
+			// The headers were reorged out in between the blocks and logs retrieval operations
+			batchLog.Warn("log found with block hash not in the batch", "block_hash", logs.Logs[i].BlockHash, "log_index", logs.Logs[i].Index)
+			return nil, fmt.Errorf("parsed log with a block hash not in the batch: %w", errBatchReorged)
 		}
 	}
 
 	// ensure we use unique downstream references for the etl batch
 	headersRef := headers
-	etl.etlBatches <- &ETLBatch{Logger: batchLog, Headers: headersRef, HeaderMap: headerMap, Logs: logs.Logs, HeadersWithLog: headersWithLog}
-	return nil
+	return &ETLBatch{Logger: batchLog, Headers: headersRef, HeaderMap: headerMap, Logs: logs.Logs, HeadersWithLog: headersWithLog}, nil
 }
@@ -0,0 +1,148 @@
+package etl
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/metrics"
+	"github.com/BLASTchain/blast/bl-service/testlog"
+	"github.com/BLASTchain/blast/indexer/bigint"
+	"github.com/BLASTchain/blast/indexer/node"
+)
+
+// make a set of headers which chain onto the parent header
+func makeHeaders(numHeaders int, parent types.Header, extra string) []types.Header {
+	headers := make([]types.Header, numHeaders)
+	for i := range headers {
+		headers[i] = types.Header{Number: new(big.Int).Add(parent.Number, bigint.One), ParentHash: parent.Hash(), Extra: []byte(extra)}
+		parent = headers[i]
+	}
+	return headers
+}
+
+func TestETLReorg(t *testing.T) {
+	client := new(node.MockEthClient)
+
+	// blocks [0..9]
+	genesis := types.Header{Number: big.NewInt(0)}
+	headers := append([]types.Header{genesis}, makeHeaders(9, genesis, "")...)
+
+	etl := &ETL{
+		log:              testlog.Logger(t, log.LvlInfo),
+		metrics:          NewMetrics(metrics.NewRegistry(), "l2"),
+		headerBufferSize: 10,
+		headerTraversal:  node.NewHeaderTraversal(client, &headers[4], bigint.Zero),
+		etlBatches:       make(chan *ETLBatch, 1),
+		EthClient:        client,
+	}
+
+	// blocks [5..9] are extracted
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[9], nil).Times(1)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers[5:], nil)
+	client.On("FilterLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool { return q.FromBlock.Int64() == 5 })).Return(node.Logs{ToBlockHeader: &headers[9]}, nil)
+
+	etl.tick(context.Background())
+	batch := <-etl.etlBatches
+	require.Nil(t, batch.CommonAncestor)
+	require.Equal(t, headers[5:], batch.Headers)
+
+	// the provider reorgs blocks [7..9] and extends the chain to block 12
+	providerHeaders := append(headers[:7:7], makeHeaders(6, headers[6], "reorg")...)
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&providerHeaders[12], nil)
+	for i := range providerHeaders {
+		number := int64(i)
+		client.On("BlockHeaderByNumber", mock.MatchedBy(func(n *big.Int) bool { return n != nil && n.Int64() == number })).Return(&providerHeaders[i], nil)
+	}
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(10)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[10:], nil)
+
+	// the consumer is signaled to roll back to the common ancestor
+	etl.tick(context.Background())
+	batch = <-etl.etlBatches
+	require.NotNil(t, batch.CommonAncestor)
+	require.Equal(t, headers[6].Hash(), batch.CommonAncestor.Hash())
+	require.Empty(t, batch.Headers)
+
+	// the canonical blocks [7..12] are extracted next
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(7)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[7:], nil)
+	client.On("FilterLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool { return q.FromBlock.Int64() == 7 })).Return(node.Logs{ToBlockHeader: &providerHeaders[12]}, nil)
+
+	etl.tick(context.Background())
+	batch = <-etl.etlBatches
+	require.Nil(t, batch.CommonAncestor)
+	require.Equal(t, providerHeaders[7:], batch.Headers)
+}
+
+func TestETLBatchReorged(t *testing.T) {
+	client := new(node.MockEthClient)
+
+	// blocks [0..9]
+	genesis := types.Header{Number: big.NewInt(0)}
+	headers := append([]types.Header{genesis}, makeHeaders(9, genesis, "")...)
+
+	etl := &ETL{
+		log:              testlog.Logger(t, log.LvlInfo),
+		metrics:          NewMetrics(metrics.NewRegistry(), "l2"),
+		headerBufferSize: 10,
+		headerTraversal:  node.NewHeaderTraversal(client, &headers[4], bigint.Zero),
+		etlBatches:       make(chan *ETLBatch, 1),
+		EthClient:        client,
+	}
+
+	// blocks [5..9] are reorged in between the blocks and logs retrieval
+	reorgedHeaders := makeHeaders(5, headers[4], "reorg")
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[9], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers[5:], nil)
+	client.On("FilterLogs", mock.Anything).Return(node.Logs{ToBlockHeader: &reorgedHeaders[4]}, nil)
+
+	// the batch is dropped, and the headers are traversed again
+	etl.tick(context.Background())
+	require.Empty(t, etl.etlBatches)
+	require.Empty(t, etl.headers)
+	require.Equal(t, headers[4].Hash(), etl.headerTraversal.LastTraversedHeader().Hash())
+}
+
+func TestETLBackfill(t *testing.T) {
+	client := new(node.MockEthClient)
+
+	// blocks [0..24]
+	genesis := types.Header{Number: big.NewInt(0)}
+	headers := append([]types.Header{genesis}, makeHeaders(24, genesis, "")...)
+
+	etl := &ETL{
+		log:              testlog.Logger(t, log.LvlInfo),
+		metrics:          NewMetrics(metrics.NewRegistry(), "l2"),
+		headerBufferSize: 10,
+		EthClient:        client,
+	}
+
+	// blocks [3..24] are extracted in batches of the header buffer size
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(3)), mock.MatchedBy(bigint.Matcher(12))).Return(headers[3:13], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(13)), mock.MatchedBy(bigint.Matcher(22))).Return(headers[13:23], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(23)), mock.MatchedBy(bigint.Matcher(24))).Return(headers[23:], nil)
+	for _, end := range []int{12, 22, 24} {
+		end := end
+		client.On("FilterLogs", mock.MatchedBy(func(q ethereum.FilterQuery) bool { return q.ToBlock.Int64() == int64(end) })).Return(node.Logs{ToBlockHeader: &headers[end]}, nil)
+	}
+
+	var batches []*ETLBatch
+	require.NoError(t, etl.backfill(big.NewInt(3), big.NewInt(24), func(batch *ETLBatch) error {
+		batches = append(batches, batch)
+		return nil
+	}))
+	require.Len(t, batches, 3)
+	require.Equal(t, headers[3:13], batches[0].Headers)
+	require.Equal(t, headers[13:23], batches[1].Headers)
+	require.Equal(t, headers[23:], batches[2].Headers)
+
+	// the range must be available from the provider
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(25)), mock.MatchedBy(bigint.Matcher(30))).Return([]types.Header{}, nil)
+	require.ErrorContains(t, etl.backfill(big.NewInt(25), big.NewInt(30), func(*ETLBatch) error { return nil }), "missing headers")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -59,16 +60,21 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 		return nil, err
 	}
 
-	latestHeader, err := db.Blocks.L1LatestBlockHeader()
+	// The latest indexed headers seed the reorg window of the header traversal
+	latestHeaders, err := db.Blocks.L1LatestBlockHeaders(node.ReorgWindowSize)
 	if err != nil {
 		return nil, err
 	}
+	traversedHeaders := make([]types.Header, len(latestHeaders))
+	for i := range latestHeaders {
+		traversedHeaders[i] = *latestHeaders[i].RLPHeader.Header()
+	}
 
 	// Determine the starting height for traversal
 	var fromHeader *types.Header
-	if latestHeader != nil {
-		log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
-		fromHeader = latestHeader.RLPHeader.Header()
+	if len(traversedHeaders) > 0 {
+		fromHeader = &traversedHeaders[len(traversedHeaders)-1]
+		log.Info("detected last indexed block", "number", fromHeader.Number, "hash", fromHeader.Hash())
 	} else if cfg.StartHeight.BitLen() > 0 {
 		log.Info("no indexed state starting from supplied L1 height", "height", cfg.StartHeight.String())
 		header, err := client.BlockHeaderByNumber(cfg.StartHeight)
@@ -77,6 +83,7 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 		}
 
 		fromHeader = header
+		traversedHeaders = []types.Header{*header}
 	} else {
 		log.Info("no indexed state, starting from genesis")
 	}
@@ -92,7 +99,7 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversalFromHeaders(client, traversedHeaders, cfg.ConfirmationDepth),
 		contracts:       l1Contracts,
 		etlBatches:      etlBatches,
 
@@ -153,6 +160,36 @@ func (l1Etl *L1ETL) Start() error {
 }
 
 func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
+	if batch.CommonAncestor != nil {
+		return l1Etl.handleReorg(batch)
+	}
+
+	if stored, err := l1Etl.storeBatch(batch); err != nil || !stored {
+		return err
+	}
+
+	batch.Logger.Info("indexed batch")
+	l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	l1Etl.notifyListeners()
+	return nil
+}
+
+// notifyListeners signals the listeners that the indexed state has changed
+func (l1Etl *L1ETL) notifyListeners() {
+	l1Etl.mu.Lock()
+	defer l1Etl.mu.Unlock()
+	for i := range l1Etl.listeners {
+		select {
+		case l1Etl.listeners[i] <- struct{}{}:
+		default:
+			// do nothing if the listener hasn't picked
+			// up the previous notif
+		}
+	}
+}
+
+// storeBatch persists the L1 blocks with an emitted log, and their logs, reporting whether there were any
+func (l1Etl *L1ETL) storeBatch(batch *ETLBatch) (bool, error) {
 	// Index incoming batches (only L1 blocks that have an emitted log)
 	l1BlockHeaders := make([]database.L1BlockHeader, 0, len(batch.Headers))
 	for i := range batch.Headers {
@@ -163,7 +200,7 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 
 	if len(l1BlockHeaders) == 0 {
 		batch.Logger.Info("no l1 blocks with logs in batch")
-		return nil
+		return false, nil
 	}
 
 	l1ContractEvents := make([]database.L1ContractEvent, len(batch.Logs))
@@ -196,24 +233,58 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 		// a-ok!
 		return nil, nil
 	}); err != nil {
-		return err
+		return false, err
 	}
 
-	batch.Logger.Info("indexed batch")
-	l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	return true, nil
+}
 
-	// Notify Listeners
-	l1Etl.mu.Lock()
-	defer l1Etl.mu.Unlock()
-	for i := range l1Etl.listeners {
-		select {
-		case l1Etl.listeners[i] <- struct{}{}:
-		default:
-			// do nothing if the listener hasn't picked
-			// up the previous notif
+// Backfill extracts & persists the L1 blocks and logs of the inclusive range [fromHeight, toHeight]. Already
+// indexed data is left as is, such that this can run alongside the live L1ETL on the tip. The traversed
+// headers, latest header and listeners of the L1ETL are unaffected.
+func (l1Etl *L1ETL) Backfill(fromHeight, toHeight *big.Int) error {
+	return l1Etl.backfill(fromHeight, toHeight, func(batch *ETLBatch) error {
+		_, err := l1Etl.storeBatch(batch)
+		return err
+	})
+}
+
+// handleReorg rolls back the indexed state after the common ancestor of the reorg
+func (l1Etl *L1ETL) handleReorg(batch *ETLBatch) error {
+	height := batch.CommonAncestor.Number
+
+	// Continually try to roll back the indexed state. If it fails after 10 attempts, we simply error out
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	if _, err := retry.Do[interface{}](l1Etl.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+		if err := l1Etl.db.Transaction(func(tx *database.DB) error {
+			// Unlink the L1 events finalizing L2 bridge state, as deleting them would cascade to it
+			if err := tx.BridgeTransactions.UnmarkL2TransactionWithdrawalEventsAfter(height); err != nil {
+				return err
+			}
+			if err := tx.BridgeMessages.UnmarkRelayedL2BridgeMessagesAfter(height); err != nil {
+				return err
+			}
+			return tx.Blocks.DeleteL1BlockHeadersAfter(height)
+		}); err != nil {
+			batch.Logger.Error("unable to roll back reorged state", "err", err)
+			return nil, fmt.Errorf("unable to roll back reorged state: %w", err)
 		}
+
+		l1Etl.ETL.metrics.RecordIndexedLatestHeight(height)
+
+		// a-ok!
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+
+	batch.Logger.Info("rolled back reorged state")
+	if l1Etl.LatestHeader != nil && l1Etl.LatestHeader.Number.Cmp(height) > 0 {
+		l1Etl.LatestHeader = batch.CommonAncestor
 	}
 
+	// Listeners reload the rolled back state without awaiting the next batch
+	l1Etl.notifyListeners()
 	return nil
 }
 
@@ -41,7 +41,7 @@ func TestL1ETLConstruction(t *testing.T) {
 				db := database.NewMockDB()
 
 				testStart := big.NewInt(100)
-				db.MockBlocks.On("L1LatestBlockHeader").Return(nil, nil)
+				db.MockBlocks.On("L1LatestBlockHeaders", node.ReorgWindowSize).Return(nil, nil)
 
 				client.On("BlockHeaderByNumber", mock.MatchedBy(
 					bigint.Matcher(100))).Return(
@@ -73,13 +73,11 @@ func TestL1ETLConstruction(t *testing.T) {
 
 				testStart := big.NewInt(100)
 
-				db.MockBlocks.On("L1LatestBlockHeader").Return(
-					&database.L1BlockHeader{
-						BlockHeader: database.BlockHeader{
-							RLPHeader: &database.RLPHeader{
-								Number: big.NewInt(69),
-							},
-						}}, nil)
+				db.MockBlocks.On("L1LatestBlockHeaders", node.ReorgWindowSize).Return(
+					[]database.L1BlockHeader{
+						{BlockHeader: database.BlockHeader{RLPHeader: &database.RLPHeader{Number: big.NewInt(42)}}},
+						{BlockHeader: database.BlockHeader{RLPHeader: &database.RLPHeader{Number: big.NewInt(69)}}},
+					}, nil)
 
 				client.On("GethEthClient").Return(nil)
 
@@ -97,6 +95,10 @@ func TestL1ETLConstruction(t *testing.T) {
 				header := etl.headerTraversal.LastTraversedHeader()
 
 				require.True(t, header.Number.Cmp(big.NewInt(69)) == 0)
+
+				// the stored headers are retained to rewind past the last indexed header
+				etl.headerTraversal.Rewind(1)
+				require.True(t, etl.headerTraversal.LastTraversedHeader().Number.Cmp(big.NewInt(42)) == 0)
 			},
 		},
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -56,15 +57,20 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 		return nil, err
 	}
 
-	latestHeader, err := db.Blocks.L2LatestBlockHeader()
+	// The latest indexed headers seed the reorg window of the header traversal
+	latestHeaders, err := db.Blocks.L2LatestBlockHeaders(node.ReorgWindowSize)
 	if err != nil {
 		return nil, err
 	}
+	traversedHeaders := make([]types.Header, len(latestHeaders))
+	for i := range latestHeaders {
+		traversedHeaders[i] = *latestHeaders[i].RLPHeader.Header()
+	}
 
 	var fromHeader *types.Header
-	if latestHeader != nil {
-		log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
-		fromHeader = latestHeader.RLPHeader.Header()
+	if len(traversedHeaders) > 0 {
+		fromHeader = &traversedHeaders[len(traversedHeaders)-1]
+		log.Info("detected last indexed block", "number", fromHeader.Number, "hash", fromHeader.Hash())
 	} else {
 		log.Info("no indexed state, starting from genesis")
 	}
@@ -76,7 +82,7 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 
 		log:             log,
 		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+		headerTraversal: node.NewHeaderTraversalFromHeaders(client, traversedHeaders, cfg.ConfirmationDepth),
 		contracts:       l2Contracts,
 		etlBatches:      etlBatches,
 
@@ -138,6 +144,36 @@ func (l2Etl *L2ETL) Start() error {
 }
 
 func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
+	if batch.CommonAncestor != nil {
+		return l2Etl.handleReorg(batch)
+	}
+
+	if err := l2Etl.storeBatch(batch); err != nil {
+		return err
+	}
+
+	batch.Logger.Info("indexed batch")
+	l2Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	l2Etl.notifyListeners()
+	return nil
+}
+
+// notifyListeners signals the listeners that the indexed state has changed
+func (l2Etl *L2ETL) notifyListeners() {
+	l2Etl.mu.Lock()
+	defer l2Etl.mu.Unlock()
+	for i := range l2Etl.listeners {
+		select {
+		case l2Etl.listeners[i] <- struct{}{}:
+		default:
+			// do nothing if the listener hasn't picked
+			// up the previous notif
+		}
+	}
+}
+
+// storeBatch persists the L2 blocks, and the logs emitted within them
+func (l2Etl *L2ETL) storeBatch(batch *ETLBatch) error {
 	l2BlockHeaders := make([]database.L2BlockHeader, len(batch.Headers))
 	for i := range batch.Headers {
 		l2BlockHeaders[i] = database.L2BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&batch.Headers[i])}
@@ -177,21 +213,51 @@ func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
 		return err
 	}
 
-	batch.Logger.Info("indexed batch")
-	l2Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	return nil
+}
 
-	// Notify Listeners
-	l2Etl.mu.Lock()
-	defer l2Etl.mu.Unlock()
-	for i := range l2Etl.listeners {
-		select {
-		case l2Etl.listeners[i] <- struct{}{}:
-		default:
-			// do nothing if the listener hasn't picked
-			// up the previous notif
+// Backfill extracts & persists the L2 blocks and logs of the inclusive range [fromHeight, toHeight]. Already
+// indexed data is left as is, such that this can run alongside the live L2ETL on the tip. The traversed
+// headers, latest header and listeners of the L2ETL are unaffected.
+func (l2Etl *L2ETL) Backfill(fromHeight, toHeight *big.Int) error {
+	return l2Etl.backfill(fromHeight, toHeight, func(batch *ETLBatch) error {
+		return l2Etl.storeBatch(batch)
+	})
+}
+
+// handleReorg rolls back the indexed state after the common ancestor of the reorg
+func (l2Etl *L2ETL) handleReorg(batch *ETLBatch) error {
+	height := batch.CommonAncestor.Number
+
+	// Continually try to roll back the indexed state. If it fails after 10 attempts, we simply error out
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	if _, err := retry.Do[interface{}](l2Etl.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+		if err := l2Etl.db.Transaction(func(tx *database.DB) error {
+			// Unlink the L2 events finalizing L1 bridge state, as deleting them would cascade to it
+			if err := tx.BridgeMessages.UnmarkRelayedL1BridgeMessagesAfter(height); err != nil {
+				return err
+			}
+			return tx.Blocks.DeleteL2BlockHeadersAfter(height)
+		}); err != nil {
+			batch.Logger.Error("unable to roll back reorged state", "err", err)
+			return nil, fmt.Errorf("unable to roll back reorged state: %w", err)
 		}
+
+		l2Etl.ETL.metrics.RecordIndexedLatestHeight(height)
+
+		// a-ok!
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+
+	batch.Logger.Info("rolled back reorged state")
+	if l2Etl.LatestHeader != nil && l2Etl.LatestHeader.Number.Cmp(height) > 0 {
+		l2Etl.LatestHeader = batch.CommonAncestor
 	}
 
+	// Listeners reload the rolled back state without awaiting the next batch
+	l2Etl.notifyListeners()
 	return nil
 }
 
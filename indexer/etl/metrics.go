@@ -20,6 +20,9 @@ type Metricer interface {
 	RecordIndexedLatestHeight(height *big.Int)
 	RecordIndexedHeaders(size int)
 	RecordIndexedLog(contractAddress common.Address)
+
+	// Reorgs
+	RecordReorg(depth uint64)
 }
 
 type etlMetrics struct {
@@ -31,6 +34,9 @@ type etlMetrics struct {
 	indexedLatestHeight prometheus.Gauge
 	indexedHeaders      prometheus.Counter
 	indexedLogs         *prometheus.CounterVec
+
+	reorgs     prometheus.Counter
+	reorgDepth prometheus.Histogram
 }
 
 func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
@@ -80,6 +86,19 @@ func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
 		}, []string{
 			"contract",
 		}),
+		reorgs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorgs_total",
+			Help:      "number of reorgs of the traversed headers detected by the etl",
+		}),
+		reorgDepth: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorg_depth",
+			Help:      "number of traversed headers replaced by a reorg",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}),
 	}
 }
 
@@ -109,3 +128,8 @@ func (m *etlMetrics) RecordIndexedHeaders(size int) {
 func (m *etlMetrics) RecordIndexedLog(addr common.Address) {
 	m.indexedLogs.WithLabelValues(addr.String()).Inc()
 }
+
+func (m *etlMetrics) RecordReorg(depth uint64) {
+	m.reorgs.Inc()
+	m.reorgDepth.Observe(float64(depth))
+}
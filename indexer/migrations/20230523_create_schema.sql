@@ -84,6 +84,27 @@ CREATE INDEX IF NOT EXISTS l2_contract_events_event_signature ON l2_contract_eve
 CREATE INDEX IF NOT EXISTS l2_contract_events_contract_address ON l2_contract_events(contract_address);
 ALTER TABLE l2_contract_events ADD UNIQUE (block_hash, log_index);
 
+/**
+ * ROLLUP DATA
+ */
+
+-- L2OutputOracle
+CREATE TABLE IF NOT EXISTS output_proposals (
+    output_root          VARCHAR PRIMARY KEY,
+    l2_output_index      UINT256 NOT NULL,
+    l2_block_number      UINT256 NOT NULL,
+    output_proposed_guid VARCHAR NOT NULL UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE,
+
+    -- timestamp of the L1 block the output was proposed in
+    timestamp INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS output_proposals_l2_block_number ON output_proposals(l2_block_number);
+CREATE INDEX IF NOT EXISTS output_proposals_output_proposed_guid ON output_proposals(output_proposed_guid);
+
+-- Set once the output is deleted by the L2OutputOracle. Cleared when the OutputsDeleted event is rolled back
+ALTER TABLE output_proposals ADD COLUMN IF NOT EXISTS outputs_deleted_guid VARCHAR REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS output_proposals_outputs_deleted_guid ON output_proposals(outputs_deleted_guid);
+
 /**
  * BRIDGING DATA
  */
@@ -193,6 +214,7 @@ CREATE TABLE IF NOT EXISTS l1_bridge_deposits (
 CREATE INDEX IF NOT EXISTS l1_bridge_deposits_timestamp ON l1_bridge_deposits(timestamp);
 CREATE INDEX IF NOT EXISTS l1_bridge_deposits_cross_domain_message_hash ON l1_bridge_deposits(cross_domain_message_hash);
 CREATE INDEX IF NOT EXISTS l1_bridge_deposits_from_address ON l1_bridge_deposits(from_address);
+CREATE INDEX IF NOT EXISTS l1_bridge_deposits_token_pair ON l1_bridge_deposits(local_token_address, remote_token_address);
 
 CREATE TABLE IF NOT EXISTS l2_bridge_withdrawals (
     transaction_withdrawal_hash VARCHAR PRIMARY KEY REFERENCES l2_transaction_withdrawals(withdrawal_hash) ON DELETE CASCADE,
@@ -210,3 +232,39 @@ CREATE TABLE IF NOT EXISTS l2_bridge_withdrawals (
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_timestamp ON l2_bridge_withdrawals(timestamp);
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_cross_domain_message_hash ON l2_bridge_withdrawals(cross_domain_message_hash);
 CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_from_address ON l2_bridge_withdrawals(from_address);
+CREATE INDEX IF NOT EXISTS l2_bridge_withdrawals_token_pair ON l2_bridge_withdrawals(local_token_address, remote_token_address);
+
+-- ERC721Bridge
+CREATE TABLE IF NOT EXISTS l1_erc721_bridge_deposits (
+    transaction_source_hash   VARCHAR PRIMARY KEY REFERENCES l1_transaction_deposits(source_hash) ON DELETE CASCADE,
+    cross_domain_message_hash VARCHAR NOT NULL UNIQUE REFERENCES l1_bridge_messages(message_hash) ON DELETE CASCADE,
+
+    -- Deposit information
+    from_address         VARCHAR NOT NULL,
+    to_address           VARCHAR NOT NULL,
+    local_token_address  VARCHAR NOT NULL,
+    remote_token_address VARCHAR NOT NULL,
+    token_id             UINT256 NOT NULL,
+    data                 VARCHAR NOT NULL,
+    timestamp            INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS l1_erc721_bridge_deposits_timestamp ON l1_erc721_bridge_deposits(timestamp);
+CREATE INDEX IF NOT EXISTS l1_erc721_bridge_deposits_from_address ON l1_erc721_bridge_deposits(from_address);
+CREATE INDEX IF NOT EXISTS l1_erc721_bridge_deposits_token_pair ON l1_erc721_bridge_deposits(local_token_address, remote_token_address);
+
+CREATE TABLE IF NOT EXISTS l2_erc721_bridge_withdrawals (
+    transaction_withdrawal_hash VARCHAR PRIMARY KEY REFERENCES l2_transaction_withdrawals(withdrawal_hash) ON DELETE CASCADE,
+    cross_domain_message_hash   VARCHAR NOT NULL UNIQUE REFERENCES l2_bridge_messages(message_hash) ON DELETE CASCADE,
+
+    -- Withdrawal information
+    from_address         VARCHAR NOT NULL,
+    to_address           VARCHAR NOT NULL,
+    local_token_address  VARCHAR NOT NULL,
+    remote_token_address VARCHAR NOT NULL,
+    token_id             UINT256 NOT NULL,
+    data                 VARCHAR NOT NULL,
+    timestamp            INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS l2_erc721_bridge_withdrawals_timestamp ON l2_erc721_bridge_withdrawals(timestamp);
+CREATE INDEX IF NOT EXISTS l2_erc721_bridge_withdrawals_from_address ON l2_erc721_bridge_withdrawals(from_address);
+CREATE INDEX IF NOT EXISTS l2_erc721_bridge_withdrawals_token_pair ON l2_erc721_bridge_withdrawals(local_token_address, remote_token_address);
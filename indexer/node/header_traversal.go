@@ -6,29 +6,59 @@ import (
 	"math/big"
 
 	"github.com/BLASTchain/blast/indexer/bigint"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
 	ErrHeaderTraversalAheadOfProvider            = errors.New("the HeaderTraversal's internal state is ahead of the provider")
 	ErrHeaderTraversalAndProviderMismatchedState = errors.New("the HeaderTraversal and provider have diverged in state")
+	ErrHeaderTraversalReorg                      = errors.New("the provider has reorged the traversed headers")
 )
 
+// ReorgWindowSize is the number of the most recently traversed headers that are retained
+// in order to find the common ancestor with the provider when its chain reorgs.
+const ReorgWindowSize = 1_000
+
 type HeaderTraversal struct {
 	ethClient EthClient
 
 	latestHeader        *types.Header
 	lastTraversedHeader *types.Header
 
+	// the most recently traversed headers, ending with `lastTraversedHeader`
+	traversedHeaders []types.Header
+
 	blockConfirmationDepth *big.Int
 }
 
 // NewHeaderTraversal instantiates a new instance of HeaderTraversal against the supplied rpc client.
 // The HeaderTraversal will start fetching blocks starting from the supplied header unless nil, indicating genesis.
 func NewHeaderTraversal(ethClient EthClient, fromHeader *types.Header, confDepth *big.Int) *HeaderTraversal {
+	var traversedHeaders []types.Header
+	if fromHeader != nil {
+		traversedHeaders = append(traversedHeaders, *fromHeader)
+	}
+	return NewHeaderTraversalFromHeaders(ethClient, traversedHeaders, confDepth)
+}
+
+// NewHeaderTraversalFromHeaders instantiates a new instance of HeaderTraversal that continues after the supplied,
+// previously traversed headers in ascending order, or from genesis if there are none. The headers need not be
+// contiguous, and are retained to find the common ancestor with the provider when its chain has reorged them.
+func NewHeaderTraversalFromHeaders(ethClient EthClient, traversedHeaders []types.Header, confDepth *big.Int) *HeaderTraversal {
+	if len(traversedHeaders) > ReorgWindowSize {
+		traversedHeaders = traversedHeaders[len(traversedHeaders)-ReorgWindowSize:]
+	}
+
+	var lastTraversedHeader *types.Header
+	if len(traversedHeaders) > 0 {
+		lastTraversedHeader = &traversedHeaders[len(traversedHeaders)-1]
+	}
+
 	return &HeaderTraversal{
 		ethClient:              ethClient,
-		lastTraversedHeader:    fromHeader,
+		lastTraversedHeader:    lastTraversedHeader,
+		traversedHeaders:       traversedHeaders,
 		blockConfirmationDepth: confDepth,
 	}
 }
@@ -48,7 +78,11 @@ func (f *HeaderTraversal) LastTraversedHeader() *types.Header {
 }
 
 // NextHeaders retrieves the next set of headers that have been
-// marked as finalized by the connected client, bounded by the supplied size
+// marked as finalized by the connected client, bounded by the supplied size.
+//
+// If the provider has reorged the traversed headers, ErrHeaderTraversalReorg is returned and the
+// traversal is rewound to the common ancestor, reported by `LastTraversedHeader`. The canonical
+// headers after the common ancestor are retrieved by the subsequent call.
 func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
 	latestHeader, err := f.ethClient.BlockHeaderByNumber(nil)
 	if err != nil {
@@ -90,11 +124,54 @@ func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastTraversedHeader != nil && headers[0].ParentHash != f.lastTraversedHeader.Hash() {
-		// The indexer's state is in an irrecoverable state relative to the provider. This
-		// should never happen since the indexer is dealing with only finalized blocks.
-		return nil, ErrHeaderTraversalAndProviderMismatchedState
+		if err := f.rewindToCommonAncestor(); err != nil {
+			return nil, err
+		}
+		return nil, ErrHeaderTraversalReorg
+	}
+
+	// Retain the parent of this batch at minimum, such that the batch can be rewound
+	f.traversedHeaders = append(f.traversedHeaders, headers...)
+	windowSize := max(ReorgWindowSize, numHeaders+1)
+	if len(f.traversedHeaders) > windowSize {
+		f.traversedHeaders = f.traversedHeaders[len(f.traversedHeaders)-windowSize:]
 	}
 
 	f.lastTraversedHeader = &headers[numHeaders-1]
 	return headers, nil
 }
+
+// Rewind drops the last `n` traversed headers, such that they are retrieved again
+// by the subsequent call to `NextHeaders`.
+func (f *HeaderTraversal) Rewind(n int) {
+	keep := max(len(f.traversedHeaders)-n, 0)
+	f.traversedHeaders = f.traversedHeaders[:keep]
+	if keep == 0 {
+		f.lastTraversedHeader = nil
+	} else {
+		f.lastTraversedHeader = &f.traversedHeaders[keep-1]
+	}
+}
+
+// rewindToCommonAncestor walks back the retained traversed headers until one matches the
+// provider's chain, and rewinds the traversal to it.
+func (f *HeaderTraversal) rewindToCommonAncestor() error {
+	for i := len(f.traversedHeaders) - 1; i >= 0; i-- {
+		header := f.traversedHeaders[i]
+		providerHeader, err := f.ethClient.BlockHeaderByNumber(header.Number)
+		if errors.Is(err, ethereum.NotFound) {
+			continue // the provider's chain is shorter
+		} else if err != nil {
+			return fmt.Errorf("unable to query header %d: %w", header.Number, err)
+		}
+
+		if providerHeader.Hash() == header.Hash() {
+			f.Rewind(len(f.traversedHeaders) - i - 1)
+			return nil
+		}
+	}
+
+	// The indexer's state is in an irrecoverable state relative to the provider. The reorg
+	// is deeper than the retained traversed headers.
+	return ErrHeaderTraversalAndProviderMismatchedState
+}
@@ -129,7 +129,108 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	headers = makeHeaders(5, nil)
 	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(9)}, nil)
 	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers, nil)
+
+	// none of the traversed headers are part of the provider's chain
+	client.On("BlockHeaderByNumber", mock.MatchedBy(func(n *big.Int) bool { return n != nil })).Return(&types.Header{Extra: []byte("other chain")}, nil)
 	headers, err = headerTraversal.NextHeaders(5)
 	require.Nil(t, headers)
 	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
 }
+
+func TestHeaderTraversalReorg(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from genesis
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+
+	// blocks [0..9]
+	headers := makeHeaders(10, nil)
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[9], nil).Times(1)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(9))).Return(headers, nil)
+	_, err := headerTraversal.NextHeaders(10)
+	require.NoError(t, err)
+
+	// the provider reorgs blocks [7..9] and extends the chain to block 12
+	reorgedHeaders := []types.Header{{Number: big.NewInt(7), ParentHash: headers[6].Hash(), Extra: []byte("reorg")}}
+	reorgedHeaders = append(reorgedHeaders, makeHeaders(5, &reorgedHeaders[0])...)
+	providerHeaders := append(headers[:7:7], reorgedHeaders...)
+
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&providerHeaders[12], nil)
+	for i := range providerHeaders {
+		number := int64(i)
+		client.On("BlockHeaderByNumber", mock.MatchedBy(func(n *big.Int) bool { return n != nil && n.Int64() == number })).Return(&providerHeaders[i], nil)
+	}
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(10)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[10:], nil)
+
+	// rewound to the common ancestor
+	headers, err = headerTraversal.NextHeaders(10)
+	require.Nil(t, headers)
+	require.ErrorIs(t, err, ErrHeaderTraversalReorg)
+	require.Equal(t, providerHeaders[6].Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+	// the canonical headers are traversed from the common ancestor
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(7)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[7:], nil)
+	headers, err = headerTraversal.NextHeaders(10)
+	require.NoError(t, err)
+	require.Len(t, headers, 6)
+	require.Equal(t, providerHeaders[12].Hash(), headerTraversal.LastTraversedHeader().Hash())
+}
+
+func TestHeaderTraversalReorgFromHeaders(t *testing.T) {
+	client := new(MockEthClient)
+
+	// restart after blocks [0..9], of which only blocks 2, 5 and 9 were retained
+	headers := makeHeaders(10, nil)
+	headerTraversal := NewHeaderTraversalFromHeaders(client, []types.Header{headers[2], headers[5], headers[9]}, bigint.Zero)
+	require.Equal(t, headers[9].Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+	// the provider reorgs blocks [7..9] and extends the chain to block 12
+	reorgedHeaders := []types.Header{{Number: big.NewInt(7), ParentHash: headers[6].Hash(), Extra: []byte("reorg")}}
+	reorgedHeaders = append(reorgedHeaders, makeHeaders(5, &reorgedHeaders[0])...)
+	providerHeaders := append(headers[:7:7], reorgedHeaders...)
+
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&providerHeaders[12], nil)
+	for i := range providerHeaders {
+		number := int64(i)
+		client.On("BlockHeaderByNumber", mock.MatchedBy(func(n *big.Int) bool { return n != nil && n.Int64() == number })).Return(&providerHeaders[i], nil)
+	}
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(10)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[10:], nil)
+
+	// rewound to the latest retained header on the provider's chain
+	traversed, err := headerTraversal.NextHeaders(10)
+	require.Nil(t, traversed)
+	require.ErrorIs(t, err, ErrHeaderTraversalReorg)
+	require.Equal(t, headers[5].Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(6)), mock.MatchedBy(bigint.Matcher(12))).Return(providerHeaders[6:], nil)
+	traversed, err = headerTraversal.NextHeaders(10)
+	require.NoError(t, err)
+	require.Equal(t, providerHeaders[6:], traversed)
+}
+
+func TestHeaderTraversalRewind(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from block 4
+	headers := makeHeaders(10, nil)
+	headerTraversal := NewHeaderTraversal(client, &headers[4], bigint.Zero)
+
+	// blocks [5..9]
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[9], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(9))).Return(headers[5:], nil)
+	_, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), headerTraversal.LastTraversedHeader().Number.Uint64())
+
+	// the batch is traversed again
+	headerTraversal.Rewind(5)
+	require.Equal(t, headers[4].Hash(), headerTraversal.LastTraversedHeader().Hash())
+
+	traversed, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+	require.Equal(t, headers[5:], traversed)
+
+	// rewinding past the starting header restarts from genesis
+	headerTraversal.Rewind(10)
+	require.Nil(t, headerTraversal.LastTraversedHeader())
+}
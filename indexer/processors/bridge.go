@@ -14,6 +14,7 @@ import (
 	"github.com/BLASTchain/blast/indexer/config"
 	"github.com/BLASTchain/blast/indexer/database"
 	"github.com/BLASTchain/blast/indexer/etl"
+	"github.com/BLASTchain/blast/indexer/node"
 	"github.com/BLASTchain/blast/indexer/processors/bridge"
 	"github.com/BLASTchain/blast/bl-service/tasks"
 )
@@ -125,6 +126,11 @@ func (b *BridgeProcessor) onL1Data() error {
 	latestL1Header := b.l1Etl.LatestHeader
 	b.log.Info("notified of new L1 state", "l1_etl_block_number", latestL1Header.Number)
 
+	if err := b.reloadL1RoutineState(); err != nil {
+		b.log.Error("failed to reload rolled back bridge state", "err", err)
+		return err
+	}
+
 	var errs error
 	if err := b.processInitiatedL1Events(); err != nil {
 		b.log.Error("failed to process initiated L1 events", "err", err)
@@ -151,6 +157,11 @@ func (b *BridgeProcessor) onL2Data() error {
 	}
 	b.log.Info("notified of new L2 state", "l2_etl_block_number", b.l2Etl.LatestHeader.Number)
 
+	if err := b.reloadL2RoutineState(); err != nil {
+		b.log.Error("failed to reload rolled back bridge state", "err", err)
+		return err
+	}
+
 	var errs error
 	if err := b.processInitiatedL2Events(); err != nil {
 		b.log.Error("failed to process initiated L2 events", "err", err)
@@ -169,6 +180,74 @@ func (b *BridgeProcessor) onL2Data() error {
 	return errs
 }
 
+// reloadL1RoutineState reloads the bridge state mutated by the L1 routine, `LastL1Header` & `LastFinalizedL2Header`,
+// from the database when the last processed headers have been rolled back by the ETL on a reorg
+func (b *BridgeProcessor) reloadL1RoutineState() error {
+	if b.LastL1Header != nil {
+		header, err := b.db.Blocks.L1BlockHeader(b.LastL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last processed L1 header: %w", err)
+		} else if header == nil {
+			latestL1Header, err := b.db.BridgeTransactions.L1LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			b.log.Warn("last processed L1 header rolled back", "block_number", b.LastL1Header.Number, "block_hash", b.LastL1Header.Hash, "l1_block", latestL1Header)
+			b.LastL1Header = latestL1Header
+		}
+	}
+
+	if b.LastFinalizedL2Header != nil {
+		header, err := b.db.Blocks.L2BlockHeader(b.LastFinalizedL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L2 header: %w", err)
+		} else if header == nil {
+			latestFinalizedL2Header, err := b.db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+			b.log.Warn("last finalized L2 header rolled back", "block_number", b.LastFinalizedL2Header.Number, "block_hash", b.LastFinalizedL2Header.Hash, "finalized_l2_block", latestFinalizedL2Header)
+			b.LastFinalizedL2Header = latestFinalizedL2Header
+		}
+	}
+
+	return nil
+}
+
+// reloadL2RoutineState reloads the bridge state mutated by the L2 routine, `LastL2Header` & `LastFinalizedL1Header`,
+// from the database when the last processed headers have been rolled back by the ETL on a reorg
+func (b *BridgeProcessor) reloadL2RoutineState() error {
+	if b.LastL2Header != nil {
+		header, err := b.db.Blocks.L2BlockHeader(b.LastL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last processed L2 header: %w", err)
+		} else if header == nil {
+			latestL2Header, err := b.db.BridgeTransactions.L2LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			b.log.Warn("last processed L2 header rolled back", "block_number", b.LastL2Header.Number, "block_hash", b.LastL2Header.Hash, "l2_block", latestL2Header)
+			b.LastL2Header = latestL2Header
+		}
+	}
+
+	if b.LastFinalizedL1Header != nil {
+		header, err := b.db.Blocks.L1BlockHeader(b.LastFinalizedL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L1 header: %w", err)
+		} else if header == nil {
+			latestFinalizedL1Header, err := b.db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+			b.log.Warn("last finalized L1 header rolled back", "block_number", b.LastFinalizedL1Header.Number, "block_hash", b.LastFinalizedL1Header.Hash, "finalized_l1_block", latestFinalizedL1Header)
+			b.LastFinalizedL1Header = latestFinalizedL1Header
+		}
+	}
+
+	return nil
+}
+
 // Process Initiated Bridge Events
 
 func (b *BridgeProcessor) processInitiatedL1Events() error {
@@ -194,27 +273,7 @@ func (b *BridgeProcessor) processInitiatedL1Events() error {
 
 	fromL1Height, toL1Height := new(big.Int).Add(lastL1BlockNumber, bigint.One), latestL1Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
-		if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 { // OP Mainnet & OP Goerli Only.
-			legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
-			if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
-				legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
-			legacyBridgeLog.Info("scanning for initiated bridge events")
-			if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			} else if legacyToL1Height.Cmp(toL1Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l1BedrockStartingHeight)
-			fromL1Height = l1BedrockStartingHeight
-		}
-
-		l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
-		l1BridgeLog.Info("scanning for initiated bridge events")
-		return bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height)
+		return processInitiatedL1Range(l1BridgeLog, tx, b.metrics, b.chainConfig, fromL1Height, toL1Height)
 	}); err != nil {
 		return err
 	}
@@ -247,27 +306,7 @@ func (b *BridgeProcessor) processInitiatedL2Events() error {
 
 	fromL2Height, toL2Height := new(big.Int).Add(lastL2BlockNumber, bigint.One), latestL2Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
-		if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 { // OP Mainnet & OP Goerli Only
-			legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
-			if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
-				legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
-			legacyBridgeLog.Info("scanning for initiated bridge events")
-			if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			} else if legacyToL2Height.Cmp(toL2Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock")
-			fromL2Height = l2BedrockStartingHeight
-		}
-
-		l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
-		l2BridgeLog.Info("scanning for initiated bridge events")
-		return bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height)
+		return processInitiatedL2Range(l2BridgeLog, tx, b.metrics, b.chainConfig, fromL2Height, toL2Height)
 	}); err != nil {
 		return err
 	}
@@ -303,27 +342,7 @@ func (b *BridgeProcessor) processFinalizedL1Events() error {
 
 	fromL1Height, toL1Height := new(big.Int).Add(lastFinalizedL1BlockNumber, bigint.One), latestL1Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
-		if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 {
-			legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
-			if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
-				legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
-			legacyBridgeLog.Info("scanning for finalized bridge events")
-			if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.l1Etl.EthClient, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			} else if legacyToL1Height.Cmp(toL1Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock")
-			fromL1Height = l1BedrockStartingHeight
-		}
-
-		l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
-		l1BridgeLog.Info("scanning for finalized bridge events")
-		return bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height)
+		return processFinalizedL1Range(l1BridgeLog, tx, b.metrics, b.l1Etl.EthClient, b.chainConfig, fromL1Height, toL1Height)
 	}); err != nil {
 		return err
 	}
@@ -357,27 +376,7 @@ func (b *BridgeProcessor) processFinalizedL2Events() error {
 
 	fromL2Height, toL2Height := new(big.Int).Add(lastFinalizedL2BlockNumber, bigint.One), latestL2Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
-		if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 {
-			legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
-			if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
-				legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
-			legacyBridgeLog.Info("scanning for finalized bridge events")
-			if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			} else if legacyToL2Height.Cmp(toL2Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l2BedrockStartingHeight)
-			fromL2Height = l2BedrockStartingHeight
-		}
-
-		l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
-		l2BridgeLog.Info("scanning for finalized bridge events")
-		return bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height)
+		return processFinalizedL2Range(l2BridgeLog, tx, b.metrics, b.chainConfig, fromL2Height, toL2Height)
 	}); err != nil {
 		return err
 	}
@@ -386,3 +385,109 @@ func (b *BridgeProcessor) processFinalizedL2Events() error {
 	b.metrics.RecordL2LatestFinalizedHeight(latestL2Header.Number)
 	return nil
 }
+
+// Range Processing
+
+// processInitiatedL1Range processes the initiated L1 bridge events within the inclusive range, delegating the
+// pre-bedrock blocks to the legacy bridge processor
+func processInitiatedL1Range(l1BridgeLog log.Logger, tx *database.DB, metrics bridge.Metricer, chainConfig config.ChainConfig, fromL1Height, toL1Height *big.Int) error {
+	l1BedrockStartingHeight := big.NewInt(int64(chainConfig.L1BedrockStartingHeight))
+	if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 { // OP Mainnet & OP Goerli Only.
+		legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
+		if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
+			legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
+		legacyBridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
+			return err
+		} else if legacyToL1Height.Cmp(toL1Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l1BedrockStartingHeight)
+		fromL1Height = l1BedrockStartingHeight
+	}
+
+	l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
+	l1BridgeLog.Info("scanning for initiated bridge events")
+	return bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, fromL1Height, toL1Height)
+}
+
+// processInitiatedL2Range processes the initiated L2 bridge events within the inclusive range, delegating the
+// pre-bedrock blocks to the legacy bridge processor
+func processInitiatedL2Range(l2BridgeLog log.Logger, tx *database.DB, metrics bridge.Metricer, chainConfig config.ChainConfig, fromL2Height, toL2Height *big.Int) error {
+	l2BedrockStartingHeight := big.NewInt(int64(chainConfig.L2BedrockStartingHeight))
+	if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 { // OP Mainnet & OP Goerli Only
+		legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
+		if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
+			legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
+		legacyBridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
+			return err
+		} else if legacyToL2Height.Cmp(toL2Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock")
+		fromL2Height = l2BedrockStartingHeight
+	}
+
+	l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
+	l2BridgeLog.Info("scanning for initiated bridge events")
+	return bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, metrics, chainConfig.L2Contracts, fromL2Height, toL2Height)
+}
+
+// processFinalizedL1Range processes the finalized L2 bridge events within the inclusive L1 range, delegating the
+// pre-bedrock blocks to the legacy bridge processor
+func processFinalizedL1Range(l1BridgeLog log.Logger, tx *database.DB, metrics bridge.Metricer, l1Client node.EthClient, chainConfig config.ChainConfig, fromL1Height, toL1Height *big.Int) error {
+	l1BedrockStartingHeight := big.NewInt(int64(chainConfig.L1BedrockStartingHeight))
+	if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 {
+		legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
+		if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
+			legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
+		legacyBridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, metrics, l1Client, chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
+			return err
+		} else if legacyToL1Height.Cmp(toL1Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock")
+		fromL1Height = l1BedrockStartingHeight
+	}
+
+	l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
+	l1BridgeLog.Info("scanning for finalized bridge events")
+	return bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, fromL1Height, toL1Height)
+}
+
+// processFinalizedL2Range processes the finalized L1 bridge events within the inclusive L2 range, delegating the
+// pre-bedrock blocks to the legacy bridge processor
+func processFinalizedL2Range(l2BridgeLog log.Logger, tx *database.DB, metrics bridge.Metricer, chainConfig config.ChainConfig, fromL2Height, toL2Height *big.Int) error {
+	l2BedrockStartingHeight := big.NewInt(int64(chainConfig.L2BedrockStartingHeight))
+	if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 {
+		legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
+		if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
+			legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
+		legacyBridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
+			return err
+		} else if legacyToL2Height.Cmp(toL2Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l2BedrockStartingHeight)
+		fromL2Height = l2BedrockStartingHeight
+	}
+
+	l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
+	l2BridgeLog.Info("scanning for finalized bridge events")
+	return bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, metrics, chainConfig.L2Contracts, fromL2Height, toL2Height)
+}
@@ -19,6 +19,7 @@ import (
 //  1. OptimismPortal
 //  2. L1CrossDomainMessenger
 //  3. L1StandardBridge
+//  4. L1ERC721Bridge
 func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal
 	optimismPortalTxDeposits, err := contracts.OptimismPortalTransactionDepositEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -137,16 +138,110 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (4) L1ERC721Bridge
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected erc721 bridge deposits", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedERC721Tokens := make(map[common.Address]int)
+	erc721BridgeDeposits := make([]database.L1ERC721BridgeDeposit, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		// extract the cross domain message hash & deposit source hash from the preceding events. Unlike the
+		// StandardBridge, the ERC721Bridge emits the initiated event after sending the message
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if sentMessage.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
+		}
+
+		portalDeposit, ok := portalDeposits[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 3}]
+		if !ok {
+			return fmt.Errorf("expected TransactionDeposit preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if portalDeposit.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch, bridge_tx_hash = %s, deposit_tx_hash = %s", initiatedBridge.Event.TransactionHash, portalDeposit.Event.TransactionHash)
+		}
+
+		bridgedERC721Tokens[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = sentMessage.BridgeMessage.MessageHash
+		erc721BridgeDeposits[i] = database.L1ERC721BridgeDeposit{
+			TransactionSourceHash: portalDeposit.DepositTx.SourceHash,
+			ERC721BridgeTransfer:  initiatedBridge.BridgeTransfer,
+		}
+	}
+	if len(erc721BridgeDeposits) > 0 {
+		if err := db.BridgeTransfers.StoreL1ERC721BridgeDeposits(erc721BridgeDeposits); err != nil {
+			return err
+		}
+		for tokenAddr, size := range bridgedERC721Tokens {
+			metrics.RecordL1InitiatedBridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	return nil
 }
 
 // L1ProcessFinalizedBridgeEvent will query the database for all the finalization markers for all initiated
 // bridge events. This covers every part of the multi-layered stack:
-//  1. OptimismPortal (Bedrock prove & finalize steps)
-//  2. L1CrossDomainMessenger (relayMessage marker)
-//  3. L1StandardBridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
+//  1. L2OutputOracle (output proposals, which withdrawals are proven against, and deleted outputs)
+//  2. OptimismPortal (Bedrock prove & finalize steps)
+//  3. L1CrossDomainMessenger (relayMessage marker)
+//  4. L1StandardBridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
+//  5. L1ERC721Bridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
 func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
-	// (1) OptimismPortal (proven withdrawals)
+	// (1) L2OutputOracle
+	outputProposedEvents, err := contracts.L2OutputOracleOutputProposedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	outputsDeletedEvents, err := contracts.L2OutputOracleOutputsDeletedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	// Deleted outputs may be proposed again in the same range, so the events are applied in the order they were emitted
+	outputProposals := []database.OutputProposal{}
+	storeOutputProposals := func() error {
+		if len(outputProposals) == 0 {
+			return nil
+		}
+		log.Info("detected output proposals", "size", len(outputProposals))
+		if err := db.Blocks.StoreOutputProposals(outputProposals); err != nil {
+			return err
+		}
+		metrics.RecordL1OutputProposals(len(outputProposals))
+		outputProposals = []database.OutputProposal{}
+		return nil
+	}
+	for _, outputsDeleted := range outputsDeletedEvents {
+		for len(outputProposedEvents) > 0 && emittedBefore(outputProposedEvents[0].Event, outputsDeleted.Event) {
+			outputProposals = append(outputProposals, outputProposalFromEvent(outputProposedEvents[0]))
+			outputProposedEvents = outputProposedEvents[1:]
+		}
+		if err := storeOutputProposals(); err != nil {
+			return err
+		}
+
+		log.Info("detected deleted outputs", "prev_next_index", outputsDeleted.PrevNextOutputIndex, "new_next_index", outputsDeleted.NewNextOutputIndex)
+		if err := db.Blocks.MarkOutputProposalsDeleted(outputsDeleted.NewNextOutputIndex, outputsDeleted.Event.GUID); err != nil {
+			return err
+		}
+	}
+	for _, outputProposed := range outputProposedEvents {
+		outputProposals = append(outputProposals, outputProposalFromEvent(outputProposed))
+	}
+	if err := storeOutputProposals(); err != nil {
+		return err
+	}
+
+	// (2) OptimismPortal (proven withdrawals)
 	provenWithdrawals, err := contracts.OptimismPortalWithdrawalProvenEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
 	if err != nil {
 		return err
@@ -172,7 +267,7 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		metrics.RecordL1ProvenWithdrawals(len(provenWithdrawals))
 	}
 
-	// (2) OptimismPortal (finalized withdrawals)
+	// (3) OptimismPortal (finalized withdrawals)
 	finalizedWithdrawals, err := contracts.OptimismPortalWithdrawalFinalizedEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
 	if err != nil {
 		return err
@@ -198,7 +293,7 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		metrics.RecordL1FinalizedWithdrawals(len(finalizedWithdrawals))
 	}
 
-	// (3) L1CrossDomainMessenger
+	// (4) L1CrossDomainMessenger
 	crossDomainRelayedMessages, err := contracts.CrossDomainMessengerRelayedMessageEvents("l1", l1Contracts.L1CrossDomainMessengerProxy, db, fromHeight, toHeight)
 	if err != nil {
 		return err
@@ -224,7 +319,7 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		metrics.RecordL1CrossDomainRelayedMessages(len(crossDomainRelayedMessages))
 	}
 
-	// (4) L1StandardBridge
+	// (5) L1StandardBridge
 	// - Nothing actionable on the database. Since the StandardBridge is layered ontop of the
 	// CrossDomainMessenger, there's no need for any sanity or invariant checks as the previous step
 	// ensures a relayed message (finalized bridge) can be linked with a sent message (initiated bridge).
@@ -245,6 +340,44 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (6) L1ERC721Bridge
+	// - Nothing actionable on the database, for the same reasons as the L1StandardBridge
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	finalizedERC721Tokens := make(map[common.Address]int)
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		finalizedERC721Tokens[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized erc721 bridge withdrawals", "size", len(finalizedERC721Bridges))
+		for tokenAddr, size := range finalizedERC721Tokens {
+			metrics.RecordL1FinalizedBridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
+
+func outputProposalFromEvent(outputProposed contracts.L2OutputOracleOutputProposed) database.OutputProposal {
+	return database.OutputProposal{
+		OutputRoot:         outputProposed.OutputRoot,
+		L2OutputIndex:      outputProposed.L2OutputIndex,
+		L2BlockNumber:      outputProposed.L2BlockNumber,
+		OutputProposedGUID: outputProposed.Event.GUID,
+		Timestamp:          outputProposed.Event.Timestamp,
+	}
+}
+
+// emittedBefore returns true if the L1 event a was emitted before b. L1 block timestamps strictly increase,
+// so events are ordered by their timestamp and log index.
+func emittedBefore(a, b *database.ContractEvent) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	return a.LogIndex < b.LogIndex
+}
@@ -18,6 +18,8 @@ type L1Metricer interface {
 	RecordL1LatestHeight(height *big.Int)
 	RecordL1LatestFinalizedHeight(height *big.Int)
 
+	RecordL1OutputProposals(size int)
+
 	RecordL1TransactionDeposits(size int, mintedETH float64)
 	RecordL1ProvenWithdrawals(size int)
 	RecordL1FinalizedWithdrawals(size int)
@@ -55,6 +57,8 @@ type bridgeMetrics struct {
 	intervalDuration *prometheus.HistogramVec
 	intervalFailures *prometheus.CounterVec
 
+	outputProposals prometheus.Counter
+
 	txDeposits           prometheus.Counter
 	txMintedETH          prometheus.Counter
 	txWithdrawals        prometheus.Counter
@@ -101,6 +105,11 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			"chain",
 			"kind",
 		}),
+		outputProposals: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "output_proposals",
+			Help:      "number of processed output proposals on l1",
+		}),
 		txDeposits: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: MetricsNamespace,
 			Name:      "tx_deposits",
@@ -185,6 +194,10 @@ func (m *bridgeMetrics) RecordL1LatestFinalizedHeight(height *big.Int) {
 	m.latestHeight.WithLabelValues("l1", "finalized").Set(float64(height.Uint64()))
 }
 
+func (m *bridgeMetrics) RecordL1OutputProposals(size int) {
+	m.outputProposals.Add(float64(size))
+}
+
 func (m *bridgeMetrics) RecordL1TransactionDeposits(size int, mintedETH float64) {
 	m.txDeposits.Add(float64(size))
 	m.txMintedETH.Add(mintedETH)
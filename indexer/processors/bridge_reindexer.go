@@ -0,0 +1,68 @@
+package processors
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/indexer/config"
+	"github.com/BLASTchain/blast/indexer/database"
+	"github.com/BLASTchain/blast/indexer/node"
+	"github.com/BLASTchain/blast/indexer/processors/bridge"
+)
+
+// BridgeReindexer re-processes the bridge events of historical block ranges. The bridge state is upserted,
+// such that fixes to the bridge processor are applied, while the BridgeProcessor keeps running on the tip.
+type BridgeReindexer struct {
+	log     log.Logger
+	db      *database.DB
+	metrics bridge.Metricer
+
+	// l1Client is only used to process the finalized legacy L1 bridge events
+	l1Client    node.EthClient
+	chainConfig config.ChainConfig
+}
+
+func NewBridgeReindexer(log log.Logger, db *database.DB, metrics bridge.Metricer, l1Client node.EthClient, chainConfig config.ChainConfig) *BridgeReindexer {
+	return &BridgeReindexer{
+		log:         log.New("processor", "bridge", "reindex", true),
+		db:          db.WithUpserts(),
+		metrics:     metrics,
+		l1Client:    l1Client,
+		chainConfig: chainConfig,
+	}
+}
+
+// ProcessInitiatedL1Events re-processes the initiated L1 bridge events within the inclusive range
+func (r *BridgeReindexer) ProcessInitiatedL1Events(fromL1Height, toL1Height *big.Int) error {
+	l1BridgeLog := r.log.New("bridge", "l1", "kind", "initiated")
+	return r.db.Transaction(func(tx *database.DB) error {
+		return processInitiatedL1Range(l1BridgeLog, tx, r.metrics, r.chainConfig, fromL1Height, toL1Height)
+	})
+}
+
+// ProcessInitiatedL2Events re-processes the initiated L2 bridge events within the inclusive range
+func (r *BridgeReindexer) ProcessInitiatedL2Events(fromL2Height, toL2Height *big.Int) error {
+	l2BridgeLog := r.log.New("bridge", "l2", "kind", "initiated")
+	return r.db.Transaction(func(tx *database.DB) error {
+		return processInitiatedL2Range(l2BridgeLog, tx, r.metrics, r.chainConfig, fromL2Height, toL2Height)
+	})
+}
+
+// ProcessFinalizedL1Events re-processes the finalized L2 bridge events within the inclusive L1 range. The
+// L2 bridge events finalized within the range must have been indexed.
+func (r *BridgeReindexer) ProcessFinalizedL1Events(fromL1Height, toL1Height *big.Int) error {
+	l1BridgeLog := r.log.New("bridge", "l1", "kind", "finalization")
+	return r.db.Transaction(func(tx *database.DB) error {
+		return processFinalizedL1Range(l1BridgeLog, tx, r.metrics, r.l1Client, r.chainConfig, fromL1Height, toL1Height)
+	})
+}
+
+// ProcessFinalizedL2Events re-processes the finalized L1 bridge events within the inclusive L2 range. The
+// L1 bridge events finalized within the range must have been indexed.
+func (r *BridgeReindexer) ProcessFinalizedL2Events(fromL2Height, toL2Height *big.Int) error {
+	l2BridgeLog := r.log.New("bridge", "l2", "kind", "finalization")
+	return r.db.Transaction(func(tx *database.DB) error {
+		return processFinalizedL2Range(l2BridgeLog, tx, r.metrics, r.chainConfig, fromL2Height, toL2Height)
+	})
+}
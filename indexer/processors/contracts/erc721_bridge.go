@@ -0,0 +1,97 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/indexer/database"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ERC721BridgeInitiatedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+type ERC721BridgeFinalizedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+// ERC721BridgeInitiatedEvents extracts all initiated bridge events from the contracts that follow the ERC721Bridge ABI. The
+// L1ERC721Bridge & L2ERC721Bridge share the events of the ERC721Bridge, so the L1ERC721Bridge ABI is used for both.
+func ERC721BridgeInitiatedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeInitiatedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeInitiatedEventAbi := erc721BridgeAbi.Events["ERC721BridgeInitiated"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: bridgeInitiatedEventAbi.ID}
+	bridgeInitiatedEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeInitiatedEvents := make([]ERC721BridgeInitiatedEvent, len(bridgeInitiatedEvents))
+	for i := range bridgeInitiatedEvents {
+		bridgeInitiated := bindings.L1ERC721BridgeERC721BridgeInitiated{Raw: *bridgeInitiatedEvents[i].RLPLog}
+		err := UnpackLog(&bridgeInitiated, bridgeInitiatedEvents[i].RLPLog, bridgeInitiatedEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeInitiatedEvents[i] = ERC721BridgeInitiatedEvent{
+			Event: &bridgeInitiatedEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				TokenPair:   database.TokenPair{LocalTokenAddress: bridgeInitiated.LocalToken, RemoteTokenAddress: bridgeInitiated.RemoteToken},
+				TokenID:     bridgeInitiated.TokenId,
+				FromAddress: bridgeInitiated.From,
+				ToAddress:   bridgeInitiated.To,
+				Data:        bridgeInitiated.ExtraData,
+				Timestamp:   bridgeInitiatedEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeInitiatedEvents, nil
+}
+
+// ERC721BridgeFinalizedEvents extracts all finalization bridge events from the contracts that follow the ERC721Bridge ABI.
+func ERC721BridgeFinalizedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeFinalizedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	bridgeFinalizedEventAbi := erc721BridgeAbi.Events["ERC721BridgeFinalized"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: bridgeFinalizedEventAbi.ID}
+	bridgeFinalizedEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeFinalizedEvents := make([]ERC721BridgeFinalizedEvent, len(bridgeFinalizedEvents))
+	for i := range bridgeFinalizedEvents {
+		bridgeFinalized := bindings.L1ERC721BridgeERC721BridgeFinalized{Raw: *bridgeFinalizedEvents[i].RLPLog}
+		err := UnpackLog(&bridgeFinalized, bridgeFinalizedEvents[i].RLPLog, bridgeFinalizedEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeFinalizedEvents[i] = ERC721BridgeFinalizedEvent{
+			Event: &bridgeFinalizedEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				TokenPair:   database.TokenPair{LocalTokenAddress: bridgeFinalized.LocalToken, RemoteTokenAddress: bridgeFinalized.RemoteToken},
+				TokenID:     bridgeFinalized.TokenId,
+				FromAddress: bridgeFinalized.From,
+				ToAddress:   bridgeFinalized.To,
+				Data:        bridgeFinalized.ExtraData,
+				Timestamp:   bridgeFinalizedEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeFinalizedEvents, nil
+}
@@ -0,0 +1,88 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/BLASTchain/blast/bl-bindings/bindings"
+	"github.com/BLASTchain/blast/indexer/database"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type L2OutputOracleOutputProposed struct {
+	Event *database.ContractEvent
+
+	OutputRoot    common.Hash
+	L2OutputIndex *big.Int
+	L2BlockNumber *big.Int
+}
+
+func L2OutputOracleOutputProposedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]L2OutputOracleOutputProposed, error) {
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	outputProposedAbi := l2OutputOracleAbi.Events["OutputProposed"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: outputProposedAbi.ID}
+	outputProposedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	outputProposals := make([]L2OutputOracleOutputProposed, len(outputProposedEvents))
+	for i := range outputProposedEvents {
+		outputProposed := bindings.L2OutputOracleOutputProposed{Raw: *outputProposedEvents[i].RLPLog}
+		err := UnpackLog(&outputProposed, outputProposedEvents[i].RLPLog, outputProposedAbi.Name, l2OutputOracleAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		outputProposals[i] = L2OutputOracleOutputProposed{
+			Event:         &outputProposedEvents[i].ContractEvent,
+			OutputRoot:    outputProposed.OutputRoot,
+			L2OutputIndex: outputProposed.L2OutputIndex,
+			L2BlockNumber: outputProposed.L2BlockNumber,
+		}
+	}
+
+	return outputProposals, nil
+}
+
+type L2OutputOracleOutputsDeleted struct {
+	Event *database.ContractEvent
+
+	PrevNextOutputIndex *big.Int
+	NewNextOutputIndex  *big.Int
+}
+
+func L2OutputOracleOutputsDeletedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]L2OutputOracleOutputsDeleted, error) {
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	outputsDeletedAbi := l2OutputOracleAbi.Events["OutputsDeleted"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: outputsDeletedAbi.ID}
+	outputsDeletedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	outputsDeleted := make([]L2OutputOracleOutputsDeleted, len(outputsDeletedEvents))
+	for i := range outputsDeletedEvents {
+		deleted := bindings.L2OutputOracleOutputsDeleted{Raw: *outputsDeletedEvents[i].RLPLog}
+		err := UnpackLog(&deleted, outputsDeletedEvents[i].RLPLog, outputsDeletedAbi.Name, l2OutputOracleAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		outputsDeleted[i] = L2OutputOracleOutputsDeleted{
+			Event:               &outputsDeletedEvents[i].ContractEvent,
+			PrevNextOutputIndex: deleted.PrevNextOutputIndex,
+			NewNextOutputIndex:  deleted.NewNextOutputIndex,
+		}
+	}
+
+	return outputsDeleted, nil
+}
@@ -0,0 +1,261 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/BLASTchain/blast/bl-service/metrics"
+	"github.com/BLASTchain/blast/indexer/bigint"
+	"github.com/BLASTchain/blast/indexer/config"
+	"github.com/BLASTchain/blast/indexer/database"
+	"github.com/BLASTchain/blast/indexer/etl"
+	"github.com/BLASTchain/blast/indexer/node"
+	"github.com/BLASTchain/blast/indexer/processors"
+	"github.com/BLASTchain/blast/indexer/processors/bridge"
+)
+
+// ReindexConfig configures the historical block ranges to re-index
+type ReindexConfig struct {
+	// Processor to re-run over the block ranges. Only the "bridge" processor is supported
+	Processor string
+
+	// The inclusive L1 and L2 block ranges, nil if the chain is not re-indexed. Bridge events are finalized
+	// by events of the other chain, so both chains can be re-indexed in one run.
+	L1FromHeight *big.Int
+	L1ToHeight   *big.Int
+	L2FromHeight *big.Int
+	L2ToHeight   *big.Int
+
+	// The ranges are re-indexed in chunks of ChunkSize blocks, with up to Parallelism chunks at a time
+	ChunkSize   uint64
+	Parallelism int
+}
+
+func (c ReindexConfig) Check() error {
+	if c.Processor != "bridge" {
+		return fmt.Errorf("unknown processor %q, expected bridge", c.Processor)
+	}
+	if c.L1FromHeight == nil && c.L1ToHeight == nil && c.L2FromHeight == nil && c.L2ToHeight == nil {
+		return errors.New("no block range to re-index")
+	}
+	if err := checkBlockRange(c.L1FromHeight, c.L1ToHeight); err != nil {
+		return fmt.Errorf("invalid L1 block range: %w", err)
+	}
+	if err := checkBlockRange(c.L2FromHeight, c.L2ToHeight); err != nil {
+		return fmt.Errorf("invalid L2 block range: %w", err)
+	}
+	if c.ChunkSize == 0 {
+		return errors.New("chunk size must be positive")
+	}
+	if c.Parallelism <= 0 {
+		return errors.New("parallelism must be positive")
+	}
+	return nil
+}
+
+// checkBlockRange checks that a block range is either unset, or fully set and not empty
+func checkBlockRange(from, to *big.Int) error {
+	if (from == nil) != (to == nil) {
+		return errors.New("both the start and the end must be set")
+	}
+	if from != nil && from.Cmp(to) > 0 {
+		return errors.New("start is after the end")
+	}
+	return nil
+}
+
+// blockRange is an inclusive range of block numbers
+type blockRange struct {
+	from, to *big.Int
+}
+
+// chunkRange splits the inclusive range [from, to] into consecutive chunks of at most size blocks
+func chunkRange(from, to *big.Int, size uint64) []blockRange {
+	var chunks []blockRange
+	for height := from; height.Cmp(to) <= 0; {
+		end := bigint.Clamp(height, to, size)
+		chunks = append(chunks, blockRange{from: height, to: end})
+		height = new(big.Int).Add(end, bigint.One)
+	}
+	return chunks
+}
+
+// reindexedChain is the block range of a chain to re-index, and how to re-index it
+type reindexedChain struct {
+	log    log.Logger
+	chunks []blockRange
+
+	backfill         func(from, to *big.Int) error
+	processInitiated func(from, to *big.Int) error
+	processFinalized func(from, to *big.Int) error
+}
+
+// Reindex re-runs the ETL and the processor over historical block ranges of the L1 and/or L2 chain. Blocks & logs
+// missing from the ranges are backfilled, and the processed state is upserted, such that a range can be re-indexed
+// after fixing a processor while the indexer keeps running on the tip. The ranges must not exceed the indexed tips.
+//
+// The initiated bridge events of both chains are processed first, as the bridge events of a chain are finalized
+// by events of the other chain.
+func Reindex(ctx context.Context, log log.Logger, cfg *config.Config, reindexCfg ReindexConfig) error {
+	if err := reindexCfg.Check(); err != nil {
+		return fmt.Errorf("invalid reindex config: %w", err)
+	}
+
+	db, err := database.NewDB(ctx, log, cfg.DB)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	// The metrics are not served, as the reindex is a one-off process
+	registry := metrics.NewRegistry()
+	ctx, shutdown := context.WithCancelCause(ctx)
+	defer shutdown(nil)
+
+	// The L1 client is also used for the finalized legacy L1 bridge events
+	l1Client, err := node.DialEthClient(ctx, cfg.RPCs.L1RPC, node.NewMetrics(registry, "l1"))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 client: %w", err)
+	}
+	defer l1Client.Close()
+	reindexer := processors.NewBridgeReindexer(log, db, bridge.NewMetrics(registry), l1Client, cfg.Chain)
+
+	var chains []reindexedChain
+	if reindexCfg.L1FromHeight != nil {
+		l1Log := log.New("chain", "l1")
+		l1Cfg := etl.Config{
+			HeaderBufferSize:  cfg.Chain.L1HeaderBufferSize,
+			ConfirmationDepth: big.NewInt(int64(cfg.Chain.L1ConfirmationDepth)),
+			StartHeight:       big.NewInt(int64(cfg.Chain.L1StartingHeight)),
+		}
+		l1Etl, err := etl.NewL1ETL(l1Cfg, l1Log, db, etl.NewMetrics(registry, "l1"), l1Client, cfg.Chain.L1Contracts, shutdown)
+		if err != nil {
+			return err
+		}
+		defer l1Etl.Close()
+
+		latestHeader, err := db.Blocks.L1LatestBlockHeader()
+		if err != nil {
+			return fmt.Errorf("failed to query latest indexed L1 header: %w", err)
+		}
+		var latestBlockNumber *big.Int
+		if latestHeader != nil {
+			latestBlockNumber = latestHeader.Number
+		}
+		if err := checkIndexedTip(reindexCfg.L1ToHeight, latestBlockNumber); err != nil {
+			return fmt.Errorf("invalid L1 block range: %w", err)
+		}
+
+		chains = append(chains, reindexedChain{
+			log:              l1Log,
+			chunks:           chunkRange(reindexCfg.L1FromHeight, reindexCfg.L1ToHeight, reindexCfg.ChunkSize),
+			backfill:         l1Etl.Backfill,
+			processInitiated: reindexer.ProcessInitiatedL1Events,
+			processFinalized: reindexer.ProcessFinalizedL1Events,
+		})
+	}
+	if reindexCfg.L2FromHeight != nil {
+		l2Log := log.New("chain", "l2")
+		l2Client, err := node.DialEthClient(ctx, cfg.RPCs.L2RPC, node.NewMetrics(registry, "l2"))
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 client: %w", err)
+		}
+		defer l2Client.Close()
+
+		l2Cfg := etl.Config{
+			HeaderBufferSize:  cfg.Chain.L2HeaderBufferSize,
+			ConfirmationDepth: big.NewInt(int64(cfg.Chain.L2ConfirmationDepth)),
+		}
+		l2Etl, err := etl.NewL2ETL(l2Cfg, l2Log, db, etl.NewMetrics(registry, "l2"), l2Client, cfg.Chain.L2Contracts, shutdown)
+		if err != nil {
+			return err
+		}
+		defer l2Etl.Close()
+
+		latestHeader, err := db.Blocks.L2LatestBlockHeader()
+		if err != nil {
+			return fmt.Errorf("failed to query latest indexed L2 header: %w", err)
+		}
+		var latestBlockNumber *big.Int
+		if latestHeader != nil {
+			latestBlockNumber = latestHeader.Number
+		}
+		if err := checkIndexedTip(reindexCfg.L2ToHeight, latestBlockNumber); err != nil {
+			return fmt.Errorf("invalid L2 block range: %w", err)
+		}
+
+		chains = append(chains, reindexedChain{
+			log:              l2Log,
+			chunks:           chunkRange(reindexCfg.L2FromHeight, reindexCfg.L2ToHeight, reindexCfg.ChunkSize),
+			backfill:         l2Etl.Backfill,
+			processInitiated: reindexer.ProcessInitiatedL2Events,
+			processFinalized: reindexer.ProcessFinalizedL2Events,
+		})
+	}
+
+	// The initiated bridge events of a chunk are independent of the other chunks, and are processed in parallel
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(reindexCfg.Parallelism)
+	for _, chain := range chains {
+		chain := chain
+		chain.log.Info("re-indexing block range", "processor", reindexCfg.Processor, "from_block_number", chain.chunks[0].from,
+			"to_block_number", chain.chunks[len(chain.chunks)-1].to, "chunks", len(chain.chunks), "parallelism", reindexCfg.Parallelism)
+		for _, chunk := range chain.chunks {
+			chunk := chunk
+			group.Go(func() error {
+				if err := groupCtx.Err(); err != nil {
+					return err
+				}
+
+				chunkLog := chain.log.New("from_block_number", chunk.from, "to_block_number", chunk.to)
+				if err := chain.backfill(chunk.from, chunk.to); err != nil {
+					chunkLog.Error("failed to backfill chunk", "err", err)
+					return fmt.Errorf("failed to backfill blocks [%d, %d]: %w", chunk.from, chunk.to, err)
+				}
+				if err := chain.processInitiated(chunk.from, chunk.to); err != nil {
+					chunkLog.Error("failed to process initiated bridge events", "err", err)
+					return fmt.Errorf("failed to process initiated bridge events in blocks [%d, %d]: %w", chunk.from, chunk.to, err)
+				}
+
+				chunkLog.Info("re-indexed initiated bridge events")
+				return nil
+			})
+		}
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	// Finalization builds upon the earlier events of a withdrawal (proven before finalized), so the
+	// chunks are processed in order
+	for _, chain := range chains {
+		for _, chunk := range chain.chunks {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := chain.processFinalized(chunk.from, chunk.to); err != nil {
+				return fmt.Errorf("failed to process finalized bridge events in blocks [%d, %d]: %w", chunk.from, chunk.to, err)
+			}
+		}
+		chain.log.Info("re-indexed block range", "from_block_number", chain.chunks[0].from, "to_block_number", chain.chunks[len(chain.chunks)-1].to)
+	}
+
+	return nil
+}
+
+// checkIndexedTip checks that the end of a block range does not exceed the indexed tip. The indexer owns
+// the tip, where it has to handle reorgs, so re-indexing is limited to the indexed state.
+func checkIndexedTip(to, latestBlockNumber *big.Int) error {
+	if latestBlockNumber == nil {
+		return errors.New("no indexed state to re-index")
+	} else if to.Cmp(latestBlockNumber) > 0 {
+		return fmt.Errorf("block range exceeds the indexed tip %d", latestBlockNumber)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package indexer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkRange(t *testing.T) {
+	chunks := chunkRange(big.NewInt(10), big.NewInt(34), 10)
+	require.Len(t, chunks, 3)
+	require.Equal(t, []blockRange{
+		{from: big.NewInt(10), to: big.NewInt(19)},
+		{from: big.NewInt(20), to: big.NewInt(29)},
+		{from: big.NewInt(30), to: big.NewInt(34)},
+	}, chunks)
+
+	// a single block, and a range that fits into a single chunk
+	require.Equal(t, []blockRange{{from: big.NewInt(5), to: big.NewInt(5)}}, chunkRange(big.NewInt(5), big.NewInt(5), 10))
+	require.Equal(t, []blockRange{{from: big.NewInt(0), to: big.NewInt(9)}}, chunkRange(big.NewInt(0), big.NewInt(9), 10))
+}
+
+func TestReindexConfigCheck(t *testing.T) {
+	valid := ReindexConfig{Processor: "bridge", L1FromHeight: big.NewInt(1), L1ToHeight: big.NewInt(1), ChunkSize: 1, Parallelism: 1}
+	require.NoError(t, valid.Check())
+
+	// both chains in one run, or only L2
+	cfg := valid
+	cfg.L2FromHeight, cfg.L2ToHeight = big.NewInt(5), big.NewInt(10)
+	require.NoError(t, cfg.Check())
+	cfg.L1FromHeight, cfg.L1ToHeight = nil, nil
+	require.NoError(t, cfg.Check())
+
+	cfg = valid
+	cfg.L1FromHeight, cfg.L1ToHeight = nil, nil
+	require.ErrorContains(t, cfg.Check(), "no block range")
+
+	cfg = valid
+	cfg.Processor = "erc20"
+	require.ErrorContains(t, cfg.Check(), "unknown processor")
+
+	cfg = valid
+	cfg.L1FromHeight = big.NewInt(2)
+	require.ErrorContains(t, cfg.Check(), "invalid L1 block range")
+
+	cfg = valid
+	cfg.L2FromHeight = big.NewInt(2)
+	require.ErrorContains(t, cfg.Check(), "invalid L2 block range")
+
+	cfg = valid
+	cfg.ChunkSize = 0
+	require.ErrorContains(t, cfg.Check(), "chunk size")
+
+	cfg = valid
+	cfg.Parallelism = 0
+	require.ErrorContains(t, cfg.Check(), "parallelism")
+}
+
+func TestCheckIndexedTip(t *testing.T) {
+	require.NoError(t, checkIndexedTip(big.NewInt(10), big.NewInt(10)))
+	require.ErrorContains(t, checkIndexedTip(big.NewInt(11), big.NewInt(10)), "exceeds the indexed tip")
+	require.ErrorContains(t, checkIndexedTip(big.NewInt(1), nil), "no indexed state")
+}
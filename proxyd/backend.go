@@ -88,6 +88,11 @@ var (
 		Message:       "sender is over rate limit",
 		HTTPErrorCode: 429,
 	}
+	ErrOverKeyQuota = &RPCErr{
+		Code:          JSONRPCErrorInternal - 22,
+		Message:       "key is over quota",
+		HTTPErrorCode: 429,
+	}
 	ErrNotHealthy = &RPCErr{
 		Code:          JSONRPCErrorInternal - 18,
 		Message:       "backend is currently not healthy to serve traffic",
@@ -422,14 +427,14 @@ func (b *Backend) Forward(ctx context.Context, reqs []*RPCReq, isBatch bool) ([]
 	return nil, wrapErr(lastError, "permanent error forwarding request")
 }
 
-func (b *Backend) ProxyWS(clientConn *websocket.Conn, methodWhitelist *StringSet) (*WSProxier, error) {
+func (b *Backend) ProxyWS(clientConn *websocket.Conn, methodWhitelist *StringSet, keyQuotas *KeyQuotas) (*WSProxier, error) {
 	backendConn, _, err := b.dialer.Dial(b.wsURL, nil) // nolint:bodyclose
 	if err != nil {
 		return nil, wrapErr(err, "error dialing backend")
 	}
 
 	activeBackendWsConnsGauge.WithLabelValues(b.Name).Inc()
-	return NewWSProxier(b, clientConn, backendConn, methodWhitelist), nil
+	return NewWSProxier(b, clientConn, backendConn, methodWhitelist, keyQuotas), nil
 }
 
 // ForwardRPC makes a call directly to a backend and populate the response into `res`
@@ -707,6 +712,7 @@ type BackendGroup struct {
 	Backends        []*Backend
 	WeightedRouting bool
 	Consensus       *ConsensusPoller
+	GetLogsSplitter *GetLogsSplitter
 }
 
 func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch bool) ([]*RPCRes, string, error) {
@@ -730,6 +736,10 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 			finalized:     bg.Consensus.GetFinalizedBlockNumber(),
 			maxBlockRange: bg.Consensus.maxBlockRange,
 		}
+		if bg.GetLogsSplitter != nil {
+			// ranges above the split range are split instead of rejected
+			rctx.maxBlockRange = bg.GetLogsSplitter.maxRange
+		}
 
 		for i, req := range rpcReqs {
 			res := RPCRes{JSONRPC: JSONRPCVersion, ID: req.ID}
@@ -757,6 +767,16 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 					res:   &res,
 				})
 			case RewriteOverrideRequest, RewriteNone:
+				if bg.GetLogsSplitter != nil {
+					if filter, ranges, ok := bg.GetLogsSplitter.split(req); ok {
+						overriddenResponses = append(overriddenResponses, &indexedReqRes{
+							index: i,
+							req:   req,
+							res:   bg.GetLogsSplitter.Forward(ctx, backends, req, filter, ranges),
+						})
+						continue
+					}
+				}
 				rewrittenReqs = append(rewrittenReqs, req)
 			}
 		}
@@ -828,9 +848,9 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 	return nil, "", ErrNoBackends
 }
 
-func (bg *BackendGroup) ProxyWS(ctx context.Context, clientConn *websocket.Conn, methodWhitelist *StringSet) (*WSProxier, error) {
+func (bg *BackendGroup) ProxyWS(ctx context.Context, clientConn *websocket.Conn, methodWhitelist *StringSet, keyQuotas *KeyQuotas) (*WSProxier, error) {
 	for _, back := range bg.Backends {
-		proxier, err := back.ProxyWS(clientConn, methodWhitelist)
+		proxier, err := back.ProxyWS(clientConn, methodWhitelist, keyQuotas)
 		if errors.Is(err, ErrBackendOffline) {
 			log.Warn(
 				"skipping offline backend",
@@ -943,16 +963,18 @@ type WSProxier struct {
 	backendConn     *websocket.Conn
 	backendConnMu   sync.Mutex
 	methodWhitelist *StringSet
+	keyQuotas       *KeyQuotas
 	readTimeout     time.Duration
 	writeTimeout    time.Duration
 }
 
-func NewWSProxier(backend *Backend, clientConn, backendConn *websocket.Conn, methodWhitelist *StringSet) *WSProxier {
+func NewWSProxier(backend *Backend, clientConn, backendConn *websocket.Conn, methodWhitelist *StringSet, keyQuotas *KeyQuotas) *WSProxier {
 	return &WSProxier{
 		backend:         backend,
 		clientConn:      clientConn,
 		backendConn:     backendConn,
 		methodWhitelist: methodWhitelist,
+		keyQuotas:       keyQuotas,
 		readTimeout:     defaultWSReadTimeout,
 		writeTimeout:    defaultWSWriteTimeout,
 	}
@@ -996,7 +1018,7 @@ func (w *WSProxier) clientPump(ctx context.Context, errC chan error) {
 
 		// Don't bother sending invalid requests to the backend,
 		// just handle them here.
-		req, err := w.prepareClientMsg(msg)
+		req, err := w.prepareClientMsg(ctx, msg)
 		if err != nil {
 			var id json.RawMessage
 			method := MethodUnknown
@@ -1116,7 +1138,7 @@ func (w *WSProxier) close() {
 	activeBackendWsConnsGauge.WithLabelValues(w.backend.Name).Dec()
 }
 
-func (w *WSProxier) prepareClientMsg(msg []byte) (*RPCReq, error) {
+func (w *WSProxier) prepareClientMsg(ctx context.Context, msg []byte) (*RPCReq, error) {
 	req, err := ParseRPCReq(msg)
 	if err != nil {
 		return nil, err
@@ -1126,9 +1148,33 @@ func (w *WSProxier) prepareClientMsg(msg []byte) (*RPCReq, error) {
 		return req, ErrMethodNotWhitelisted
 	}
 
+	if err := w.takeKeyQuota(ctx, req); err != nil {
+		return req, err
+	}
+
 	return req, nil
 }
 
+// takeKeyQuota takes each message of an authenticated connection from the quota of its key,
+// like a single RPC request over HTTP.
+func (w *WSProxier) takeKeyQuota(ctx context.Context, req *RPCReq) error {
+	key := GetAuthCtx(ctx)
+	if w.keyQuotas == nil || key == "none" {
+		return nil
+	}
+	ok, err := w.keyQuotas.TakeRequest(ctx, key)
+	if err == nil && ok {
+		ok, err = w.keyQuotas.TakeComputeUnits(ctx, key, req.Method)
+	}
+	if err != nil {
+		log.Warn("error taking key quota", "source", "ws", "auth", key, "err", err)
+	}
+	if err != nil || !ok {
+		return ErrOverKeyQuota
+	}
+	return nil
+}
+
 func (w *WSProxier) parseBackendMsg(msg []byte) (*RPCRes, error) {
 	res, err := ParseRPCRes(bytes.NewReader(msg))
 	if err != nil {
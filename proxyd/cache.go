@@ -2,11 +2,13 @@ package proxyd
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/redis/go-redis/v9"
 
 	"github.com/golang/snappy"
@@ -15,7 +17,8 @@ import (
 
 type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
-	Put(ctx context.Context, key string, value string) error
+	// Put stores the value under the key. A ttl of 0 uses the backend's default TTL.
+	Put(ctx context.Context, key string, value string, ttl time.Duration) error
 }
 
 const (
@@ -23,26 +26,96 @@ const (
 	memoryCacheLimit = 4096
 	// Set a large ttl to avoid expirations. However, a ttl must be set for volatile-lru to take effect.
 	redisTTL = 30 * 7 * 24 * time.Hour
+
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
+)
+
+// CacheBackendFactory creates a Cache backend from the proxyd config.
+// The redis client is nil if redis is not configured.
+type CacheBackendFactory func(config *Config, rdb *redis.Client) (Cache, error)
+
+var (
+	cacheBackendsMtx sync.RWMutex
+	cacheBackends    = map[string]CacheBackendFactory{
+		CacheBackendMemory: func(config *Config, _ *redis.Client) (Cache, error) {
+			return newMemoryCacheWithSize(config.Cache.MemorySize), nil
+		},
+		CacheBackendRedis: func(config *Config, rdb *redis.Client) (Cache, error) {
+			if rdb == nil {
+				return nil, fmt.Errorf("redis cache backend requires redis to be configured")
+			}
+			return newRedisCache(rdb, config.Redis.Namespace), nil
+		},
+	}
 )
 
+// RegisterCacheBackend registers a cache backend, which can then be selected
+// with the `backend` option of the cache config.
+func RegisterCacheBackend(name string, factory CacheBackendFactory) {
+	cacheBackendsMtx.Lock()
+	defer cacheBackendsMtx.Unlock()
+	cacheBackends[name] = factory
+}
+
+// newCacheBackend creates the cache backend selected in the config.
+func newCacheBackend(config *Config, rdb *redis.Client) (Cache, error) {
+	name := config.Cache.Backend
+	if name == "" {
+		name = CacheBackendRedis
+		if rdb == nil {
+			log.Warn("redis is not configured, using in-memory cache")
+			name = CacheBackendMemory
+		}
+	}
+	cacheBackendsMtx.RLock()
+	factory, ok := cacheBackends[name]
+	cacheBackendsMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown cache backend: %s", name)
+	}
+	return factory(config, rdb)
+}
+
 type cache struct {
 	lru *lru.Cache
 }
 
+type memoryCacheEntry struct {
+	value     string
+	expiresAt time.Time // zero if the entry does not expire
+}
+
 func newMemoryCache() *cache {
-	rep, _ := lru.New(memoryCacheLimit)
+	return newMemoryCacheWithSize(memoryCacheLimit)
+}
+
+func newMemoryCacheWithSize(size int) *cache {
+	if size <= 0 {
+		size = memoryCacheLimit
+	}
+	rep, _ := lru.New(size)
 	return &cache{rep}
 }
 
 func (c *cache) Get(ctx context.Context, key string) (string, error) {
 	if val, ok := c.lru.Get(key); ok {
-		return val.(string), nil
+		entry := val.(memoryCacheEntry)
+		if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+			c.lru.Remove(key)
+			return "", nil
+		}
+		return entry.value, nil
 	}
 	return "", nil
 }
 
-func (c *cache) Put(ctx context.Context, key string, value string) error {
-	c.lru.Add(key, value)
+func (c *cache) Put(ctx context.Context, key string, value string, ttl time.Duration) error {
+	entry := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.lru.Add(key, entry)
 	return nil
 }
 
@@ -76,9 +149,12 @@ func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
 	return val, nil
 }
 
-func (c *redisCache) Put(ctx context.Context, key string, value string) error {
+func (c *redisCache) Put(ctx context.Context, key string, value string, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = redisTTL
+	}
 	start := time.Now()
-	err := c.rdb.SetEx(ctx, c.namespaced(key), value, redisTTL).Err()
+	err := c.rdb.SetEx(ctx, c.namespaced(key), value, ttl).Err()
 	redisCacheDurationSumm.WithLabelValues("SETEX").Observe(float64(time.Since(start).Milliseconds()))
 
 	if err != nil {
@@ -110,9 +186,9 @@ func (c *cacheWithCompression) Get(ctx context.Context, key string) (string, err
 	return string(val), nil
 }
 
-func (c *cacheWithCompression) Put(ctx context.Context, key string, value string) error {
+func (c *cacheWithCompression) Put(ctx context.Context, key string, value string, ttl time.Duration) error {
 	encodedVal := snappy.Encode(nil, []byte(value))
-	return c.cache.Put(ctx, key, string(encodedVal))
+	return c.cache.Put(ctx, key, string(encodedVal), ttl)
 }
 
 type RPCCache interface {
@@ -125,40 +201,101 @@ type rpcCache struct {
 	handlers map[string]RPCMethodHandler
 }
 
-func newRPCCache(cache Cache) RPCCache {
-	staticHandler := &StaticMethodHandler{cache: cache}
-	debugGetRawReceiptsHandler := &StaticMethodHandler{cache: cache,
-		filterGet: func(req *RPCReq) bool {
-			// cache only if the request is for a block hash
+type rpcCacheConfig struct {
+	ttl            time.Duration
+	unfinalizedTTL time.Duration
+	methodTTLs     map[string]time.Duration
+	consensus      *CacheConsensus
+}
 
-			var p []rpc.BlockNumberOrHash
-			err := json.Unmarshal(req.Params, &p)
-			if err != nil {
-				return false
-			}
-			if len(p) != 1 {
-				return false
-			}
-			return p[0].BlockHash != nil
-		},
-		filterPut: func(req *RPCReq, res *RPCRes) bool {
-			// don't cache if response contains 0 receipts
-			rawReceipts, ok := res.Result.([]interface{})
-			if !ok {
-				return false
-			}
-			return len(rawReceipts) > 0
-		},
+type RPCCacheOpt func(cfg *rpcCacheConfig)
+
+// WithCacheTTL sets the TTL of immutable responses. 0 uses the cache backend's default.
+func WithCacheTTL(ttl time.Duration) RPCCacheOpt {
+	return func(cfg *rpcCacheConfig) {
+		cfg.ttl = ttl
+	}
+}
+
+// WithUnfinalizedCacheTTL enables caching responses for blocks above the finalized block with the given TTL.
+func WithUnfinalizedCacheTTL(ttl time.Duration) RPCCacheOpt {
+	return func(cfg *rpcCacheConfig) {
+		cfg.unfinalizedTTL = ttl
+	}
+}
+
+// WithMethodCacheTTLs overrides the TTL of immutable responses per method.
+func WithMethodCacheTTLs(ttls map[string]time.Duration) RPCCacheOpt {
+	return func(cfg *rpcCacheConfig) {
+		cfg.methodTTLs = ttls
+	}
+}
+
+// WithCacheConsensus enables caching of methods referencing blocks by number or tag,
+// using the block heights of the consensus.
+func WithCacheConsensus(consensus *CacheConsensus) RPCCacheOpt {
+	return func(cfg *rpcCacheConfig) {
+		cfg.consensus = consensus
+	}
+}
+
+func newRPCCache(cache Cache, opts ...RPCCacheOpt) RPCCache {
+	cfg := &rpcCacheConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ttl := func(method string) time.Duration {
+		if ttl, ok := cfg.methodTTLs[method]; ok {
+			return ttl
+		}
+		return cfg.ttl
+	}
+	static := func(method string) *StaticMethodHandler {
+		return &StaticMethodHandler{cache: cache, ttl: ttl(method)}
+	}
+	blockTag := func(method string, kind blockParamKind, pos int, required bool) *BlockTagMethodHandler {
+		return &BlockTagMethodHandler{
+			cache:          cache,
+			consensus:      cfg.consensus,
+			ttl:            ttl(method),
+			unfinalizedTTL: cfg.unfinalizedTTL,
+			kind:           kind,
+			pos:            pos,
+			required:       required,
+		}
+	}
+
+	debugGetRawReceiptsHandler := blockTag("debug_getRawReceipts", blockParamNumberOrHash, 0, true)
+	debugGetRawReceiptsHandler.filterPut = func(req *RPCReq, res *RPCRes) bool {
+		// don't cache if response contains 0 receipts
+		rawReceipts, ok := res.Result.([]interface{})
+		if !ok {
+			return false
+		}
+		return len(rawReceipts) > 0
 	}
 	handlers := map[string]RPCMethodHandler{
-		"eth_chainId":                           staticHandler,
-		"net_version":                           staticHandler,
-		"eth_getBlockTransactionCountByHash":    staticHandler,
-		"eth_getUncleCountByBlockHash":          staticHandler,
-		"eth_getBlockByHash":                    staticHandler,
-		"eth_getTransactionByBlockHashAndIndex": staticHandler,
-		"eth_getUncleByBlockHashAndIndex":       staticHandler,
+		"eth_chainId":                           static("eth_chainId"),
+		"net_version":                           static("net_version"),
+		"eth_getBlockTransactionCountByHash":    static("eth_getBlockTransactionCountByHash"),
+		"eth_getUncleCountByBlockHash":          static("eth_getUncleCountByBlockHash"),
+		"eth_getBlockByHash":                    static("eth_getBlockByHash"),
+		"eth_getTransactionByBlockHashAndIndex": static("eth_getTransactionByBlockHashAndIndex"),
+		"eth_getUncleByBlockHashAndIndex":       static("eth_getUncleByBlockHashAndIndex"),
 		"debug_getRawReceipts":                  debugGetRawReceiptsHandler,
+
+		"eth_getBlockByNumber":                    blockTag("eth_getBlockByNumber", blockParamNumber, 0, true),
+		"eth_getBlockTransactionCountByNumber":    blockTag("eth_getBlockTransactionCountByNumber", blockParamNumber, 0, true),
+		"eth_getUncleCountByBlockNumber":          blockTag("eth_getUncleCountByBlockNumber", blockParamNumber, 0, true),
+		"eth_getTransactionByBlockNumberAndIndex": blockTag("eth_getTransactionByBlockNumberAndIndex", blockParamNumber, 0, true),
+		"eth_getUncleByBlockNumberAndIndex":       blockTag("eth_getUncleByBlockNumberAndIndex", blockParamNumber, 0, true),
+		"eth_getBalance":                          blockTag("eth_getBalance", blockParamNumberOrHash, 1, false),
+		"eth_getCode":                             blockTag("eth_getCode", blockParamNumberOrHash, 1, false),
+		"eth_getTransactionCount":                 blockTag("eth_getTransactionCount", blockParamNumberOrHash, 1, false),
+		"eth_call":                                blockTag("eth_call", blockParamNumberOrHash, 1, false),
+		"eth_getStorageAt":                        blockTag("eth_getStorageAt", blockParamNumberOrHash, 2, false),
+		"eth_getProof":                            blockTag("eth_getProof", blockParamNumberOrHash, 2, false),
+		"eth_getLogs":                             blockTag("eth_getLogs", blockParamFilter, 0, false),
 	}
 	return &rpcCache{
 		cache:    cache,
@@ -166,6 +303,68 @@ func newRPCCache(cache Cache) RPCCache {
 	}
 }
 
+// BlockHeights provides the latest, safe and finalized block numbers agreed on by a backend group.
+type BlockHeights interface {
+	GetLatestBlockNumber() hexutil.Uint64
+	GetSafeBlockNumber() hexutil.Uint64
+	GetFinalizedBlockNumber() hexutil.Uint64
+}
+
+type cacheHeights struct {
+	latest     hexutil.Uint64
+	safe       hexutil.Uint64
+	finalized  hexutil.Uint64
+	generation uint64
+}
+
+// CacheConsensus tracks the block heights used to resolve block tags of cached requests,
+// and invalidates cached unfinalized data on reorgs.
+type CacheConsensus struct {
+	mutex      sync.RWMutex
+	source     BlockHeights
+	generation uint64
+}
+
+func NewCacheConsensus() *CacheConsensus {
+	// unfinalized entries are keyed by generation, so start from a unique
+	// generation to not serve entries of a previous run from a shared cache.
+	return &CacheConsensus{generation: uint64(time.Now().UnixNano())}
+}
+
+// SetBlockHeights sets the source of the block heights.
+func (c *CacheConsensus) SetBlockHeights(source BlockHeights) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.source = source
+}
+
+// Invalidate invalidates all cached unfinalized data. It's called when a reorg is detected.
+func (c *CacheConsensus) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	RecordCacheInvalidation()
+}
+
+func (c *CacheConsensus) heights() (cacheHeights, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.source == nil {
+		return cacheHeights{}, false
+	}
+	h := cacheHeights{
+		latest:     c.source.GetLatestBlockNumber(),
+		safe:       c.source.GetSafeBlockNumber(),
+		finalized:  c.source.GetFinalizedBlockNumber(),
+		generation: c.generation,
+	}
+	// heights are unknown until the consensus has been established
+	if h.latest == 0 || h.finalized == 0 {
+		return cacheHeights{}, false
+	}
+	return h, true
+}
+
 func (c *rpcCache) GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error) {
 	handler := c.handlers[req.Method]
 	if handler == nil {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -211,3 +213,125 @@ func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	}
 
 }
+
+type testBlockHeights struct {
+	latest, safe, finalized hexutil.Uint64
+}
+
+func (h *testBlockHeights) GetLatestBlockNumber() hexutil.Uint64    { return h.latest }
+func (h *testBlockHeights) GetSafeBlockNumber() hexutil.Uint64      { return h.safe }
+func (h *testBlockHeights) GetFinalizedBlockNumber() hexutil.Uint64 { return h.finalized }
+
+func TestRPCCacheBlockTags(t *testing.T) {
+	ctx := context.Background()
+
+	heights := &testBlockHeights{latest: 0x200, safe: 0x180, finalized: 0x100}
+	consensus := NewCacheConsensus()
+	consensus.SetBlockHeights(heights)
+	cache := newRPCCache(newMemoryCache(), WithCacheConsensus(consensus))
+	ID := []byte(strconv.Itoa(1))
+
+	put := func(method string, params interface{}) {
+		req := &RPCReq{JSONRPC: "2.0", Method: method, Params: mustMarshalJSON(params), ID: ID}
+		require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{JSONRPC: "2.0", Result: method, ID: ID}))
+	}
+	get := func(method string, params interface{}) *RPCRes {
+		req := &RPCReq{JSONRPC: "2.0", Method: method, Params: mustMarshalJSON(params), ID: ID}
+		res, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("finalized block number", func(t *testing.T) {
+		put("eth_getBlockByNumber", []interface{}{"0x100", false})
+		require.NotNil(t, get("eth_getBlockByNumber", []interface{}{"0x100", false}))
+		require.NotNil(t, get("eth_getBlockByNumber", []interface{}{"finalized", false}))
+		require.Nil(t, get("eth_getBlockByNumber", []interface{}{"0x100", true}))
+	})
+
+	t.Run("block tags are not put", func(t *testing.T) {
+		put("eth_getBlockByNumber", []interface{}{"finalized", true})
+		require.Nil(t, get("eth_getBlockByNumber", []interface{}{"0x100", true}))
+	})
+
+	t.Run("unfinalized data is not cached by default", func(t *testing.T) {
+		put("eth_getBalance", []interface{}{"0xabc", "0x180"})
+		require.Nil(t, get("eth_getBalance", []interface{}{"0xabc", "safe"}))
+	})
+
+	t.Run("pending and future blocks", func(t *testing.T) {
+		put("eth_getCode", []interface{}{"0xabc", "0x300"})
+		require.Nil(t, get("eth_getCode", []interface{}{"0xabc", "0x300"}))
+		require.Nil(t, get("eth_getCode", []interface{}{"0xabc", "pending"}))
+	})
+
+	t.Run("block hash", func(t *testing.T) {
+		params := []interface{}{map[string]interface{}{"to": "0xabc"}, map[string]interface{}{"blockHash": "0xb903239f8543d04b5dc1ba6579132b143087c68db1b2168786408fcbce568238"}}
+		put("eth_call", params)
+		require.NotNil(t, get("eth_call", params))
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		put("eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x10", "toBlock": "0x100", "address": "0xabc"}})
+		require.NotNil(t, get("eth_getLogs", []interface{}{map[string]interface{}{"address": "0xabc", "fromBlock": "0x10", "toBlock": "finalized"}}))
+		require.Nil(t, get("eth_getLogs", []interface{}{map[string]interface{}{"address": "0xabc", "fromBlock": "0x10", "toBlock": "latest"}}))
+
+		put("eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x10", "toBlock": "0x101"}})
+		require.Nil(t, get("eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x10", "toBlock": "0x101"}}))
+	})
+
+	t.Run("unknown heights", func(t *testing.T) {
+		cache := newRPCCache(newMemoryCache(), WithCacheConsensus(NewCacheConsensus()))
+		req := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x1", false}), ID: ID}
+		require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{Result: "0x1"}))
+		res, err := cache.GetRPC(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+}
+
+func TestRPCCacheUnfinalizedInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	heights := &testBlockHeights{latest: 0x200, safe: 0x180, finalized: 0x100}
+	consensus := NewCacheConsensus()
+	consensus.SetBlockHeights(heights)
+	cache := newRPCCache(newMemoryCache(), WithCacheConsensus(consensus), WithUnfinalizedCacheTTL(time.Minute))
+	ID := []byte(strconv.Itoa(1))
+
+	req := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x180", false}), ID: ID}
+	safeReq := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"safe", false}), ID: ID}
+	require.NoError(t, cache.PutRPC(ctx, req, &RPCRes{Result: "0x180"}))
+
+	res, err := cache.GetRPC(ctx, safeReq)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	consensus.Invalidate()
+	res, err = cache.GetRPC(ctx, req)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestRPCCacheTTL(t *testing.T) {
+	ctx := context.Background()
+
+	cache := newRPCCache(newMemoryCache(), WithCacheTTL(time.Minute), WithMethodCacheTTLs(map[string]time.Duration{
+		"net_version": time.Millisecond,
+	}))
+	ID := []byte(strconv.Itoa(1))
+
+	chainID := &RPCReq{JSONRPC: "2.0", Method: "eth_chainId", ID: ID}
+	netVersion := &RPCReq{JSONRPC: "2.0", Method: "net_version", ID: ID}
+	require.NoError(t, cache.PutRPC(ctx, chainID, &RPCRes{Result: "0xff"}))
+	require.NoError(t, cache.PutRPC(ctx, netVersion, &RPCRes{Result: "255"}))
+	time.Sleep(10 * time.Millisecond)
+
+	res, err := cache.GetRPC(ctx, chainID)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	res, err = cache.GetRPC(ctx, netVersion)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
@@ -30,6 +30,28 @@ type ServerConfig struct {
 
 type CacheConfig struct {
 	Enabled bool `toml:"enabled"`
+
+	// Backend selects the cache backend, one of the registered cache backends
+	// ("memory" or "redis" by default). If empty, redis is used when configured,
+	// and the in-memory cache otherwise.
+	Backend string `toml:"backend"`
+	// MemorySize is the maximum number of entries of the in-memory cache backend.
+	MemorySize int `toml:"memory_size"`
+
+	// TTL is the default TTL of cached responses referencing immutable data, i.e. data
+	// addressed by block hash or at or below the finalized block.
+	TTL TOMLDuration `toml:"ttl"`
+	// UnfinalizedTTL is the TTL of cached responses referencing data above the
+	// finalized block. These entries are invalidated on reorgs. 0 disables caching
+	// of unfinalized data.
+	UnfinalizedTTL TOMLDuration `toml:"unfinalized_ttl"`
+	// MethodTTLs overrides TTL per method.
+	MethodTTLs map[string]TOMLDuration `toml:"method_ttls"`
+
+	// ConsensusBackendGroup is the consensus-aware backend group whose latest, safe and
+	// finalized block heights are used to resolve block tags of cached requests.
+	// Requests referencing blocks by number or tag are only cached if it is set.
+	ConsensusBackendGroup string `toml:"consensus_backend_group"`
 }
 
 type RedisConfig struct {
@@ -54,6 +76,33 @@ type RateLimitConfig struct {
 	IPHeaderOverride string                              `toml:"ip_header_override"`
 }
 
+// KeyQuotasConfig configures the quotas of authenticated keys.
+// Keys are referred to by their alias in the authentication config.
+type KeyQuotasConfig struct {
+	Enabled  bool `toml:"enabled"`
+	UseRedis bool `toml:"use_redis"`
+	// DefaultRate is the requests per second limit of keys without a quota. 0 means unlimited.
+	DefaultRate int `toml:"default_rate"`
+	// DefaultDailyComputeUnits is the daily compute units limit of keys without a quota. 0 means unlimited.
+	DefaultDailyComputeUnits int64 `toml:"default_daily_compute_units"`
+	// DefaultMethodWeight is the compute units of methods without a weight, 1 if unset.
+	DefaultMethodWeight int64                      `toml:"default_method_weight"`
+	MethodWeights       map[string]int64           `toml:"method_weights"`
+	Keys                map[string]*KeyQuotaConfig `toml:"keys"`
+	ErrorMessage        string                     `toml:"error_message"`
+
+	// The admin server exposes the key usage, and allows resetting it. It requires the
+	// admin token, and listens on 127.0.0.1 unless admin_host is set.
+	AdminHost  string `toml:"admin_host"`
+	AdminPort  int    `toml:"admin_port"`
+	AdminToken string `toml:"admin_token"`
+}
+
+type KeyQuotaConfig struct {
+	Rate              int   `toml:"rate"`
+	DailyComputeUnits int64 `toml:"daily_compute_units"`
+}
+
 type RateLimitMethodOverride struct {
 	Limit    int          `toml:"limit"`
 	Interval TOMLDuration `toml:"interval"`
@@ -122,6 +171,14 @@ type BackendGroupConfig struct {
 	ConsensusHA                  bool         `toml:"consensus_ha"`
 	ConsensusHAHeartbeatInterval TOMLDuration `toml:"consensus_ha_heartbeat_interval"`
 	ConsensusHALockPeriod        TOMLDuration `toml:"consensus_ha_lock_period"`
+
+	// GetLogsSplitRange enables splitting eth_getLogs requests spanning more blocks
+	// into sub-ranges of this size, which are fanned out across the backends.
+	// Requires consensus_aware. 0 disables splitting.
+	GetLogsSplitRange     uint64 `toml:"get_logs_split_range"`
+	GetLogsMaxRange       uint64 `toml:"get_logs_max_range"`
+	GetLogsMaxConcurrency int    `toml:"get_logs_max_concurrency"`
+	GetLogsMaxResults     int    `toml:"get_logs_max_results"`
 }
 
 type BackendGroupsConfig map[string]*BackendGroupConfig
@@ -142,6 +199,26 @@ type SenderRateLimitConfig struct {
 	AllowedChainIds []*big.Int `toml:"allowed_chain_ids"`
 }
 
+// TxGuardConfig configures the pre-flight checks of eth_sendRawTransaction requests.
+type TxGuardConfig struct {
+	Enabled bool `toml:"enabled"`
+	// ChainIDs are the allowed chain ids of transactions, any if empty.
+	ChainIDs []*big.Int `toml:"chain_ids"`
+	// CheckNonce rejects transactions with a nonce below the pending nonce of the sender,
+	// or more than MaxNonceGap above it. MaxNonceGap defaults to 64.
+	CheckNonce  bool   `toml:"check_nonce"`
+	MaxNonceGap uint64 `toml:"max_nonce_gap"`
+	// CheckBalance rejects transactions whose max cost exceeds the pending balance of the sender.
+	CheckBalance bool `toml:"check_balance"`
+	// Simulate rejects transactions reverting in eth_call against the pending state.
+	Simulate bool `toml:"simulate"`
+	// EstimateGas rejects transactions reverting in eth_estimateGas, or with a gas limit below the estimate.
+	EstimateGas bool `toml:"estimate_gas"`
+	// BackendGroup is the backend group queried for the checks. Defaults to the
+	// backend group eth_sendRawTransaction is mapped to.
+	BackendGroup string `toml:"backend_group"`
+}
+
 type Config struct {
 	WSBackendGroup        string                `toml:"ws_backend_group"`
 	Server                ServerConfig          `toml:"server"`
@@ -158,6 +235,8 @@ type Config struct {
 	WSMethodWhitelist     []string              `toml:"ws_method_whitelist"`
 	WhitelistErrorMessage string                `toml:"whitelist_error_message"`
 	SenderRateLimit       SenderRateLimitConfig `toml:"sender_rate_limit"`
+	KeyQuotas             KeyQuotasConfig       `toml:"key_quotas"`
+	TxGuard               TxGuardConfig         `toml:"tx_guard"`
 }
 
 func ReadFromEnvOrConfig(value string) (string, error) {
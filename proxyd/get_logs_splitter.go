@@ -0,0 +1,220 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultGetLogsMaxConcurrency = 4
+	defaultGetLogsMaxRange       = 100_000
+)
+
+// GetLogsSplitter splits eth_getLogs requests spanning more blocks than the split range
+// into sub-range requests, fans them out across the backends of a group and merges the results.
+type GetLogsSplitter struct {
+	splitRange     uint64
+	maxRange       uint64
+	maxConcurrency int
+	maxResults     int
+}
+
+type GetLogsSplitterOpt func(s *GetLogsSplitter)
+
+// WithGetLogsMaxRange sets the maximum block range of a request that is split. It defaults to
+// defaultGetLogsMaxRange, or the split range if larger.
+func WithGetLogsMaxRange(maxRange uint64) GetLogsSplitterOpt {
+	return func(s *GetLogsSplitter) {
+		s.maxRange = maxRange
+	}
+}
+
+// WithGetLogsMaxConcurrency sets the maximum number of sub-range requests in flight per request.
+func WithGetLogsMaxConcurrency(maxConcurrency int) GetLogsSplitterOpt {
+	return func(s *GetLogsSplitter) {
+		s.maxConcurrency = maxConcurrency
+	}
+}
+
+// WithGetLogsMaxResults sets the maximum number of logs returned by a split request. 0 means unlimited.
+func WithGetLogsMaxResults(maxResults int) GetLogsSplitterOpt {
+	return func(s *GetLogsSplitter) {
+		s.maxResults = maxResults
+	}
+}
+
+func NewGetLogsSplitter(splitRange uint64, opts ...GetLogsSplitterOpt) *GetLogsSplitter {
+	s := &GetLogsSplitter{
+		splitRange:     splitRange,
+		maxConcurrency: defaultGetLogsMaxConcurrency,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.maxRange == 0 {
+		s.maxRange = max(defaultGetLogsMaxRange, splitRange)
+	}
+	return s
+}
+
+// logsRange is a sub-range request of a split eth_getLogs request.
+type logsRange struct {
+	from, to uint64
+}
+
+// split returns the filter and sub-ranges of the request if it should be split.
+// The block tags of the request must already be rewritten to block numbers.
+func (s *GetLogsSplitter) split(req *RPCReq) (map[string]interface{}, []logsRange, bool) {
+	if req.Method != "eth_getLogs" {
+		return nil, nil, false
+	}
+	var p []map[string]interface{}
+	if err := json.Unmarshal(req.Params, &p); err != nil || len(p) != 1 {
+		return nil, nil, false
+	}
+	filter := p[0]
+	if _, ok := filter["blockHash"]; ok {
+		return nil, nil, false
+	}
+	from, err := splittableBlockNumber(filter, "fromBlock")
+	if err != nil {
+		return nil, nil, false
+	}
+	to, err := splittableBlockNumber(filter, "toBlock")
+	if err != nil || to < from || to-from < s.splitRange {
+		return nil, nil, false
+	}
+
+	ranges := make([]logsRange, 0, (to-from)/s.splitRange+1)
+	for start := from; start <= to; start += s.splitRange {
+		end := start + s.splitRange - 1
+		if end > to || end < start {
+			end = to
+		}
+		ranges = append(ranges, logsRange{from: start, to: end})
+		if end == to {
+			break
+		}
+	}
+	return filter, ranges, true
+}
+
+func splittableBlockNumber(filter map[string]interface{}, key string) (uint64, error) {
+	s, ok := filter[key].(string)
+	if !ok {
+		return 0, errors.New("expected string")
+	}
+	if s == "earliest" {
+		return 0, nil
+	}
+	return hexutil.DecodeUint64(s)
+}
+
+// Forward forwards the sub-range requests to the backends, rotating the backend per sub-range and
+// failing over to the other backends, and returns the merged logs ordered by block.
+func (s *GetLogsSplitter) Forward(ctx context.Context, backends []*Backend, req *RPCReq, filter map[string]interface{}, ranges []logsRange) *RPCRes {
+	res := &RPCRes{JSONRPC: JSONRPCVersion, ID: req.ID}
+	if len(backends) == 0 {
+		res.Error = ErrNoBackends
+		return res
+	}
+
+	results := make([][]interface{}, len(ranges))
+	var count atomic.Int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.maxConcurrency)
+	for i := range ranges {
+		i := i
+		g.Go(func() error {
+			logs, err := s.forwardRange(gctx, backends, i, filter, ranges[i])
+			if err != nil {
+				return err
+			}
+			if s.maxResults > 0 && count.Add(int64(len(logs))) > int64(s.maxResults) {
+				return ErrInvalidParams(fmt.Sprintf("query returned more than %d results", s.maxResults))
+			}
+			results[i] = logs
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		var rpcErr *RPCErr
+		if errors.As(err, &rpcErr) {
+			res.Error = rpcErr
+		} else {
+			res.Error = ErrInternal
+		}
+		return res
+	}
+
+	merged := make([]interface{}, 0, count.Load())
+	for _, logs := range results {
+		merged = append(merged, logs...)
+	}
+	res.Result = merged
+	RecordGetLogsSplit(len(ranges))
+	return res
+}
+
+func (s *GetLogsSplitter) forwardRange(ctx context.Context, backends []*Backend, i int, filter map[string]interface{}, r logsRange) ([]interface{}, error) {
+	subFilter := make(map[string]interface{}, len(filter))
+	for k, v := range filter {
+		subFilter[k] = v
+	}
+	subFilter["fromBlock"] = hexutil.Uint64(r.from).String()
+	subFilter["toBlock"] = hexutil.Uint64(r.to).String()
+	params, err := json.Marshal([]interface{}{subFilter})
+	if err != nil {
+		return nil, err
+	}
+	subReq := &RPCReq{
+		JSONRPC: JSONRPCVersion,
+		Method:  "eth_getLogs",
+		Params:  params,
+		ID:      []byte(fmt.Sprintf("%d", i)),
+	}
+
+	for j := range backends {
+		back := backends[(i+j)%len(backends)]
+		res, err := back.Forward(ctx, []*RPCReq{subReq}, false)
+		if errors.Is(err, ErrBackendResponseTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Warn(
+				"error forwarding split eth_getLogs request to backend",
+				"name", back.Name,
+				"req_id", GetReqID(ctx),
+				"from", r.from,
+				"to", r.to,
+				"err", err,
+			)
+			continue
+		}
+		if len(res) != 1 {
+			continue
+		}
+		if res[0].IsError() {
+			return nil, res[0].Error
+		}
+		if res[0].Result == nil {
+			return nil, nil
+		}
+		logs, ok := res[0].Result.([]interface{})
+		if !ok {
+			return nil, ErrInternal
+		}
+		return logs, nil
+	}
+	return nil, ErrNoBackends
+}
@@ -0,0 +1,132 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestGetLogsSplitterSplit(t *testing.T) {
+	s := NewGetLogsSplitter(10)
+
+	tests := []struct {
+		name   string
+		params string
+		ranges []logsRange
+	}{
+		{
+			name:   "within split range",
+			params: `[{"fromBlock":"0x1","toBlock":"0xa"}]`,
+		},
+		{
+			name:   "block hash",
+			params: `[{"blockHash":"0xb903239f8543d04b5dc1ba6579132b143087c68db1b2168786408fcbce568238"}]`,
+		},
+		{
+			name:   "pending",
+			params: `[{"fromBlock":"0x1","toBlock":"pending"}]`,
+		},
+		{
+			name:   "exact multiple",
+			params: `[{"fromBlock":"0x1","toBlock":"0x14"}]`,
+			ranges: []logsRange{{1, 10}, {11, 20}},
+		},
+		{
+			name:   "remainder",
+			params: `[{"fromBlock":"earliest","toBlock":"0x14","address":"0x1"}]`,
+			ranges: []logsRange{{0, 9}, {10, 19}, {20, 20}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ranges, ok := s.split(&RPCReq{Method: "eth_getLogs", Params: json.RawMessage(tt.params)})
+			require.Equal(t, tt.ranges != nil, ok)
+			require.Equal(t, tt.ranges, ranges)
+		})
+	}
+}
+
+// logsBackend returns a log per block of the requested range.
+func logsBackend(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RPCReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var p []map[string]string
+		require.NoError(t, json.Unmarshal(req.Params, &p))
+		from := hexutil.MustDecodeUint64(p[0]["fromBlock"])
+		to := hexutil.MustDecodeUint64(p[0]["toBlock"])
+		logs := make([]map[string]string, 0)
+		for n := from; n <= to; n++ {
+			logs = append(logs, map[string]string{"blockNumber": hexutil.Uint64(n).String()})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(NewRPCRes(req.ID, logs)))
+	}))
+}
+
+func TestGetLogsSplitterForward(t *testing.T) {
+	sem := semaphore.NewWeighted(100)
+	srv1, srv2 := logsBackend(t), logsBackend(t)
+	defer srv1.Close()
+	defer srv2.Close()
+	backends := []*Backend{
+		NewBackend("node1", srv1.URL, "", sem, WithStrippedTrailingXFF()),
+		NewBackend("node2", srv2.URL, "", sem, WithStrippedTrailingXFF()),
+	}
+	req := &RPCReq{
+		JSONRPC: JSONRPCVersion,
+		Method:  "eth_getLogs",
+		Params:  json.RawMessage(`[{"fromBlock":"0x1","toBlock":"0x64"}]`),
+		ID:      json.RawMessage("1"),
+	}
+
+	t.Run("merges ordered results", func(t *testing.T) {
+		s := NewGetLogsSplitter(7, WithGetLogsMaxConcurrency(3))
+		filter, ranges, ok := s.split(req)
+		require.True(t, ok)
+		res := s.Forward(context.Background(), backends, req, filter, ranges)
+		require.Nil(t, res.Error)
+		logs := res.Result.([]interface{})
+		require.Len(t, logs, 100)
+		for i, l := range logs {
+			require.Equal(t, hexutil.Uint64(i+1).String(), l.(map[string]interface{})["blockNumber"])
+		}
+	})
+
+	t.Run("max results", func(t *testing.T) {
+		s := NewGetLogsSplitter(10, WithGetLogsMaxResults(50))
+		filter, ranges, ok := s.split(req)
+		require.True(t, ok)
+		res := s.Forward(context.Background(), backends, req, filter, ranges)
+		require.NotNil(t, res.Error)
+		require.Equal(t, "query returned more than 50 results", res.Error.Message)
+	})
+
+	t.Run("fails over", func(t *testing.T) {
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer down.Close()
+		s := NewGetLogsSplitter(10)
+		filter, ranges, ok := s.split(req)
+		require.True(t, ok)
+		res := s.Forward(context.Background(), []*Backend{
+			NewBackend("down", down.URL, "", sem, WithStrippedTrailingXFF()),
+			backends[0],
+		}, req, filter, ranges)
+		require.Nil(t, res.Error)
+		require.Len(t, res.Result, 100)
+	})
+}
+
+func TestGetLogsSplitterMaxRange(t *testing.T) {
+	// the max range is bounded by default
+	require.Equal(t, uint64(defaultGetLogsMaxRange), NewGetLogsSplitter(10).maxRange)
+	require.Equal(t, uint64(defaultGetLogsMaxRange*2), NewGetLogsSplitter(defaultGetLogsMaxRange*2).maxRange)
+	require.Equal(t, uint64(500), NewGetLogsSplitter(10, WithGetLogsMaxRange(500)).maxRange)
+}
@@ -315,3 +315,41 @@ func TestWSClientExceedReadLimit(t *testing.T) {
 	require.True(t, closed)
 
 }
+
+func TestWSKeyQuotas(t *testing.T) {
+	backend := NewMockWSBackend(nil, nil, nil)
+	defer backend.Close()
+
+	require.NoError(t, os.Setenv("GOOD_BACKEND_RPC_URL", backend.URL()))
+
+	config := ReadConfig("ws_key_quotas")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	resC := make(chan string, 3)
+	client, err := NewProxydWSClient("ws://127.0.0.1:8546/secret", func(msgType int, data []byte) {
+		resC <- string(data)
+	}, nil)
+	require.NoError(t, err)
+	defer client.HardClose()
+
+	// eth_accounts is answered by proxyd, but still uses the compute units of the key
+	expRes := []string{
+		"{\"jsonrpc\":\"2.0\",\"result\":[],\"id\":1}",
+		"{\"jsonrpc\":\"2.0\",\"result\":[],\"id\":1}",
+		"{\"jsonrpc\":\"2.0\",\"error\":{\"code\":-32022,\"message\":\"key is over quota\"},\"id\":1}",
+	}
+	for _, exp := range expRes {
+		require.NoError(t, client.WriteMessage(
+			websocket.TextMessage,
+			[]byte("{\"jsonrpc\": \"2.0\", \"method\": \"eth_accounts\", \"id\": 1}"),
+		))
+		select {
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out")
+		case res := <-resC:
+			require.Equal(t, exp, res)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package proxyd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	computeUnitsPeriod  = 24 * time.Hour
+	defaultMethodWeight = 1
+)
+
+// ComputeUnitCounter counts the compute units used per key within a period.
+type ComputeUnitCounter interface {
+	// Add adds the units to the usage of the key in the period starting at periodTS,
+	// and returns the total usage of the period.
+	Add(ctx context.Context, key string, periodTS int64, units int64) (int64, error)
+	// Get returns the usage of the key in the period starting at periodTS.
+	Get(ctx context.Context, key string, periodTS int64) (int64, error)
+	// Reset clears the usage of the key in the period starting at periodTS.
+	Reset(ctx context.Context, key string, periodTS int64) error
+}
+
+type computeUnitsKey struct {
+	key      string
+	periodTS int64
+}
+
+// MemoryComputeUnitCounter is a ComputeUnitCounter that stores
+// the usage in local memory. Only the current period is retained.
+type MemoryComputeUnitCounter struct {
+	usage map[computeUnitsKey]int64
+	mtx   sync.Mutex
+}
+
+func NewMemoryComputeUnitCounter() ComputeUnitCounter {
+	return &MemoryComputeUnitCounter{
+		usage: make(map[computeUnitsKey]int64),
+	}
+}
+
+func (m *MemoryComputeUnitCounter) Add(ctx context.Context, key string, periodTS int64, units int64) (int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	// drop the usage of past periods
+	for k := range m.usage {
+		if k.periodTS < periodTS {
+			delete(m.usage, k)
+		}
+	}
+	k := computeUnitsKey{key, periodTS}
+	m.usage[k] += units
+	return m.usage[k], nil
+}
+
+func (m *MemoryComputeUnitCounter) Get(ctx context.Context, key string, periodTS int64) (int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.usage[computeUnitsKey{key, periodTS}], nil
+}
+
+func (m *MemoryComputeUnitCounter) Reset(ctx context.Context, key string, periodTS int64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.usage, computeUnitsKey{key, periodTS})
+	return nil
+}
+
+// RedisComputeUnitCounter is a ComputeUnitCounter that stores the usage in Redis,
+// so it is shared by all proxyd instances.
+type RedisComputeUnitCounter struct {
+	r      *redis.Client
+	prefix string
+}
+
+func NewRedisComputeUnitCounter(r *redis.Client, prefix string) ComputeUnitCounter {
+	return &RedisComputeUnitCounter{
+		r:      r,
+		prefix: prefix,
+	}
+}
+
+func (r *RedisComputeUnitCounter) fullKey(key string, periodTS int64) string {
+	return fmt.Sprintf("compute_units:%s:%s:%d", r.prefix, key, periodTS)
+}
+
+func (r *RedisComputeUnitCounter) Add(ctx context.Context, key string, periodTS int64, units int64) (int64, error) {
+	var incr *redis.IntCmd
+	fullKey := r.fullKey(key, periodTS)
+	_, err := r.r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.IncrBy(ctx, fullKey, units)
+		// keep the usage around past the end of the period, so it can still be inspected
+		pipe.ExpireAt(ctx, fullKey, time.Unix(periodTS, 0).Add(2*computeUnitsPeriod))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r *RedisComputeUnitCounter) Get(ctx context.Context, key string, periodTS int64) (int64, error) {
+	val, err := r.r.Get(ctx, r.fullKey(key, periodTS)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return val, err
+}
+
+func (r *RedisComputeUnitCounter) Reset(ctx context.Context, key string, periodTS int64) error {
+	return r.r.Del(ctx, r.fullKey(key, periodTS)).Err()
+}
+
+// KeyQuota is the quota of an authenticated key. A limit of 0 means unlimited.
+type KeyQuota struct {
+	// Rate is the maximum number of requests per second.
+	Rate int
+	// DailyComputeUnits is the maximum number of compute units per UTC day.
+	DailyComputeUnits int64
+}
+
+// KeyUsage is the usage of an authenticated key in the current period.
+type KeyUsage struct {
+	Key                   string    `json:"key"`
+	Rate                  int       `json:"rate"`
+	DailyComputeUnits     int64     `json:"daily_compute_units"`
+	ComputeUnitsUsed      int64     `json:"compute_units_used"`
+	ComputeUnitsResetTime time.Time `json:"compute_units_reset_time"`
+}
+
+// KeyQuotas enforces per authenticated key request rates and daily compute units.
+// Requests are weighted in compute units per method.
+type KeyQuotas struct {
+	defaultQuota  KeyQuota
+	quotas        map[string]KeyQuota
+	methodWeights map[string]int64
+	defaultWeight int64
+
+	defaultRateLim FrontendRateLimiter
+	rateLims       map[string]FrontendRateLimiter
+	computeUnits   ComputeUnitCounter
+}
+
+// NewKeyQuotas creates the key quotas. The limiterFactory creates the rate limiters with the
+// given max requests per second, and computeUnits counts the compute units used.
+func NewKeyQuotas(
+	defaultQuota KeyQuota,
+	quotas map[string]KeyQuota,
+	methodWeights map[string]int64,
+	defaultWeight int64,
+	limiterFactory func(dur time.Duration, max int, prefix string) FrontendRateLimiter,
+	computeUnits ComputeUnitCounter,
+) *KeyQuotas {
+	if defaultWeight <= 0 {
+		defaultWeight = defaultMethodWeight
+	}
+	q := &KeyQuotas{
+		defaultQuota:  defaultQuota,
+		quotas:        quotas,
+		methodWeights: methodWeights,
+		defaultWeight: defaultWeight,
+		rateLims:      make(map[string]FrontendRateLimiter),
+		computeUnits:  computeUnits,
+	}
+	if defaultQuota.Rate > 0 {
+		q.defaultRateLim = limiterFactory(time.Second, defaultQuota.Rate, "key_quota")
+	}
+	for key, quota := range quotas {
+		if quota.Rate > 0 {
+			q.rateLims[key] = limiterFactory(time.Second, quota.Rate, "key_quota:"+key)
+		}
+	}
+	return q
+}
+
+func (q *KeyQuotas) quota(key string) KeyQuota {
+	if quota, ok := q.quotas[key]; ok {
+		return quota
+	}
+	return q.defaultQuota
+}
+
+func (q *KeyQuotas) weight(method string) int64 {
+	if w, ok := q.methodWeights[method]; ok {
+		return w
+	}
+	return q.defaultWeight
+}
+
+// TakeRequest takes a request from the rate limit of the key.
+// It returns false if the key is over its rate limit.
+func (q *KeyQuotas) TakeRequest(ctx context.Context, key string) (bool, error) {
+	lim, ok := q.rateLims[key]
+	if !ok {
+		if _, configured := q.quotas[key]; configured {
+			// configured without rate limit
+			return true, nil
+		}
+		lim = q.defaultRateLim
+	}
+	if lim == nil {
+		return true, nil
+	}
+	ok, err := lim.Take(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		RecordKeyQuotaRejection(key, "rate")
+	}
+	return ok, nil
+}
+
+// TakeComputeUnits takes the compute units of the method from the daily quota of the key.
+// It returns false if the key exceeded its daily compute units. Rejected requests are not accounted.
+func (q *KeyQuotas) TakeComputeUnits(ctx context.Context, key string, method string) (bool, error) {
+	units := q.weight(method)
+	periodTS := truncateNow(computeUnitsPeriod)
+	total, err := q.computeUnits.Add(ctx, key, periodTS, units)
+	if err != nil {
+		return false, err
+	}
+	limit := q.quota(key).DailyComputeUnits
+	if limit > 0 && total > limit {
+		if _, err := q.computeUnits.Add(ctx, key, periodTS, -units); err != nil {
+			return false, err
+		}
+		RecordKeyQuotaRejection(key, "compute_units")
+		return false, nil
+	}
+	RecordKeyComputeUnits(key, method, units)
+	return true, nil
+}
+
+// Usage returns the usage of the key in the current period.
+func (q *KeyQuotas) Usage(ctx context.Context, key string) (*KeyUsage, error) {
+	periodTS := truncateNow(computeUnitsPeriod)
+	used, err := q.computeUnits.Get(ctx, key, periodTS)
+	if err != nil {
+		return nil, err
+	}
+	quota := q.quota(key)
+	return &KeyUsage{
+		Key:                   key,
+		Rate:                  quota.Rate,
+		DailyComputeUnits:     quota.DailyComputeUnits,
+		ComputeUnitsUsed:      used,
+		ComputeUnitsResetTime: time.Unix(periodTS, 0).Add(computeUnitsPeriod).UTC(),
+	}, nil
+}
+
+// Reset clears the compute units used by the key in the current period.
+func (q *KeyQuotas) Reset(ctx context.Context, key string) error {
+	return q.computeUnits.Reset(ctx, key, truncateNow(computeUnitsPeriod))
+}
+
+// Keys returns the keys with a configured quota, sorted.
+func (q *KeyQuotas) Keys() []string {
+	keys := make([]string, 0, len(q.quotas))
+	for key := range q.quotas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+package proxyd
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/mux"
+)
+
+// defaultAdminHost is the host the admin server listens on if admin_host is unset.
+const defaultAdminHost = "127.0.0.1"
+
+// AdminListenAndServe serves the key quota admin API:
+//
+//	GET  /quotas              usage of all known keys
+//	GET  /quotas/{key}        usage of a key
+//	POST /quotas/{key}/reset  resets the compute units used by a key today
+func (s *Server) AdminListenAndServe(host string, port int) error {
+	s.srvMu.Lock()
+	addr := fmt.Sprintf("%s:%d", host, port)
+	s.adminServer = &http.Server{
+		Handler: s.AdminHandler(),
+		Addr:    addr,
+	}
+	log.Info("starting admin server", "addr", addr)
+	s.srvMu.Unlock()
+	return s.adminServer.ListenAndServe()
+}
+
+func (s *Server) AdminHandler() http.Handler {
+	hdlr := mux.NewRouter()
+	hdlr.HandleFunc("/quotas", s.handleListKeyUsage).Methods("GET")
+	hdlr.HandleFunc("/quotas/{key}", s.handleGetKeyUsage).Methods("GET")
+	hdlr.HandleFunc("/quotas/{key}/reset", s.handleResetKeyUsage).Methods("POST")
+	return s.adminAuth(hdlr)
+}
+
+func (s *Server) adminAuth(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the admin API is never served without a token
+		token := r.Header.Get("Authorization")
+		if s.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+s.adminToken)) != 1 {
+			writeAdminError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// knownKeys returns the aliases of the authenticated keys and the keys with a configured quota.
+func (s *Server) knownKeys() []string {
+	set := make(map[string]struct{})
+	for _, alias := range s.authenticatedPaths {
+		set[alias] = struct{}{}
+	}
+	for _, key := range s.keyQuotas.Keys() {
+		set[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) isKnownKey(key string) bool {
+	for _, k := range s.knownKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handleListKeyUsage(w http.ResponseWriter, r *http.Request) {
+	keys := s.knownKeys()
+	usage := make([]*KeyUsage, 0, len(keys))
+	for _, key := range keys {
+		u, err := s.keyQuotas.Usage(r.Context(), key)
+		if err != nil {
+			log.Error("error reading key usage", "auth", key, "err", err)
+			writeAdminError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		usage = append(usage, u)
+	}
+	writeAdminJSON(w, usage)
+}
+
+func (s *Server) handleGetKeyUsage(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+	if !s.isKnownKey(key) {
+		writeAdminError(w, http.StatusNotFound, "unknown key")
+		return
+	}
+	u, err := s.keyQuotas.Usage(r.Context(), key)
+	if err != nil {
+		log.Error("error reading key usage", "auth", key, "err", err)
+		writeAdminError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeAdminJSON(w, u)
+}
+
+func (s *Server) handleResetKeyUsage(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+	if !s.isKnownKey(key) {
+		writeAdminError(w, http.StatusNotFound, "unknown key")
+		return
+	}
+	if err := s.keyQuotas.Reset(r.Context(), key); err != nil {
+		log.Error("error resetting key usage", "auth", key, "err", err)
+		writeAdminError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	log.Info("reset key usage", "auth", key)
+	s.handleGetKeyUsage(w, r)
+}
+
+func writeAdminJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error("error writing admin response", "err", err)
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
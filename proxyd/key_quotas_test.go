@@ -0,0 +1,187 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyQuotas(t *testing.T) {
+	redisServer, err := miniredis.Run()
+	require.NoError(t, err)
+	defer redisServer.Close()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("127.0.0.1:%s", redisServer.Port()),
+	})
+
+	backends := []struct {
+		name           string
+		limiterFactory func(dur time.Duration, max int, prefix string) FrontendRateLimiter
+		computeUnits   ComputeUnitCounter
+	}{
+		{
+			"memory",
+			func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+				return NewMemoryFrontendRateLimit(dur, max)
+			},
+			NewMemoryComputeUnitCounter(),
+		},
+		{
+			"redis",
+			func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+				return NewRedisFrontendRateLimiter(redisClient, dur, max, prefix)
+			},
+			NewRedisComputeUnitCounter(redisClient, "test"),
+		},
+	}
+
+	for _, cfg := range backends {
+		cfg := cfg
+		t.Run(cfg.name, func(t *testing.T) {
+			ctx := context.Background()
+			q := NewKeyQuotas(
+				KeyQuota{Rate: 1, DailyComputeUnits: 10},
+				map[string]KeyQuota{"alice": {DailyComputeUnits: 100}},
+				map[string]int64{"eth_getLogs": 40},
+				0,
+				cfg.limiterFactory,
+				cfg.computeUnits,
+			)
+
+			// alice has no rate limit, bob the default one
+			for i := 0; i < 3; i++ {
+				ok, err := q.TakeRequest(ctx, "alice")
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+			ok, err := q.TakeRequest(ctx, "bob")
+			require.NoError(t, err)
+			require.True(t, ok)
+			ok, err = q.TakeRequest(ctx, "bob")
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			// alice has room for two eth_getLogs calls and 20 default weighted calls
+			for i := 0; i < 2; i++ {
+				ok, err := q.TakeComputeUnits(ctx, "alice", "eth_getLogs")
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+			ok, err = q.TakeComputeUnits(ctx, "alice", "eth_getLogs")
+			require.NoError(t, err)
+			require.False(t, ok)
+			for i := 0; i < 20; i++ {
+				ok, err := q.TakeComputeUnits(ctx, "alice", "eth_chainId")
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+			ok, err = q.TakeComputeUnits(ctx, "alice", "eth_chainId")
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			usage, err := q.Usage(ctx, "alice")
+			require.NoError(t, err)
+			require.Equal(t, int64(100), usage.ComputeUnitsUsed)
+			require.Equal(t, int64(100), usage.DailyComputeUnits)
+
+			// bob can't afford eth_getLogs with the default quota
+			ok, err = q.TakeComputeUnits(ctx, "bob", "eth_getLogs")
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			require.NoError(t, q.Reset(ctx, "alice"))
+			usage, err = q.Usage(ctx, "alice")
+			require.NoError(t, err)
+			require.Equal(t, int64(0), usage.ComputeUnitsUsed)
+			ok, err = q.TakeComputeUnits(ctx, "alice", "eth_getLogs")
+			require.NoError(t, err)
+			require.True(t, ok)
+		})
+	}
+}
+
+func TestKeyQuotasAdminHandler(t *testing.T) {
+	ctx := context.Background()
+	q := NewKeyQuotas(
+		KeyQuota{DailyComputeUnits: 10},
+		map[string]KeyQuota{"alice": {DailyComputeUnits: 100}},
+		nil,
+		0,
+		func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+			return NewMemoryFrontendRateLimit(dur, max)
+		},
+		NewMemoryComputeUnitCounter(),
+	)
+	s := &Server{
+		keyQuotas:          q,
+		authenticatedPaths: map[string]string{"secret": "bob"},
+		adminToken:         "token",
+	}
+	srv := httptest.NewServer(s.AdminHandler())
+	defer srv.Close()
+
+	ok, err := q.TakeComputeUnits(ctx, "bob", "eth_chainId")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	do := func(method string, path string, token string) *http.Response {
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return res
+	}
+
+	res := do("GET", "/quotas", "")
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	res.Body.Close()
+
+	res = do("GET", "/quotas", "token")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var usage []*KeyUsage
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&usage))
+	res.Body.Close()
+	require.Len(t, usage, 2)
+	require.Equal(t, "alice", usage[0].Key)
+	require.Equal(t, "bob", usage[1].Key)
+	require.Equal(t, int64(1), usage[1].ComputeUnitsUsed)
+
+	res = do("GET", "/quotas/carol", "token")
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	res.Body.Close()
+
+	res = do("POST", "/quotas/bob/reset", "token")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var bob KeyUsage
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&bob))
+	res.Body.Close()
+	require.Equal(t, int64(0), bob.ComputeUnitsUsed)
+	require.Equal(t, int64(10), bob.DailyComputeUnits)
+}
+
+func TestKeyQuotasAdminHandlerWithoutToken(t *testing.T) {
+	s := &Server{keyQuotas: NewKeyQuotas(KeyQuota{}, nil, nil, 0, nil, NewMemoryComputeUnitCounter())}
+	srv := httptest.NewServer(s.AdminHandler())
+	defer srv.Close()
+
+	// without a configured token, every request is rejected
+	req, err := http.NewRequest("GET", srv.URL+"/quotas", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer ")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	res.Body.Close()
+}
@@ -1,14 +1,19 @@
 package proxyd
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type RPCMethodHandler interface {
@@ -18,6 +23,7 @@ type RPCMethodHandler interface {
 
 type StaticMethodHandler struct {
 	cache     Cache
+	ttl       time.Duration
 	m         sync.RWMutex
 	filterGet func(*RPCReq) bool
 	filterPut func(*RPCReq, *RPCRes) bool
@@ -83,10 +89,258 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	key := e.key(req)
 	value := mustMarshalJSON(res.Result)
 
-	err := e.cache.Put(ctx, key, string(value))
+	err := e.cache.Put(ctx, key, string(value), e.ttl)
 	if err != nil {
 		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
 		return err
 	}
 	return nil
 }
+
+// blockParamKind describes where a method references the block it operates on.
+type blockParamKind uint8
+
+const (
+	// blockParamNumber is a block number or tag param
+	blockParamNumber blockParamKind = iota
+	// blockParamNumberOrHash is an EIP-1898 block number, tag or hash param
+	blockParamNumberOrHash
+	// blockParamFilter is a log filter object with fromBlock/toBlock or blockHash fields
+	blockParamFilter
+)
+
+var errNotCacheable = errors.New("request not cacheable")
+
+// BlockTagMethodHandler caches responses of methods referencing a block by number, tag or hash.
+// Block tags are resolved to block numbers with the consensus block heights, and responses are
+// cached by the resolved params:
+//   - data referenced by block hash, or at or below the finalized block, is cached with the
+//     regular TTL, as it is immutable.
+//   - data above the finalized block, up to the latest block, is cached with the unfinalized TTL
+//     (if enabled), and invalidated on reorgs.
+//   - data above the latest block, or referenced with the pending tag, is never cached.
+type BlockTagMethodHandler struct {
+	cache          Cache
+	consensus      *CacheConsensus
+	ttl            time.Duration
+	unfinalizedTTL time.Duration
+
+	kind     blockParamKind
+	pos      int
+	required bool
+
+	filterPut func(*RPCReq, *RPCRes) bool
+}
+
+func (e *BlockTagMethodHandler) key(req *RPCReq, params []byte, finalized bool, generation uint64) string {
+	h := sha256.New()
+	h.Write(params)
+	signature := fmt.Sprintf("%x", h.Sum(nil))
+	if finalized {
+		return strings.Join([]string{"cache", req.Method, signature}, ":")
+	}
+	return strings.Join([]string{"cache", req.Method, fmt.Sprintf("g%d", generation), signature}, ":")
+}
+
+// resolve returns the cache key and TTL for the request. If allowTags is false, requests
+// with block tags are not cacheable, since the block a tag was resolved to by the backend is unknown.
+func (e *BlockTagMethodHandler) resolve(req *RPCReq, allowTags bool) (string, time.Duration, error) {
+	var heights cacheHeights
+	var ok bool
+	if e.consensus != nil {
+		heights, ok = e.consensus.heights()
+	}
+	params, height, byHash, err := resolveBlockParams(req.Params, e.kind, e.pos, e.required, heights, allowTags)
+	if err != nil {
+		return "", 0, err
+	}
+	if byHash {
+		return e.key(req, params, true, heights.generation), e.ttl, nil
+	}
+	// without consensus block heights, only data referenced by hash can be cached
+	if !ok {
+		return "", 0, errNotCacheable
+	}
+	if height <= uint64(heights.finalized) {
+		return e.key(req, params, true, heights.generation), e.ttl, nil
+	}
+	if e.unfinalizedTTL > 0 && height <= uint64(heights.latest) {
+		return e.key(req, params, false, heights.generation), e.unfinalizedTTL, nil
+	}
+	return "", 0, errNotCacheable
+}
+
+func (e *BlockTagMethodHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*RPCRes, error) {
+	if e.cache == nil {
+		return nil, nil
+	}
+	key, _, err := e.resolve(req, true)
+	if err != nil {
+		return nil, nil
+	}
+
+	val, err := e.cache.Get(ctx, key)
+	if err != nil {
+		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		log.Error("error unmarshalling value from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	return &RPCRes{
+		JSONRPC: req.JSONRPC,
+		Result:  result,
+		ID:      req.ID,
+	}, nil
+}
+
+func (e *BlockTagMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res *RPCRes) error {
+	if e.cache == nil {
+		return nil
+	}
+	if e.filterPut != nil && !e.filterPut(req, res) {
+		return nil
+	}
+	// By the time the response is put, consensus-aware backend groups rewrote block tags
+	// to the block numbers they were served for. Other tags can't be cached safely.
+	key, ttl, err := e.resolve(req, false)
+	if err != nil {
+		return nil
+	}
+
+	value := mustMarshalJSON(res.Result)
+	if err := e.cache.Put(ctx, key, string(value), ttl); err != nil {
+		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
+		return err
+	}
+	return nil
+}
+
+// resolveBlockParams resolves the block tags in the params to block numbers, and returns the
+// canonical params, the highest referenced block number, and whether the block is referenced by hash.
+func resolveBlockParams(raw json.RawMessage, kind blockParamKind, pos int, required bool, heights cacheHeights, allowTags bool) ([]byte, uint64, bool, error) {
+	var p []interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if len(raw) > 0 {
+		if err := dec.Decode(&p); err != nil {
+			return nil, 0, false, err
+		}
+	}
+
+	if len(p) <= pos {
+		// the block param defaults to latest if missing
+		if required || len(p) < pos {
+			return nil, 0, false, errNotCacheable
+		}
+		if kind == blockParamFilter {
+			p = append(p, map[string]interface{}{})
+		} else {
+			p = append(p, "latest")
+		}
+	}
+
+	var height uint64
+	var byHash bool
+	switch kind {
+	case blockParamNumber, blockParamNumberOrHash:
+		var bnh rpc.BlockNumberOrHash
+		if err := remarshal(p[pos], &bnh); err != nil {
+			return nil, 0, false, err
+		}
+		if hash, ok := bnh.Hash(); ok {
+			if kind == blockParamNumber {
+				return nil, 0, false, errNotCacheable
+			}
+			byHash = true
+			p[pos] = map[string]interface{}{"blockHash": hash, "requireCanonical": bnh.RequireCanonical}
+			break
+		}
+		bn, _ := bnh.Number()
+		num, err := resolveBlockNumber(bn, heights, allowTags)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		height = num
+		p[pos] = hexutil.Uint64(num)
+	case blockParamFilter:
+		filter, ok := p[pos].(map[string]interface{})
+		if !ok {
+			return nil, 0, false, errNotCacheable
+		}
+		if _, ok := filter["blockHash"]; ok {
+			byHash = true
+			break
+		}
+		var from, to uint64
+		for _, field := range []string{"fromBlock", "toBlock"} {
+			bn := rpc.LatestBlockNumber
+			if v, ok := filter[field]; ok && v != nil {
+				if err := remarshal(v, &bn); err != nil {
+					return nil, 0, false, err
+				}
+			}
+			num, err := resolveBlockNumber(bn, heights, allowTags)
+			if err != nil {
+				return nil, 0, false, err
+			}
+			filter[field] = hexutil.Uint64(num)
+			if field == "fromBlock" {
+				from = num
+			} else {
+				to = num
+			}
+		}
+		if from > to {
+			return nil, 0, false, errNotCacheable
+		}
+		height = to
+	}
+
+	// re-marshalling yields compact JSON with sorted object keys
+	out, err := json.Marshal(p)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return out, height, byHash, nil
+}
+
+// resolveBlockNumber resolves a block number or tag to a block number.
+func resolveBlockNumber(bn rpc.BlockNumber, heights cacheHeights, allowTags bool) (uint64, error) {
+	switch bn {
+	case rpc.EarliestBlockNumber:
+		return 0, nil
+	case rpc.PendingBlockNumber:
+		return 0, errNotCacheable
+	case rpc.LatestBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		if !allowTags {
+			return 0, errNotCacheable
+		}
+		switch bn {
+		case rpc.SafeBlockNumber:
+			return uint64(heights.safe), nil
+		case rpc.FinalizedBlockNumber:
+			return uint64(heights.finalized), nil
+		default:
+			return uint64(heights.latest), nil
+		}
+	}
+	if bn < 0 {
+		return 0, errNotCacheable
+	}
+	return uint64(bn), nil
+}
+
+func remarshal(in interface{}, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
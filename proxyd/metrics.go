@@ -190,6 +190,25 @@ var (
 		"method",
 	})
 
+	cacheInvalidationsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "cache_invalidations_total",
+		Help:      "Number of invalidations of cached unfinalized data due to reorgs.",
+	})
+
+	getLogsSplitRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "get_logs_split_requests_total",
+		Help:      "Count of eth_getLogs requests split into sub-range requests.",
+	})
+
+	getLogsSplitSubRequests = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "get_logs_split_sub_requests",
+		Help:      "Number of sub-range requests per split eth_getLogs request.",
+		Buckets:   []float64{2, 4, 8, 16, 32, 64, 128, 256, 512, 1024},
+	})
+
 	batchRPCShortCircuitsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: MetricsNamespace,
 		Name:      "batch_rpc_short_circuits_total",
@@ -238,6 +257,33 @@ var (
 		Help:      "Count of errors taking frontend rate limits",
 	})
 
+	keyRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "key_requests_total",
+		Help:      "Count of RPC requests per authenticated key.",
+	}, []string{
+		"auth",
+		"method_name",
+	})
+
+	keyComputeUnitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "key_compute_units_total",
+		Help:      "Count of compute units used per authenticated key.",
+	}, []string{
+		"auth",
+		"method_name",
+	})
+
+	keyQuotaRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "key_quota_rejections_total",
+		Help:      "Count of requests rejected due to key quotas.",
+	}, []string{
+		"auth",
+		"reason",
+	})
+
 	consensusLatestBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: MetricsNamespace,
 		Name:      "group_consensus_latest_block",
@@ -461,6 +507,24 @@ func RecordCacheError(method string) {
 	cacheErrorsTotal.WithLabelValues(method).Inc()
 }
 
+func RecordCacheInvalidation() {
+	cacheInvalidationsTotal.Inc()
+}
+
+func RecordGetLogsSplit(subRequests int) {
+	getLogsSplitRequestsTotal.Inc()
+	getLogsSplitSubRequests.Observe(float64(subRequests))
+}
+
+func RecordKeyComputeUnits(key string, method string, units int64) {
+	keyRequestsTotal.WithLabelValues(key, method).Inc()
+	keyComputeUnitsTotal.WithLabelValues(key, method).Add(float64(units))
+}
+
+func RecordKeyQuotaRejection(key string, reason string) {
+	keyQuotaRejectionsTotal.WithLabelValues(key, reason).Inc()
+}
+
 func RecordBatchSize(size int) {
 	batchSizeHistogram.Observe(float64(size))
 }
@@ -48,6 +48,12 @@ func Start(config *Config) (*Server, func(), error) {
 	if redisClient == nil && config.RateLimit.UseRedis {
 		return nil, nil, errors.New("must specify a Redis URL if UseRedis is true in rate limit config")
 	}
+	if redisClient == nil && config.KeyQuotas.Enabled && config.KeyQuotas.UseRedis {
+		return nil, nil, errors.New("must specify a Redis URL if UseRedis is true in key quotas config")
+	}
+	if config.KeyQuotas.Enabled && config.KeyQuotas.AdminPort != 0 && config.KeyQuotas.AdminToken == "" {
+		return nil, nil, errors.New("must specify an admin_token if admin_port is set in key quotas config")
+	}
 
 	// While modifying shared globals is a bad practice, the alternative
 	// is to clone these errors on every invocation. This is inefficient.
@@ -59,6 +65,9 @@ func Start(config *Config) (*Server, func(), error) {
 	if config.WhitelistErrorMessage != "" {
 		ErrMethodNotWhitelisted.Message = config.WhitelistErrorMessage
 	}
+	if config.KeyQuotas.ErrorMessage != "" {
+		ErrOverKeyQuota.Message = config.KeyQuotas.ErrorMessage
+	}
 	if config.BatchConfig.ErrorMessage != "" {
 		ErrTooManyBatchRequests.Message = config.BatchConfig.ErrorMessage
 	}
@@ -193,6 +202,23 @@ func Start(config *Config) (*Server, func(), error) {
 			Backends:        backends,
 			WeightedRouting: bg.WeightedRouting,
 		}
+
+		if bg.GetLogsSplitRange > 0 {
+			if !bg.ConsensusAware {
+				return nil, nil, fmt.Errorf("backend group %s: get_logs_split_range requires consensus_aware", bgName)
+			}
+			sopts := make([]GetLogsSplitterOpt, 0)
+			if bg.GetLogsMaxRange > 0 {
+				sopts = append(sopts, WithGetLogsMaxRange(bg.GetLogsMaxRange))
+			}
+			if bg.GetLogsMaxConcurrency > 0 {
+				sopts = append(sopts, WithGetLogsMaxConcurrency(bg.GetLogsMaxConcurrency))
+			}
+			if bg.GetLogsMaxResults > 0 {
+				sopts = append(sopts, WithGetLogsMaxResults(bg.GetLogsMaxResults))
+			}
+			backendGroups[bgName].GetLogsSplitter = NewGetLogsSplitter(bg.GetLogsSplitRange, sopts...)
+		}
 	}
 
 	var wsBackendGroup *BackendGroup
@@ -227,17 +253,38 @@ func Start(config *Config) (*Server, func(), error) {
 	}
 
 	var (
-		cache    Cache
-		rpcCache RPCCache
+		cache          Cache
+		rpcCache       RPCCache
+		cacheConsensus *CacheConsensus
 	)
 	if config.Cache.Enabled {
-		if redisClient == nil {
-			log.Warn("redis is not configured, using in-memory cache")
-			cache = newMemoryCache()
-		} else {
-			cache = newRedisCache(redisClient, config.Redis.Namespace)
+		var err error
+		cache, err = newCacheBackend(config, redisClient)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		methodTTLs := make(map[string]time.Duration, len(config.Cache.MethodTTLs))
+		for method, ttl := range config.Cache.MethodTTLs {
+			methodTTLs[method] = time.Duration(ttl)
+		}
+		copts := []RPCCacheOpt{
+			WithCacheTTL(time.Duration(config.Cache.TTL)),
+			WithUnfinalizedCacheTTL(time.Duration(config.Cache.UnfinalizedTTL)),
+			WithMethodCacheTTLs(methodTTLs),
+		}
+		if config.Cache.ConsensusBackendGroup != "" {
+			bgcfg, ok := config.BackendGroups[config.Cache.ConsensusBackendGroup]
+			if !ok {
+				return nil, nil, fmt.Errorf("cache consensus backend group %s does not exist", config.Cache.ConsensusBackendGroup)
+			}
+			if !bgcfg.ConsensusAware {
+				return nil, nil, fmt.Errorf("cache consensus backend group %s must be consensus aware", config.Cache.ConsensusBackendGroup)
+			}
+			cacheConsensus = NewCacheConsensus()
+			copts = append(copts, WithCacheConsensus(cacheConsensus))
 		}
-		rpcCache = newRPCCache(newCacheWithCompression(cache))
+		rpcCache = newRPCCache(newCacheWithCompression(cache), copts...)
 	}
 
 	srv, err := NewServer(
@@ -253,6 +300,8 @@ func Start(config *Config) (*Server, func(), error) {
 		rpcCache,
 		config.RateLimit,
 		config.SenderRateLimit,
+		config.KeyQuotas,
+		config.TxGuard,
 		config.Server.EnableRequestLog,
 		config.Server.MaxRequestBodyLogLen,
 		config.BatchConfig.MaxSize,
@@ -303,6 +352,22 @@ func Start(config *Config) (*Server, func(), error) {
 		log.Info("WS server not enabled (ws_port is set to 0)")
 	}
 
+	if config.KeyQuotas.Enabled && config.KeyQuotas.AdminPort != 0 {
+		adminHost := config.KeyQuotas.AdminHost
+		if adminHost == "" {
+			adminHost = defaultAdminHost
+		}
+		go func() {
+			if err := srv.AdminListenAndServe(adminHost, config.KeyQuotas.AdminPort); err != nil {
+				if errors.Is(err, http.ErrServerClosed) {
+					log.Info("admin server shut down")
+					return
+				}
+				log.Crit("error starting admin server", "err", err)
+			}
+		}()
+	}
+
 	for bgName, bg := range backendGroups {
 		bgcfg := config.BackendGroups[bgName]
 		if bgcfg.ConsensusAware {
@@ -345,9 +410,17 @@ func Start(config *Config) (*Server, func(), error) {
 				copts = append(copts, WithTracker(tracker))
 			}
 
+			if cacheConsensus != nil && bgName == config.Cache.ConsensusBackendGroup {
+				copts = append(copts, WithListener(cacheConsensus.Invalidate))
+			}
+
 			cp := NewConsensusPoller(bg, copts...)
 			bg.Consensus = cp
 
+			if cacheConsensus != nil && bgName == config.Cache.ConsensusBackendGroup {
+				cacheConsensus.SetBlockHeights(cp)
+			}
+
 			if bgcfg.ConsensusHA {
 				tracker.(*RedisConsensusTracker).Init()
 			}
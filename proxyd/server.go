@@ -17,6 +17,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
@@ -70,8 +71,13 @@ type Server struct {
 	limExemptOrigins       []*regexp.Regexp
 	limExemptUserAgents    []*regexp.Regexp
 	globallyLimitedMethods map[string]bool
+	keyQuotas              *KeyQuotas
+	txGuard                *TxGuard
+	txGuardBackendGroup    string
+	adminToken             string
 	rpcServer              *http.Server
 	wsServer               *http.Server
+	adminServer            *http.Server
 	cache                  RPCCache
 	srvMu                  sync.Mutex
 	rateLimitHeader        string
@@ -92,6 +98,8 @@ func NewServer(
 	cache RPCCache,
 	rateLimitConfig RateLimitConfig,
 	senderRateLimitConfig SenderRateLimitConfig,
+	keyQuotasConfig KeyQuotasConfig,
+	txGuardConfig TxGuardConfig,
 	enableRequestLog bool,
 	maxRequestBodyLogLen int,
 	maxBatchSize int,
@@ -170,6 +178,49 @@ func NewServer(
 		senderLim = limiterFactory(time.Duration(senderRateLimitConfig.Interval), senderRateLimitConfig.Limit, "senders")
 	}
 
+	var keyQuotas *KeyQuotas
+	var adminToken string
+	if keyQuotasConfig.Enabled {
+		var computeUnits ComputeUnitCounter
+		if keyQuotasConfig.UseRedis {
+			computeUnits = NewRedisComputeUnitCounter(redisClient, "key_quota")
+		} else {
+			computeUnits = NewMemoryComputeUnitCounter()
+		}
+		quotas := make(map[string]KeyQuota, len(keyQuotasConfig.Keys))
+		for key, quota := range keyQuotasConfig.Keys {
+			quotas[key] = KeyQuota{Rate: quota.Rate, DailyComputeUnits: quota.DailyComputeUnits}
+		}
+		keyQuotas = NewKeyQuotas(
+			KeyQuota{Rate: keyQuotasConfig.DefaultRate, DailyComputeUnits: keyQuotasConfig.DefaultDailyComputeUnits},
+			quotas,
+			keyQuotasConfig.MethodWeights,
+			keyQuotasConfig.DefaultMethodWeight,
+			func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+				if keyQuotasConfig.UseRedis {
+					return NewRedisFrontendRateLimiter(redisClient, dur, max, prefix)
+				}
+				return NewMemoryFrontendRateLimit(dur, max)
+			},
+			computeUnits,
+		)
+		if keyQuotasConfig.AdminToken != "" {
+			var err error
+			adminToken, err = ReadFromEnvOrConfig(keyQuotasConfig.AdminToken)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var txGuard *TxGuard
+	if txGuardConfig.Enabled {
+		if txGuardConfig.BackendGroup != "" && backendGroups[txGuardConfig.BackendGroup] == nil {
+			return nil, fmt.Errorf("tx guard backend group %s does not exist", txGuardConfig.BackendGroup)
+		}
+		txGuard = NewTxGuard(txGuardConfig)
+	}
+
 	rateLimitHeader := defaultRateLimitHeader
 	if rateLimitConfig.IPHeaderOverride != "" {
 		rateLimitHeader = rateLimitConfig.IPHeaderOverride
@@ -200,6 +251,10 @@ func NewServer(
 		limExemptOrigins:       limExemptOrigins,
 		limExemptUserAgents:    limExemptUserAgents,
 		rateLimitHeader:        rateLimitHeader,
+		keyQuotas:              keyQuotas,
+		txGuard:                txGuard,
+		txGuardBackendGroup:    txGuardConfig.BackendGroup,
+		adminToken:             adminToken,
 	}, nil
 }
 
@@ -249,6 +304,9 @@ func (s *Server) Shutdown() {
 	if s.wsServer != nil {
 		_ = s.wsServer.Shutdown(context.Background())
 	}
+	if s.adminServer != nil {
+		_ = s.adminServer.Shutdown(context.Background())
+	}
 	for _, bg := range s.BackendGroups {
 		bg.Shutdown()
 	}
@@ -318,6 +376,23 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.keyQuotas != nil && GetAuthCtx(ctx) != "none" {
+		ok, err := s.keyQuotas.TakeRequest(ctx, GetAuthCtx(ctx))
+		if err != nil {
+			log.Warn("error taking key rate limit", "auth", GetAuthCtx(ctx), "err", err)
+		}
+		if err != nil || !ok {
+			RecordRPCError(ctx, BackendProxyd, "unknown", ErrOverKeyQuota)
+			log.Warn(
+				"key quota limited request",
+				"req_id", GetReqID(ctx),
+				"auth", GetAuthCtx(ctx),
+			)
+			writeRPCError(ctx, w, nil, ErrOverKeyQuota)
+			return
+		}
+	}
+
 	log.Info(
 		"received RPC request",
 		"req_id", GetReqID(ctx),
@@ -489,6 +564,36 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			}
 		}
 
+		// Take the compute units of the method from the daily quota of the key.
+		if s.keyQuotas != nil && GetAuthCtx(ctx) != "none" {
+			ok, err := s.keyQuotas.TakeComputeUnits(ctx, GetAuthCtx(ctx), parsedReq.Method)
+			if err != nil {
+				log.Warn("error taking key compute units", "auth", GetAuthCtx(ctx), "err", err)
+			}
+			if err != nil || !ok {
+				log.Info(
+					"key quota limited RPC",
+					"source", "rpc",
+					"req_id", GetReqID(ctx),
+					"auth", GetAuthCtx(ctx),
+					"method", parsedReq.Method,
+				)
+				RecordRPCError(ctx, BackendProxyd, parsedReq.Method, ErrOverKeyQuota)
+				responses[i] = NewRPCErrorRes(parsedReq.ID, ErrOverKeyQuota)
+				continue
+			}
+		}
+
+		// Run the pre-flight checks of the transaction if enabled. They query the backends, so
+		// they only run once the request is within the quota of the key.
+		if parsedReq.Method == "eth_sendRawTransaction" && s.txGuard != nil {
+			if err := s.guardTransaction(ctx, parsedReq, group); err != nil {
+				RecordRPCError(ctx, BackendProxyd, parsedReq.Method, err)
+				responses[i] = NewRPCErrorRes(parsedReq.ID, err)
+				continue
+			}
+		}
+
 		id := string(parsedReq.ID)
 		// If this is a duplicate Request ID, move the Request to a new batchGroup
 		ids[id]++
@@ -591,7 +696,7 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 	}
 	clientConn.SetReadLimit(s.maxBodySize)
 
-	proxier, err := s.wsBackendGroup.ProxyWS(ctx, clientConn, s.wsMethodWhitelist)
+	proxier, err := s.wsBackendGroup.ProxyWS(ctx, clientConn, s.wsMethodWhitelist, s.keyQuotas)
 	if err != nil {
 		if errors.Is(err, ErrNoBackends) {
 			RecordUnserviceableRequest(ctx, RPCRequestSourceWS)
@@ -675,57 +780,93 @@ func (s *Server) isGlobalLimit(method string) bool {
 }
 
 func (s *Server) rateLimitSender(ctx context.Context, req *RPCReq) error {
+	tx, err := decodeRawTransaction(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Check if the transaction is for the expected chain,
+	// otherwise reject before rate limiting to avoid replay attacks.
+	if !s.isAllowedChainId(tx.ChainId()) {
+		log.Debug("chain id is not allowed", "req_id", GetReqID(ctx))
+		return txpool.ErrInvalidSender
+	}
+
+	from, err := transactionSender(ctx, tx)
+	if err != nil {
+		return err
+	}
+	ok, err := s.senderLim.Take(ctx, fmt.Sprintf("%s:%d", from.Hex(), tx.Nonce()))
+	if err != nil {
+		log.Error("error taking from sender limiter", "err", err, "req_id", GetReqID(ctx))
+		return ErrInternal
+	}
+	if !ok {
+		log.Debug("sender rate limit exceeded", "sender", from.Hex(), "req_id", GetReqID(ctx))
+		return ErrOverSenderRateLimit
+	}
+
+	return nil
+}
+
+func (s *Server) guardTransaction(ctx context.Context, req *RPCReq, group string) error {
+	tx, err := decodeRawTransaction(ctx, req)
+	if err != nil {
+		return err
+	}
+	from, err := transactionSender(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if s.txGuardBackendGroup != "" {
+		group = s.txGuardBackendGroup
+	}
+	if err := s.txGuard.Check(ctx, s.BackendGroups[group], tx, from); err != nil {
+		log.Debug("transaction rejected by tx guard", "sender", from.Hex(), "err", err, "req_id", GetReqID(ctx))
+		return err
+	}
+	return nil
+}
+
+// decodeRawTransaction decodes the transaction of an eth_sendRawTransaction request.
+func decodeRawTransaction(ctx context.Context, req *RPCReq) (*types.Transaction, error) {
 	var params []string
 	if err := json.Unmarshal(req.Params, &params); err != nil {
 		log.Debug("error unmarshaling raw transaction params", "err", err, "req_Id", GetReqID(ctx))
-		return ErrParseErr
+		return nil, ErrParseErr
 	}
 
 	if len(params) != 1 {
 		log.Debug("raw transaction request has invalid number of params", "req_id", GetReqID(ctx))
 		// The error below is identical to the one Geth responds with.
-		return ErrInvalidParams("missing value for required argument 0")
+		return nil, ErrInvalidParams("missing value for required argument 0")
 	}
 
 	var data hexutil.Bytes
 	if err := data.UnmarshalText([]byte(params[0])); err != nil {
 		log.Debug("error decoding raw tx data", "err", err, "req_id", GetReqID(ctx))
 		// Geth returns the raw error from UnmarshalText.
-		return ErrInvalidParams(err.Error())
+		return nil, ErrInvalidParams(err.Error())
 	}
 
 	// Inflates a types.Transaction object from the transaction's raw bytes.
 	tx := new(types.Transaction)
 	if err := tx.UnmarshalBinary(data); err != nil {
 		log.Debug("could not unmarshal transaction", "err", err, "req_id", GetReqID(ctx))
-		return ErrInvalidParams(err.Error())
-	}
-
-	// Check if the transaction is for the expected chain,
-	// otherwise reject before rate limiting to avoid replay attacks.
-	if !s.isAllowedChainId(tx.ChainId()) {
-		log.Debug("chain id is not allowed", "req_id", GetReqID(ctx))
-		return txpool.ErrInvalidSender
+		return nil, ErrInvalidParams(err.Error())
 	}
+	return tx, nil
+}
 
-	// Convert the transaction into a Message object so that we can get the
-	// sender. This method performs an ecrecover, which can be expensive.
+// transactionSender recovers the sender of the transaction.
+// This performs an ecrecover, which can be expensive.
+func transactionSender(ctx context.Context, tx *types.Transaction) (common.Address, error) {
 	msg, err := core.TransactionToMessage(tx, types.LatestSignerForChainID(tx.ChainId()), nil)
 	if err != nil {
 		log.Debug("could not get message from transaction", "err", err, "req_id", GetReqID(ctx))
-		return ErrInvalidParams(err.Error())
-	}
-	ok, err := s.senderLim.Take(ctx, fmt.Sprintf("%s:%d", msg.From.Hex(), tx.Nonce()))
-	if err != nil {
-		log.Error("error taking from sender limiter", "err", err, "req_id", GetReqID(ctx))
-		return ErrInternal
+		return common.Address{}, ErrInvalidParams(err.Error())
 	}
-	if !ok {
-		log.Debug("sender rate limit exceeded", "sender", msg.From.Hex(), "req_id", GetReqID(ctx))
-		return ErrOverSenderRateLimit
-	}
-
-	return nil
+	return msg.From, nil
 }
 
 func (s *Server) isAllowedChainId(chainId *big.Int) bool {
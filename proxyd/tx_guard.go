@@ -0,0 +1,220 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrTxInvalidChainID = &RPCErr{
+		Code:          JSONRPCErrorInternal - 23,
+		Message:       "transaction chain id is not allowed",
+		HTTPErrorCode: 400,
+	}
+	ErrTxNonceTooLow = &RPCErr{
+		Code:          JSONRPCErrorInternal - 24,
+		Message:       "nonce too low",
+		HTTPErrorCode: 400,
+	}
+	ErrTxNonceGapTooLarge = &RPCErr{
+		Code:          JSONRPCErrorInternal - 25,
+		Message:       "nonce gap too large",
+		HTTPErrorCode: 400,
+	}
+	ErrTxInsufficientFunds = &RPCErr{
+		Code:          JSONRPCErrorInternal - 26,
+		Message:       "insufficient funds for gas * price + value",
+		HTTPErrorCode: 400,
+	}
+	ErrTxReverted = &RPCErr{
+		Code:          JSONRPCErrorInternal - 27,
+		Message:       "transaction simulation reverted",
+		HTTPErrorCode: 400,
+	}
+	ErrTxGasTooLow = &RPCErr{
+		Code:          JSONRPCErrorInternal - 28,
+		Message:       "gas limit below estimate",
+		HTTPErrorCode: 400,
+	}
+)
+
+// JSONRPCErrorExecutionReverted is the error code of geth for reverted calls.
+const JSONRPCErrorExecutionReverted = 3
+
+// defaultMaxNonceGap is the max nonce gap if unset, the default number of queued
+// transactions per account of the geth transaction pool.
+const defaultMaxNonceGap = 64
+
+// TxGuard runs pre-flight checks of eth_sendRawTransaction requests against the state of
+// the backends, and rejects transactions that are bound to fail before forwarding them.
+// Checks that can't be completed, e.g. because the backends are unavailable, don't reject
+// the transaction.
+type TxGuard struct {
+	chainIDs     []*big.Int
+	checkNonce   bool
+	maxNonceGap  uint64
+	checkBalance bool
+	simulate     bool
+	estimateGas  bool
+}
+
+func NewTxGuard(cfg TxGuardConfig) *TxGuard {
+	maxNonceGap := cfg.MaxNonceGap
+	if maxNonceGap == 0 {
+		maxNonceGap = defaultMaxNonceGap
+	}
+	return &TxGuard{
+		chainIDs:     cfg.ChainIDs,
+		checkNonce:   cfg.CheckNonce,
+		maxNonceGap:  maxNonceGap,
+		checkBalance: cfg.CheckBalance,
+		simulate:     cfg.Simulate,
+		estimateGas:  cfg.EstimateGas,
+	}
+}
+
+// Check checks the transaction of the given sender against the state of the backend group.
+// It returns an *RPCErr if the transaction should be rejected.
+func (g *TxGuard) Check(ctx context.Context, bg *BackendGroup, tx *types.Transaction, from common.Address) error {
+	if len(g.chainIDs) > 0 && !g.isAllowedChainID(tx.ChainId()) {
+		return ErrTxInvalidChainID
+	}
+
+	// the state checks are batched into a single request to the backend group
+	var reqs []*RPCReq
+	addReq := func(method string, params ...interface{}) int {
+		reqs = append(reqs, &RPCReq{
+			JSONRPC: JSONRPCVersion,
+			Method:  method,
+			Params:  mustMarshalJSON(params),
+			ID:      json.RawMessage(fmt.Sprintf("%d", len(reqs))),
+		})
+		return len(reqs) - 1
+	}
+	nonceIdx, balanceIdx, callIdx, estimateIdx := -1, -1, -1, -1
+	if g.checkNonce {
+		nonceIdx = addReq("eth_getTransactionCount", from, "pending")
+	}
+	if g.checkBalance {
+		balanceIdx = addReq("eth_getBalance", from, "pending")
+	}
+	if g.simulate {
+		callIdx = addReq("eth_call", txCallArgs(tx, from, true), "pending")
+	}
+	if g.estimateGas {
+		estimateIdx = addReq("eth_estimateGas", txCallArgs(tx, from, false), "pending")
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	res, _, err := bg.Forward(ctx, reqs, true)
+	if err != nil || len(res) != len(reqs) {
+		log.Warn("skipping transaction checks, error querying backends", "req_id", GetReqID(ctx), "err", err)
+		return nil
+	}
+	// responses are returned in request order
+	result := func(idx int, v interface{}) bool {
+		if idx < 0 || res[idx].IsError() {
+			return false
+		}
+		if err := remarshal(res[idx].Result, v); err != nil {
+			log.Warn("skipping transaction check, invalid response", "method", reqs[idx].Method, "req_id", GetReqID(ctx), "err", err)
+			return false
+		}
+		return true
+	}
+
+	var nonce hexutil.Uint64
+	if result(nonceIdx, &nonce) {
+		if tx.Nonce() < uint64(nonce) {
+			return txGuardError(ErrTxNonceTooLow, fmt.Sprintf("nonce too low: next nonce %d, tx nonce %d", nonce, tx.Nonce()))
+		}
+		if tx.Nonce()-uint64(nonce) > g.maxNonceGap {
+			return txGuardError(ErrTxNonceGapTooLarge, fmt.Sprintf("nonce gap too large: next nonce %d, tx nonce %d", nonce, tx.Nonce()))
+		}
+	}
+
+	var balance hexutil.Big
+	if result(balanceIdx, &balance) {
+		if cost := tx.Cost(); balance.ToInt().Cmp(cost) < 0 {
+			return txGuardError(ErrTxInsufficientFunds, fmt.Sprintf("insufficient funds for gas * price + value: balance %v, tx cost %v", balance.ToInt(), cost))
+		}
+	}
+
+	if callIdx >= 0 && isRevert(res[callIdx]) {
+		return revertError(res[callIdx].Error)
+	}
+
+	if estimateIdx >= 0 {
+		if isRevert(res[estimateIdx]) {
+			return revertError(res[estimateIdx].Error)
+		}
+		var estimate hexutil.Uint64
+		if result(estimateIdx, &estimate) && tx.Gas() < uint64(estimate) {
+			return txGuardError(ErrTxGasTooLow, fmt.Sprintf("gas limit below estimate: estimate %d, tx gas %d", estimate, tx.Gas()))
+		}
+	}
+
+	return nil
+}
+
+func (g *TxGuard) isAllowedChainID(chainID *big.Int) bool {
+	for _, id := range g.chainIDs {
+		if chainID.Cmp(id) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// txCallArgs returns the call arguments of the transaction. The gas limit is
+// omitted for gas estimation, so the backend searches for the required gas.
+func txCallArgs(tx *types.Transaction, from common.Address, withGas bool) map[string]interface{} {
+	args := map[string]interface{}{
+		"from":  from,
+		"value": (*hexutil.Big)(tx.Value()),
+		"input": hexutil.Bytes(tx.Data()),
+	}
+	if tx.To() != nil {
+		args["to"] = tx.To()
+	}
+	if withGas {
+		args["gas"] = hexutil.Uint64(tx.Gas())
+	}
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+	}
+	if len(tx.AccessList()) > 0 {
+		args["accessList"] = tx.AccessList()
+	}
+	return args
+}
+
+func isRevert(res *RPCRes) bool {
+	return res.IsError() && (res.Error.Code == JSONRPCErrorExecutionReverted ||
+		strings.HasPrefix(res.Error.Message, "execution reverted"))
+}
+
+func revertError(err *RPCErr) *RPCErr {
+	rpcErr := txGuardError(ErrTxReverted, fmt.Sprintf("%s: %s", ErrTxReverted.Message, err.Message))
+	rpcErr.Data = err.Data
+	return rpcErr
+}
+
+func txGuardError(base *RPCErr, msg string) *RPCErr {
+	rpcErr := base.Clone()
+	rpcErr.Message = msg
+	return rpcErr
+}
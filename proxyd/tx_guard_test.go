@@ -0,0 +1,176 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+// txGuardBackend serves the state queried by the tx guard.
+type txGuardBackend struct {
+	nonce    string
+	balance  string
+	callErr  *RPCErr
+	estimate string
+}
+
+func (b *txGuardBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var reqs []*RPCReq
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res := make([]*RPCRes, 0, len(reqs))
+	for _, req := range reqs {
+		switch req.Method {
+		case "eth_getTransactionCount":
+			res = append(res, NewRPCRes(req.ID, b.nonce))
+		case "eth_getBalance":
+			res = append(res, NewRPCRes(req.ID, b.balance))
+		case "eth_call":
+			if b.callErr != nil {
+				res = append(res, NewRPCErrorRes(req.ID, b.callErr))
+			} else {
+				res = append(res, NewRPCRes(req.ID, "0x"))
+			}
+		case "eth_estimateGas":
+			if b.callErr != nil {
+				res = append(res, NewRPCErrorRes(req.ID, b.callErr))
+			} else {
+				res = append(res, NewRPCRes(req.ID, b.estimate))
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func TestTxGuard(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(901)
+	to := common.Address{0xaa}
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     5,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+	})
+	require.NoError(t, err)
+	// cost: 21_000 * 10 + 1000 = 211_000 = 0x33838
+
+	allChecks := TxGuardConfig{
+		ChainIDs:     []*big.Int{chainID},
+		CheckNonce:   true,
+		MaxNonceGap:  2,
+		CheckBalance: true,
+		Simulate:     true,
+		EstimateGas:  true,
+	}
+
+	tests := []struct {
+		name    string
+		cfg     TxGuardConfig
+		backend txGuardBackend
+		err     *RPCErr
+	}{
+		{
+			name:    "valid",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x4", balance: "0x33838", estimate: "0x5208"},
+		},
+		{
+			name:    "invalid chain id",
+			cfg:     TxGuardConfig{ChainIDs: []*big.Int{big.NewInt(10)}},
+			backend: txGuardBackend{},
+			err:     ErrTxInvalidChainID,
+		},
+		{
+			name:    "nonce too low",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x6", balance: "0x33838", estimate: "0x5208"},
+			err:     ErrTxNonceTooLow,
+		},
+		{
+			name:    "nonce gap too large",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x2", balance: "0x33838", estimate: "0x5208"},
+			err:     ErrTxNonceGapTooLarge,
+		},
+		{
+			name:    "insufficient funds",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x5", balance: "0x33837", estimate: "0x5208"},
+			err:     ErrTxInsufficientFunds,
+		},
+		{
+			name:    "reverted",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x5", balance: "0x33838", callErr: &RPCErr{Code: 3, Message: "execution reverted", Data: "0x1234"}},
+			err:     ErrTxReverted,
+		},
+		{
+			name:    "gas below estimate",
+			cfg:     allChecks,
+			backend: txGuardBackend{nonce: "0x5", balance: "0x33838", estimate: "0x5209"},
+			err:     ErrTxGasTooLow,
+		},
+		{
+			name:    "disabled checks",
+			cfg:     TxGuardConfig{},
+			backend: txGuardBackend{nonce: "0x6", balance: "0x0", estimate: "0x5209"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(&tt.backend)
+			defer srv.Close()
+			bg := &BackendGroup{
+				Name:     "main",
+				Backends: []*Backend{NewBackend("node", srv.URL, "", semaphore.NewWeighted(10), WithStrippedTrailingXFF())},
+			}
+			err := NewTxGuard(tt.cfg).Check(context.Background(), bg, tx, from)
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			var rpcErr *RPCErr
+			require.ErrorAs(t, err, &rpcErr)
+			require.Equal(t, tt.err.Code, rpcErr.Code)
+			if tt.err == ErrTxReverted {
+				require.Equal(t, "0x1234", rpcErr.Data)
+			}
+		})
+	}
+
+	t.Run("unavailable backend", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+		bg := &BackendGroup{
+			Name:     "main",
+			Backends: []*Backend{NewBackend("node", srv.URL, "", semaphore.NewWeighted(10), WithStrippedTrailingXFF())},
+		}
+		require.NoError(t, NewTxGuard(allChecks).Check(context.Background(), bg, tx, from))
+	})
+}
+
+func TestTxGuardDefaultMaxNonceGap(t *testing.T) {
+	require.Equal(t, uint64(defaultMaxNonceGap), NewTxGuard(TxGuardConfig{CheckNonce: true}).maxNonceGap)
+	require.Equal(t, uint64(2), NewTxGuard(TxGuardConfig{CheckNonce: true, MaxNonceGap: 2}).maxNonceGap)
+}